@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+)
+
+// dedupCommand rewrites links to their canonical form. Links with the same
+// canonical form are merged into one.
+const dedupCommand = "dedup"
+
+type link struct {
+	id  int64
+	url string
+}
+
+// dedupLinks runs in a single transaction, so it is safe to run it again
+// after a failure.
+func dedupLinks(ctx context.Context, db *sql.DB, types *linktype.Registry) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.Error("failed to rollback transaction", slog.Any("error", err))
+		}
+	}()
+
+	links, err := getLinks(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	groups, order := groupLinks(links, types)
+
+	for _, canonical := range order {
+		if err := mergeLinks(ctx, tx, canonical, groups[canonical]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func getLinks(ctx context.Context, tx *sql.Tx) ([]link, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, url FROM links ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get links: %w", err)
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed to close rows", slog.Any("error", err))
+		}
+	}()
+
+	links := make([]link, 0)
+
+	for rows.Next() {
+		var l link
+
+		if err := rows.Scan(&l.id, &l.url); err != nil {
+			return nil, fmt.Errorf("failed to scan link: %w", err)
+		}
+
+		links = append(links, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return links, nil
+}
+
+// groupLinks groups links by the canonical form. Unsupported links are kept
+// as is. Groups are returned in the order of their first link.
+func groupLinks(links []link, types *linktype.Registry) (map[string][]link, []string) {
+	groups := make(map[string][]link)
+	order := make([]string, 0)
+
+	for _, l := range links {
+		canonical, _, ok := types.Canonicalize(l.url)
+		if !ok {
+			slog.Warn("unsupported link is kept as is", slog.Any("url", l.url))
+
+			continue
+		}
+
+		if _, ok := groups[canonical]; !ok {
+			order = append(order, canonical)
+		}
+
+		groups[canonical] = append(groups[canonical], l)
+	}
+
+	return groups, order
+}
+
+// mergeLinks keeps the link already in the canonical form or the oldest one.
+// A chat tracking several duplicates keeps tags and filters of the kept link
+// and the smallest pinned check interval.
+func mergeLinks(ctx context.Context, tx *sql.Tx, canonical string, links []link) error {
+	kept := links[0]
+
+	for _, l := range links {
+		if l.url == canonical {
+			kept = l
+
+			break
+		}
+	}
+
+	for _, l := range links {
+		if l.id == kept.id {
+			continue
+		}
+
+		if err := mergeLink(ctx, tx, kept.id, l.id); err != nil {
+			return fmt.Errorf("failed to merge link %q into %q: %w", l.url, kept.url, err)
+		}
+
+		slog.Info("merged duplicate link", slog.Any("url", l.url), slog.Any("into", canonical))
+	}
+
+	if kept.url == canonical {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE links SET url = $1 WHERE id = $2`, canonical, kept.id); err != nil {
+		return fmt.Errorf("failed to update url of %q: %w", kept.url, err)
+	}
+
+	slog.Info("canonicalized link", slog.Any("url", kept.url), slog.Any("canonical", canonical))
+
+	return nil
+}
+
+func mergeLink(ctx context.Context, tx *sql.Tx, keptID, duplicateID int64) error {
+	queries := []string{
+		`INSERT INTO links_tags (link_id, tag_id, chat_id)
+		SELECT $1, tag_id, chat_id FROM links_tags
+		WHERE link_id = $2
+			AND chat_id NOT IN (SELECT chat_id FROM links_chats WHERE link_id = $1)
+		ON CONFLICT DO NOTHING`,
+		`INSERT INTO links_filters (link_id, filter_id, chat_id)
+		SELECT $1, filter_id, chat_id FROM links_filters
+		WHERE link_id = $2
+			AND chat_id NOT IN (SELECT chat_id FROM links_chats WHERE link_id = $1)
+		ON CONFLICT DO NOTHING`,
+		// a chat tracking both links keeps the more frequent pinned interval,
+		// zero is not pinned
+		`INSERT INTO links_chats (link_id, chat_id, send_immediately, check_interval)
+		SELECT $1, chat_id, send_immediately, check_interval FROM links_chats
+		WHERE link_id = $2
+		ON CONFLICT (link_id, chat_id) DO UPDATE SET check_interval = CASE
+			WHEN links_chats.check_interval = INTERVAL '0' THEN EXCLUDED.check_interval
+			WHEN EXCLUDED.check_interval = INTERVAL '0' THEN links_chats.check_interval
+			ELSE LEAST(links_chats.check_interval, EXCLUDED.check_interval)
+		END`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, keptID, duplicateID); err != nil {
+			return fmt.Errorf("failed to move link rows: %w", err)
+		}
+	}
+
+	// link states are deleted by the cascade
+	for _, query := range []string{
+		`DELETE FROM links_tags WHERE link_id = $1`,
+		`DELETE FROM links_filters WHERE link_id = $1`,
+		`DELETE FROM links_chats WHERE link_id = $1`,
+		`DELETE FROM links WHERE id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, duplicateID); err != nil {
+			return fmt.Errorf("failed to delete duplicate: %w", err)
+		}
+	}
+
+	return nil
+}
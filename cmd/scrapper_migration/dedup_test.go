@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+const migrationDir = "../../migrations/scrapper"
+
+func setupPostgresContainer(t *testing.T) (db *sql.DB, cleanup func()) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	cont, err := testpostgres.Run(ctx,
+		"postgres:latest",
+		testpostgres.WithDatabase("postgres"),
+		testpostgres.WithUsername("postgres"),
+		testpostgres.WithPassword("postgres"),
+		testpostgres.BasicWaitStrategies(),
+	)
+	require.NoError(t, err, "failed to start postgres container")
+
+	dsn, err := cont.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err, "failed to get connection string")
+
+	db, err = sql.Open("postgres", dsn)
+	require.NoError(t, err, "failed to open db")
+
+	err = goose.RunContext(ctx, "up", db, migrationDir)
+	require.NoError(t, err, "failed to run migrations")
+
+	return db, func() {
+		err := db.Close()
+		assert.NoError(t, err, "failed to close db")
+
+		err = cont.Terminate(ctx)
+		assert.NoError(t, err, "failed to terminate postgres container")
+	}
+}
+
+// getCheckIntervals returns pinned intervals of chats of the link.
+func getCheckIntervals(t *testing.T, db *sql.DB, linkID int64) map[int64]time.Duration {
+	t.Helper()
+
+	rows, err := db.QueryContext(context.Background(), `
+		SELECT chat_id, EXTRACT(EPOCH FROM check_interval)::BIGINT
+		FROM links_chats WHERE link_id = $1
+	`, linkID)
+	require.NoError(t, err, "failed to get link chats")
+
+	defer func() {
+		assert.NoError(t, rows.Close(), "failed to close rows")
+	}()
+
+	intervals := make(map[int64]time.Duration)
+
+	for rows.Next() {
+		var chatID, seconds int64
+
+		require.NoError(t, rows.Scan(&chatID, &seconds), "failed to scan link chat")
+
+		intervals[chatID] = time.Duration(seconds) * time.Second
+	}
+
+	require.NoError(t, rows.Err(), "rows iteration error")
+
+	return intervals
+}
+
+func TestDedupLinks_CheckInterval(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, cleanup := setupPostgresContainer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	types := linktype.New(&config.GitLab{})
+
+	// chat 1 tracks both duplicates, chats 2 and 3 track the duplicate only
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO chats (id) VALUES (1), (2), (3);
+
+		INSERT INTO links (url) VALUES
+			('https://github.com/owner/repo'),
+			('https://GitHub.com/owner/repo/');
+
+		INSERT INTO links_chats (link_id, chat_id, check_interval) VALUES
+			(1, 1, INTERVAL '0'),
+			(2, 1, INTERVAL '1 hour'),
+			(2, 2, INTERVAL '30 minutes'),
+			(2, 3, INTERVAL '0');
+	`)
+	require.NoError(t, err, "failed to insert links")
+
+	require.NoError(t, dedupLinks(ctx, db, types), "failed to dedup links")
+
+	assert.Equal(t, map[int64]time.Duration{
+		1: time.Hour,
+		2: 30 * time.Minute,
+		3: 0,
+	}, getCheckIntervals(t, db, 1), "pinned intervals should survive the merge")
+
+	// chats pinned on both links keep the smaller interval
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO links (url) VALUES ('https://github.com/owner/repo/');
+
+		INSERT INTO links_chats (link_id, chat_id, check_interval) VALUES
+			(3, 1, INTERVAL '15 minutes'),
+			(3, 2, INTERVAL '1 hour');
+	`)
+	require.NoError(t, err, "failed to insert duplicate")
+
+	require.NoError(t, dedupLinks(ctx, db, types), "failed to dedup links")
+
+	assert.Equal(t, map[int64]time.Duration{
+		1: 15 * time.Minute,
+		2: 30 * time.Minute,
+		3: 0,
+	}, getCheckIntervals(t, db, 1))
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/pressly/goose/v3"
 
 	_ "github.com/lib/pq"
@@ -19,10 +21,12 @@ const (
 	ErrorConfigLoad
 	ErrorConnectDatabase
 	ErrorMigrate
+	ErrorDedup
 )
 
 type Config struct {
-	Database Database `envPrefix:"SCRAPPER_DATABASE_"`
+	Database Database      `envPrefix:"SCRAPPER_DATABASE_"`
+	GitLab   config.GitLab `envPrefix:"GITLAB_"`
 }
 
 type Database struct {
@@ -38,7 +42,7 @@ type Database struct {
 func main() {
 	var cmd string
 
-	flag.StringVar(&cmd, "command", "up", "Migration command")
+	flag.StringVar(&cmd, "command", "up", "Migration command or "+dedupCommand)
 	flag.Parse()
 
 	var config Config
@@ -62,28 +66,37 @@ func scrapperMigrate(cfg *Config, cmd string) (code int) {
 		cfg.Database.SSLMode,
 	)
 
+	if cmd == dedupCommand {
+		return dedup(dsn, "scrapper", linktype.New(&cfg.GitLab))
+	}
+
 	return migrate(dsn, "scrapper", cmd)
 }
 
-func migrate(dsn, tpe, cmd string) (code int) {
-	db, err := sql.Open("postgres", dsn)
+func dedup(dsn, tpe string, types *linktype.Registry) (code int) {
+	db, err := connect(dsn, tpe)
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error connect to %s database", tpe), slog.Any("error", err))
-
 		return ErrorConnectDatabase
 	}
 
-	if err := db.Ping(); err != nil {
-		slog.Error(fmt.Sprintf("Error ping %s database", tpe), slog.Any("error", err))
+	defer closeDB(db, tpe)
 
+	if err := dedupLinks(context.Background(), db, types); err != nil {
+		slog.Error(fmt.Sprintf("Error dedup links of %s database", tpe), slog.Any("error", err))
+
+		return ErrorDedup
+	}
+
+	return OkCode
+}
+
+func migrate(dsn, tpe, cmd string) (code int) {
+	db, err := connect(dsn, tpe)
+	if err != nil {
 		return ErrorConnectDatabase
 	}
 
-	defer func() {
-		if err := db.Close(); err != nil {
-			slog.Error(fmt.Sprintf("Error close %s database", tpe), slog.Any("error", err))
-		}
-	}()
+	defer closeDB(db, tpe)
 
 	if err = goose.RunContext(context.Background(), cmd, db, "./migrations/"+tpe); err != nil {
 		slog.Error(fmt.Sprintf("Error migrate %s database", tpe), slog.Any("error", err))
@@ -93,3 +106,27 @@ func migrate(dsn, tpe, cmd string) (code int) {
 
 	return OkCode
 }
+
+func connect(dsn, tpe string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Error connect to %s database", tpe), slog.Any("error", err))
+
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		slog.Error(fmt.Sprintf("Error ping %s database", tpe), slog.Any("error", err))
+		closeDB(db, tpe)
+
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func closeDB(db *sql.DB, tpe string) {
+	if err := db.Close(); err != nil {
+		slog.Error(fmt.Sprintf("Error close %s database", tpe), slog.Any("error", err))
+	}
+}
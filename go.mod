@@ -5,6 +5,7 @@ go 1.23.2
 require (
 	github.com/IBM/sarama v1.45.1
 	github.com/Masterminds/squirrel v1.5.4
+	github.com/andybalholm/cascadia v1.3.3
 	github.com/caarlos0/env/v11 v11.3.1
 	github.com/georgysavva/scany/v2 v2.1.4
 	github.com/go-co-op/gocron/v2 v2.16.1
@@ -28,6 +29,8 @@ require (
 	go.opentelemetry.io/otel/metric v1.35.0
 	go.opentelemetry.io/otel/trace v1.35.0
 	golang.org/x/exp v0.0.0-20250305212735-054e65f0b394
+	golang.org/x/mod v0.24.0
+	golang.org/x/net v0.40.0
 	golang.org/x/sync v0.14.0
 )
 
@@ -121,8 +124,6 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.27.0 // indirect
 	golang.org/x/crypto v0.38.0 // indirect
-	golang.org/x/mod v0.24.0 // indirect
-	golang.org/x/net v0.40.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
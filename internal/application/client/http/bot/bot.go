@@ -33,7 +33,7 @@ func (b *Client) UpdatesPost(ctx context.Context, update *domain.Update) error {
 	rawResp, err := b.client.UpdatesPost(ctx, &bot.LinkUpdate{
 		ChatID:          bot.NewOptInt64(update.ChatID),
 		URL:             bot.NewOptURI(*parsedURL),
-		Message:         bot.NewOptString(update.Message),
+		Event:           bot.NewOptLinkEvent(eventToLinkEvent(&update.Event)),
 		Tags:            update.Tags,
 		SendImmediately: bot.NewOptBool(update.SendImmediately.Value),
 	})
@@ -57,3 +57,22 @@ func (b *Client) UpdatesPost(ctx context.Context, update *domain.Update) error {
 		return NewErrResponse("invalid response type")
 	}
 }
+
+func eventToLinkEvent(event *domain.Event) bot.LinkEvent {
+	linkEvent := bot.LinkEvent{
+		Source:     bot.NewOptString(event.Source),
+		Kind:       bot.NewOptString(event.Kind),
+		ExternalID: bot.NewOptString(event.ExternalID),
+		Author:     bot.NewOptString(event.Author),
+		Title:      bot.NewOptString(event.Title),
+		URL:        bot.NewOptString(event.URL),
+		Body:       bot.NewOptString(event.Body),
+		CreatedAt:  bot.NewOptDateTime(event.CreatedAt),
+	}
+
+	if len(event.Attributes) != 0 {
+		linkEvent.Attributes = bot.NewOptLinkEventAttributes(event.Attributes)
+	}
+
+	return linkEvent
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/bot"
@@ -18,6 +19,32 @@ import (
 
 const exampleLink = "https://example.com"
 
+var (
+	testEvent = domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindIssue,
+		ExternalID: "1",
+		Author:     "user",
+		Title:      "title",
+		URL:        exampleLink,
+		Body:       "body",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+		Attributes: map[string]string{domain.AttrStatus: "open"},
+	}
+
+	testLinkEvent = api.LinkEvent{
+		Source:     api.NewOptString(domain.SourceGitHub),
+		Kind:       api.NewOptString(domain.KindIssue),
+		ExternalID: api.NewOptString("1"),
+		Author:     api.NewOptString("user"),
+		Title:      api.NewOptString("title"),
+		URL:        api.NewOptString(exampleLink),
+		Body:       api.NewOptString("body"),
+		CreatedAt:  api.NewOptDateTime(time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)),
+		Attributes: api.NewOptLinkEventAttributes(api.LinkEventAttributes{domain.AttrStatus: "open"}),
+	}
+)
+
 func TestClient_UpdatesPost_InvalidURL(t *testing.T) {
 	t.Parallel()
 
@@ -44,12 +71,11 @@ func TestClient_UpdatesPost_RequestError(t *testing.T) {
 
 	chatID := int64(12345)
 	tags := []string{"tag1", "tag2"}
-	msg := "msg1"
 
 	expectedRequest := &api.LinkUpdate{
 		ChatID:          api.NewOptInt64(chatID),
 		URL:             api.NewOptURI(*parsedURL),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(true),
 	}
@@ -60,7 +86,7 @@ func TestClient_UpdatesPost_RequestError(t *testing.T) {
 	err = botClient.UpdatesPost(context.Background(), &domain.Update{
 		ChatID:          chatID,
 		URL:             testURL,
-		Message:         msg,
+		Event:           testEvent,
 		Tags:            tags,
 		SendImmediately: domain.NewNull(true),
 	})
@@ -82,12 +108,11 @@ func TestClient_UpdatesPost_Success(t *testing.T) {
 
 	chatID := int64(12345)
 	tags := []string{"tag1", "tag2"}
-	msg := "msg2"
 
 	expectedRequest := &api.LinkUpdate{
 		ChatID:          api.NewOptInt64(chatID),
 		URL:             api.NewOptURI(*parsedURL),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(false),
 	}
@@ -98,7 +123,7 @@ func TestClient_UpdatesPost_Success(t *testing.T) {
 	err = botClient.UpdatesPost(context.Background(), &domain.Update{
 		ChatID:          chatID,
 		URL:             testURL,
-		Message:         msg,
+		Event:           testEvent,
 		Tags:            tags,
 		SendImmediately: domain.NewNull(false),
 	})
@@ -118,12 +143,11 @@ func TestClient_UpdatesPost_ApiErrorResponse(t *testing.T) {
 
 	chatID := int64(12345)
 	tags := []string{"tag1", "tag2"}
-	msg := "msg3"
 
 	expectedRequest := &api.LinkUpdate{
 		ChatID:          api.NewOptInt64(chatID),
 		URL:             api.NewOptURI(*parsedURL),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(false),
 	}
@@ -135,10 +159,10 @@ func TestClient_UpdatesPost_ApiErrorResponse(t *testing.T) {
 	clientMock.On("UpdatesPost", mock.Anything, expectedRequest).Return(apiErr, nil).Once()
 
 	err = botClient.UpdatesPost(context.Background(), &domain.Update{
-		ChatID:  chatID,
-		URL:     testURL,
-		Message: msg,
-		Tags:    tags,
+		ChatID: chatID,
+		URL:    testURL,
+		Event:  testEvent,
+		Tags:   tags,
 	})
 
 	require.Error(t, err, "expected error")
@@ -23,6 +23,66 @@ func (_m *MockExternalClient) EXPECT() *MockExternalClient_Expecter {
 	return &MockExternalClient_Expecter{mock: &_m.Mock}
 }
 
+// LinksCheckPost provides a mock function with given fields: ctx, request, params
+func (_m *MockExternalClient) LinksCheckPost(ctx context.Context, request *scrapper.CheckLinkRequest, params scrapper.LinksCheckPostParams) (scrapper.LinksCheckPostRes, error) {
+	ret := _m.Called(ctx, request, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinksCheckPost")
+	}
+
+	var r0 scrapper.LinksCheckPostRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.CheckLinkRequest, scrapper.LinksCheckPostParams) (scrapper.LinksCheckPostRes, error)); ok {
+		return rf(ctx, request, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.CheckLinkRequest, scrapper.LinksCheckPostParams) scrapper.LinksCheckPostRes); ok {
+		r0 = rf(ctx, request, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scrapper.LinksCheckPostRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scrapper.CheckLinkRequest, scrapper.LinksCheckPostParams) error); ok {
+		r1 = rf(ctx, request, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExternalClient_LinksCheckPost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinksCheckPost'
+type MockExternalClient_LinksCheckPost_Call struct {
+	*mock.Call
+}
+
+// LinksCheckPost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *scrapper.CheckLinkRequest
+//   - params scrapper.LinksCheckPostParams
+func (_e *MockExternalClient_Expecter) LinksCheckPost(ctx interface{}, request interface{}, params interface{}) *MockExternalClient_LinksCheckPost_Call {
+	return &MockExternalClient_LinksCheckPost_Call{Call: _e.mock.On("LinksCheckPost", ctx, request, params)}
+}
+
+func (_c *MockExternalClient_LinksCheckPost_Call) Run(run func(ctx context.Context, request *scrapper.CheckLinkRequest, params scrapper.LinksCheckPostParams)) *MockExternalClient_LinksCheckPost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.CheckLinkRequest), args[2].(scrapper.LinksCheckPostParams))
+	})
+	return _c
+}
+
+func (_c *MockExternalClient_LinksCheckPost_Call) Return(_a0 scrapper.LinksCheckPostRes, _a1 error) *MockExternalClient_LinksCheckPost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExternalClient_LinksCheckPost_Call) RunAndReturn(run func(context.Context, *scrapper.CheckLinkRequest, scrapper.LinksCheckPostParams) (scrapper.LinksCheckPostRes, error)) *MockExternalClient_LinksCheckPost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LinksDelete provides a mock function with given fields: ctx, request, params
 func (_m *MockExternalClient) LinksDelete(ctx context.Context, request *scrapper.RemoveLinkRequest, params scrapper.LinksDeleteParams) (scrapper.LinksDeleteRes, error) {
 	ret := _m.Called(ctx, request, params)
@@ -142,6 +202,66 @@ func (_c *MockExternalClient_LinksGet_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// LinksIntervalPut provides a mock function with given fields: ctx, request, params
+func (_m *MockExternalClient) LinksIntervalPut(ctx context.Context, request *scrapper.SetLinkIntervalRequest, params scrapper.LinksIntervalPutParams) (scrapper.LinksIntervalPutRes, error) {
+	ret := _m.Called(ctx, request, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinksIntervalPut")
+	}
+
+	var r0 scrapper.LinksIntervalPutRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.SetLinkIntervalRequest, scrapper.LinksIntervalPutParams) (scrapper.LinksIntervalPutRes, error)); ok {
+		return rf(ctx, request, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.SetLinkIntervalRequest, scrapper.LinksIntervalPutParams) scrapper.LinksIntervalPutRes); ok {
+		r0 = rf(ctx, request, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scrapper.LinksIntervalPutRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scrapper.SetLinkIntervalRequest, scrapper.LinksIntervalPutParams) error); ok {
+		r1 = rf(ctx, request, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExternalClient_LinksIntervalPut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinksIntervalPut'
+type MockExternalClient_LinksIntervalPut_Call struct {
+	*mock.Call
+}
+
+// LinksIntervalPut is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *scrapper.SetLinkIntervalRequest
+//   - params scrapper.LinksIntervalPutParams
+func (_e *MockExternalClient_Expecter) LinksIntervalPut(ctx interface{}, request interface{}, params interface{}) *MockExternalClient_LinksIntervalPut_Call {
+	return &MockExternalClient_LinksIntervalPut_Call{Call: _e.mock.On("LinksIntervalPut", ctx, request, params)}
+}
+
+func (_c *MockExternalClient_LinksIntervalPut_Call) Run(run func(ctx context.Context, request *scrapper.SetLinkIntervalRequest, params scrapper.LinksIntervalPutParams)) *MockExternalClient_LinksIntervalPut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.SetLinkIntervalRequest), args[2].(scrapper.LinksIntervalPutParams))
+	})
+	return _c
+}
+
+func (_c *MockExternalClient_LinksIntervalPut_Call) Return(_a0 scrapper.LinksIntervalPutRes, _a1 error) *MockExternalClient_LinksIntervalPut_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExternalClient_LinksIntervalPut_Call) RunAndReturn(run func(context.Context, *scrapper.SetLinkIntervalRequest, scrapper.LinksIntervalPutParams) (scrapper.LinksIntervalPutRes, error)) *MockExternalClient_LinksIntervalPut_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LinksPost provides a mock function with given fields: ctx, request, params
 func (_m *MockExternalClient) LinksPost(ctx context.Context, request *scrapper.AddLinkRequest, params scrapper.LinksPostParams) (scrapper.LinksPostRes, error) {
 	ret := _m.Called(ctx, request, params)
@@ -202,6 +322,66 @@ func (_c *MockExternalClient_LinksPost_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// LinksWebhookPut provides a mock function with given fields: ctx, request, params
+func (_m *MockExternalClient) LinksWebhookPut(ctx context.Context, request *scrapper.SetLinkWebhookRequest, params scrapper.LinksWebhookPutParams) (scrapper.LinksWebhookPutRes, error) {
+	ret := _m.Called(ctx, request, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinksWebhookPut")
+	}
+
+	var r0 scrapper.LinksWebhookPutRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.SetLinkWebhookRequest, scrapper.LinksWebhookPutParams) (scrapper.LinksWebhookPutRes, error)); ok {
+		return rf(ctx, request, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.SetLinkWebhookRequest, scrapper.LinksWebhookPutParams) scrapper.LinksWebhookPutRes); ok {
+		r0 = rf(ctx, request, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scrapper.LinksWebhookPutRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scrapper.SetLinkWebhookRequest, scrapper.LinksWebhookPutParams) error); ok {
+		r1 = rf(ctx, request, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExternalClient_LinksWebhookPut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinksWebhookPut'
+type MockExternalClient_LinksWebhookPut_Call struct {
+	*mock.Call
+}
+
+// LinksWebhookPut is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request *scrapper.SetLinkWebhookRequest
+//   - params scrapper.LinksWebhookPutParams
+func (_e *MockExternalClient_Expecter) LinksWebhookPut(ctx interface{}, request interface{}, params interface{}) *MockExternalClient_LinksWebhookPut_Call {
+	return &MockExternalClient_LinksWebhookPut_Call{Call: _e.mock.On("LinksWebhookPut", ctx, request, params)}
+}
+
+func (_c *MockExternalClient_LinksWebhookPut_Call) Run(run func(ctx context.Context, request *scrapper.SetLinkWebhookRequest, params scrapper.LinksWebhookPutParams)) *MockExternalClient_LinksWebhookPut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.SetLinkWebhookRequest), args[2].(scrapper.LinksWebhookPutParams))
+	})
+	return _c
+}
+
+func (_c *MockExternalClient_LinksWebhookPut_Call) Return(_a0 scrapper.LinksWebhookPutRes, _a1 error) *MockExternalClient_LinksWebhookPut_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExternalClient_LinksWebhookPut_Call) RunAndReturn(run func(context.Context, *scrapper.SetLinkWebhookRequest, scrapper.LinksWebhookPutParams) (scrapper.LinksWebhookPutRes, error)) *MockExternalClient_LinksWebhookPut_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TgChatIDDelete provides a mock function with given fields: ctx, params
 func (_m *MockExternalClient) TgChatIDDelete(ctx context.Context, params scrapper.TgChatIDDeleteParams) (scrapper.TgChatIDDeleteRes, error) {
 	ret := _m.Called(ctx, params)
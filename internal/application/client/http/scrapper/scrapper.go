@@ -3,19 +3,36 @@ package scrapper
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/scrapper"
 )
 
 type ExternalClient interface {
+	LinksCheckPost(
+		ctx context.Context,
+		request *scrapper.CheckLinkRequest,
+		params scrapper.LinksCheckPostParams,
+	) (scrapper.LinksCheckPostRes, error)
 	LinksDelete(
 		ctx context.Context,
 		request *scrapper.RemoveLinkRequest,
 		params scrapper.LinksDeleteParams,
 	) (scrapper.LinksDeleteRes, error)
 	LinksGet(ctx context.Context, params scrapper.LinksGetParams) (scrapper.LinksGetRes, error)
+	LinksIntervalPut(
+		ctx context.Context,
+		request *scrapper.SetLinkIntervalRequest,
+		params scrapper.LinksIntervalPutParams,
+	) (scrapper.LinksIntervalPutRes, error)
+	LinksWebhookPut(
+		ctx context.Context,
+		request *scrapper.SetLinkWebhookRequest,
+		params scrapper.LinksWebhookPutParams,
+	) (scrapper.LinksWebhookPutRes, error)
 	LinksPost(
 		ctx context.Context,
 		request *scrapper.AddLinkRequest,
@@ -87,6 +104,10 @@ func (s *Client) AddLink(ctx context.Context, link *domain.Link) error {
 		return nil
 
 	case *scrapper.ApiErrorResponse:
+		if resp.Code.Value == http.StatusText(http.StatusBadRequest) {
+			return NewErrUserResponse(resp.Description.Value)
+		}
+
 		return NewErrResponse(fmt.Sprintf("failed to add link: %s", resp.Description.Value))
 
 	case *scrapper.LinksPostTooManyRequests:
@@ -155,6 +176,127 @@ func (s *Client) GetLinks(ctx context.Context, chatID int64, tag string) ([]*dom
 	}
 }
 
+// SetLinkInterval pins the check interval of the link, zero interval returns
+// the link to the adaptive schedule.
+func (s *Client) SetLinkInterval(ctx context.Context, chatID int64, linkURL string, interval time.Duration) error {
+	parsedURL, err := url.Parse(linkURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	rawResp, err := s.client.LinksIntervalPut(ctx, &scrapper.SetLinkIntervalRequest{
+		Link: scrapper.NewOptURI(*parsedURL),
+		IntervalSeconds: scrapper.OptInt64{
+			Value: int64(interval / time.Second),
+			Set:   interval != 0,
+		},
+	}, scrapper.LinksIntervalPutParams{
+		TgChatID: chatID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set link interval: %w", err)
+	}
+
+	switch resp := rawResp.(type) {
+	case *scrapper.LinksIntervalPutOK:
+		return nil
+
+	case *scrapper.LinksIntervalPutBadRequest:
+		if resp.Code.Value == http.StatusText(http.StatusBadRequest) {
+			return NewErrUserResponse(resp.Description.Value)
+		}
+
+		return NewErrResponse(fmt.Sprintf("failed to set link interval: %s", resp.Description.Value))
+
+	case *scrapper.LinksIntervalPutNotFound:
+		return NewErrUserResponse(fmt.Sprintf("Ссылка %q не найдена", linkURL))
+
+	case *scrapper.LinksIntervalPutTooManyRequests:
+		return NewErrUserResponse("Слишком много запросов. Повторите, пожалуйста, через некоторое время")
+
+	default:
+		return NewErrResponse("invalid response type")
+	}
+}
+
+// SetLinkWebhook switches the link to updates from webhooks, false returns the
+// link to scheduled checks.
+func (s *Client) SetLinkWebhook(ctx context.Context, chatID int64, linkURL string, webhook bool) error {
+	parsedURL, err := url.Parse(linkURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	rawResp, err := s.client.LinksWebhookPut(ctx, &scrapper.SetLinkWebhookRequest{
+		Link:    scrapper.NewOptURI(*parsedURL),
+		Webhook: scrapper.NewOptBool(webhook),
+	}, scrapper.LinksWebhookPutParams{
+		TgChatID: chatID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set link webhook: %w", err)
+	}
+
+	switch resp := rawResp.(type) {
+	case *scrapper.LinksWebhookPutOK:
+		return nil
+
+	case *scrapper.LinksWebhookPutBadRequest:
+		if resp.Code.Value == http.StatusText(http.StatusBadRequest) {
+			return NewErrUserResponse(resp.Description.Value)
+		}
+
+		return NewErrResponse(fmt.Sprintf("failed to set link webhook: %s", resp.Description.Value))
+
+	case *scrapper.LinksWebhookPutNotFound:
+		return NewErrUserResponse(fmt.Sprintf("Ссылка %q не найдена", linkURL))
+
+	case *scrapper.LinksWebhookPutTooManyRequests:
+		return NewErrUserResponse("Слишком много запросов. Повторите, пожалуйста, через некоторое время")
+
+	default:
+		return NewErrResponse("invalid response type")
+	}
+}
+
+// CheckLink checks the link out of the schedule and returns the number of new
+// updates.
+func (s *Client) CheckLink(ctx context.Context, chatID int64, linkURL string) (int, error) {
+	parsedURL, err := url.Parse(linkURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	rawResp, err := s.client.LinksCheckPost(ctx, &scrapper.CheckLinkRequest{
+		Link: scrapper.NewOptURI(*parsedURL),
+	}, scrapper.LinksCheckPostParams{
+		TgChatID: chatID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to check link: %w", err)
+	}
+
+	switch resp := rawResp.(type) {
+	case *scrapper.CheckLinkResponse:
+		return int(resp.Updates.Value), nil
+
+	case *scrapper.LinksCheckPostBadRequest:
+		return 0, NewErrResponse(fmt.Sprintf("failed to check link: %s", resp.Description.Value))
+
+	case *scrapper.LinksCheckPostNotFound:
+		return 0, NewErrUserResponse(fmt.Sprintf("Ссылка %q не найдена", linkURL))
+
+	case *scrapper.LinksCheckPostConflict:
+		return 0, NewErrUserResponse("Ссылка уже проверяется. Повторите, пожалуйста, через некоторое время")
+
+	case *scrapper.LinksCheckPostTooManyRequests:
+		return 0, NewErrUserResponse("Слишком много запросов. Повторите, пожалуйста, через некоторое время")
+
+	default:
+		return 0, NewErrResponse("invalid response type")
+	}
+}
+
 func linksToDomainLinks(links []scrapper.LinkResponse) []*domain.Link {
 	domainLinks := make([]*domain.Link, 0, len(links))
 	for i := range links {
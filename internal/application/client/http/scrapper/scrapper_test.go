@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper/mocks"
@@ -176,6 +178,38 @@ func TestClient_AddLink_APIError(t *testing.T) {
 	)
 }
 
+func TestClient_AddLink_InvalidFilters(t *testing.T) {
+	t.Parallel()
+
+	clientMock := mocks.NewMockExternalClient(t)
+	client := scrapper.NewClient(clientMock)
+
+	link := &domain.Link{
+		URL:     exampleLink,
+		Filters: []string{"(user:alice"},
+		ChatID:  12345,
+	}
+	parsedURL, _ := url.Parse(link.URL)
+	expectedRequest := &api.AddLinkRequest{
+		Link:            api.NewOptURI(*parsedURL),
+		Filters:         link.Filters,
+		SendImmediately: api.NewOptBool(link.SendImmediately.Value),
+	}
+
+	clientMock.On("LinksPost", mock.Anything, expectedRequest, api.LinksPostParams{TgChatID: link.ChatID}).
+		Return(&api.ApiErrorResponse{
+			Code:        api.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: api.NewOptString("Некорректный фильтр: позиция 1: незакрытая скобка"),
+		}, nil).
+		Once()
+
+	err := client.AddLink(context.Background(), link)
+
+	userErr := scrapper.ErrUserResponse{}
+	require.True(t, errors.As(err, &userErr), "AddLink should return user error")
+	assert.Equal(t, "Некорректный фильтр: позиция 1: незакрытая скобка", userErr.Message)
+}
+
 func TestClient_DeleteLink_Success(t *testing.T) {
 	t.Parallel()
 
@@ -355,3 +389,205 @@ func TestClient_GetLinks_APIError(t *testing.T) {
 	)
 	assert.Nil(t, links, "GetLinks should return empty list")
 }
+
+func TestClient_SetLinkInterval_Success(t *testing.T) {
+	t.Parallel()
+
+	clientMock := mocks.NewMockExternalClient(t)
+	client := scrapper.NewClient(clientMock)
+
+	chatID := int64(12345)
+	parsedURL, _ := url.Parse(exampleLink)
+
+	clientMock.On("LinksIntervalPut", mock.Anything, &api.SetLinkIntervalRequest{
+		Link:            api.NewOptURI(*parsedURL),
+		IntervalSeconds: api.NewOptInt64(600),
+	}, api.LinksIntervalPutParams{TgChatID: chatID}).
+		Return(&api.LinksIntervalPutOK{}, nil).
+		Once()
+	clientMock.On("LinksIntervalPut", mock.Anything, &api.SetLinkIntervalRequest{
+		Link: api.NewOptURI(*parsedURL),
+	}, api.LinksIntervalPutParams{TgChatID: chatID}).
+		Return(&api.LinksIntervalPutOK{}, nil).
+		Once()
+
+	err := client.SetLinkInterval(context.Background(), chatID, exampleLink, 10*time.Minute)
+	require.NoError(t, err, "SetLinkInterval should not return error")
+
+	err = client.SetLinkInterval(context.Background(), chatID, exampleLink, 0)
+	require.NoError(t, err, "SetLinkInterval should not return error")
+}
+
+func TestClient_SetLinkInterval_APIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp api.LinksIntervalPutRes
+		user bool
+	}{
+		{
+			name: "bad request",
+			resp: &api.LinksIntervalPutBadRequest{
+				Code:        api.NewOptString(http.StatusText(http.StatusBadRequest)),
+				Description: api.NewOptString("Интервал должен быть не меньше 1m0s"),
+			},
+			user: true,
+		},
+		{
+			name: "internal error",
+			resp: &api.LinksIntervalPutBadRequest{
+				Code:        api.NewOptString(http.StatusText(http.StatusInternalServerError)),
+				Description: api.NewOptString("error description"),
+			},
+		},
+		{name: "not found", resp: &api.LinksIntervalPutNotFound{}, user: true},
+		{name: "too many requests", resp: &api.LinksIntervalPutTooManyRequests{}, user: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientMock := mocks.NewMockExternalClient(t)
+			client := scrapper.NewClient(clientMock)
+
+			clientMock.On("LinksIntervalPut", mock.Anything, mock.Anything, mock.Anything).Return(tt.resp, nil).Once()
+
+			err := client.SetLinkInterval(context.Background(), 1, exampleLink, time.Minute)
+
+			if tt.user {
+				assert.ErrorAs(t, err, &scrapper.ErrUserResponse{}, "SetLinkInterval should return user error")
+			} else {
+				assert.ErrorAs(t, err, &scrapper.ErrResponse{}, "SetLinkInterval should return error response")
+			}
+		})
+	}
+}
+
+func TestClient_SetLinkWebhook_Success(t *testing.T) {
+	t.Parallel()
+
+	clientMock := mocks.NewMockExternalClient(t)
+	client := scrapper.NewClient(clientMock)
+
+	chatID := int64(12345)
+	parsedURL, _ := url.Parse(exampleLink)
+
+	clientMock.On("LinksWebhookPut", mock.Anything, &api.SetLinkWebhookRequest{
+		Link:    api.NewOptURI(*parsedURL),
+		Webhook: api.NewOptBool(true),
+	}, api.LinksWebhookPutParams{TgChatID: chatID}).
+		Return(&api.LinksWebhookPutOK{}, nil).
+		Once()
+
+	err := client.SetLinkWebhook(context.Background(), chatID, exampleLink, true)
+	require.NoError(t, err, "SetLinkWebhook should not return error")
+}
+
+func TestClient_SetLinkWebhook_APIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp api.LinksWebhookPutRes
+		user bool
+	}{
+		{
+			name: "bad request",
+			resp: &api.LinksWebhookPutBadRequest{
+				Code:        api.NewOptString(http.StatusText(http.StatusBadRequest)),
+				Description: api.NewOptString("Вебхуки не настроены"),
+			},
+			user: true,
+		},
+		{
+			name: "internal error",
+			resp: &api.LinksWebhookPutBadRequest{
+				Code:        api.NewOptString(http.StatusText(http.StatusInternalServerError)),
+				Description: api.NewOptString("error description"),
+			},
+		},
+		{name: "not found", resp: &api.LinksWebhookPutNotFound{}, user: true},
+		{name: "too many requests", resp: &api.LinksWebhookPutTooManyRequests{}, user: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientMock := mocks.NewMockExternalClient(t)
+			client := scrapper.NewClient(clientMock)
+
+			clientMock.On("LinksWebhookPut", mock.Anything, mock.Anything, mock.Anything).Return(tt.resp, nil).Once()
+
+			err := client.SetLinkWebhook(context.Background(), 1, exampleLink, false)
+
+			if tt.user {
+				assert.ErrorAs(t, err, &scrapper.ErrUserResponse{}, "SetLinkWebhook should return user error")
+			} else {
+				assert.ErrorAs(t, err, &scrapper.ErrResponse{}, "SetLinkWebhook should return error response")
+			}
+		})
+	}
+}
+
+func TestClient_CheckLink_Success(t *testing.T) {
+	t.Parallel()
+
+	clientMock := mocks.NewMockExternalClient(t)
+	client := scrapper.NewClient(clientMock)
+
+	chatID := int64(12345)
+	parsedURL, _ := url.Parse(exampleLink)
+
+	clientMock.On(
+		"LinksCheckPost",
+		mock.Anything,
+		&api.CheckLinkRequest{Link: api.NewOptURI(*parsedURL)},
+		api.LinksCheckPostParams{TgChatID: chatID},
+	).
+		Return(&api.CheckLinkResponse{Updates: api.NewOptInt32(3)}, nil).
+		Once()
+
+	updates, err := client.CheckLink(context.Background(), chatID, exampleLink)
+	require.NoError(t, err, "CheckLink should not return error")
+	assert.Equal(t, 3, updates, "CheckLink should return the number of updates")
+}
+
+func TestClient_CheckLink_APIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp api.LinksCheckPostRes
+		user bool
+	}{
+		{
+			name: "internal error",
+			resp: &api.LinksCheckPostBadRequest{Description: api.NewOptString("error description")},
+		},
+		{name: "not found", resp: &api.LinksCheckPostNotFound{}, user: true},
+		{name: "being checked", resp: &api.LinksCheckPostConflict{}, user: true},
+		{name: "too many requests", resp: &api.LinksCheckPostTooManyRequests{}, user: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clientMock := mocks.NewMockExternalClient(t)
+			client := scrapper.NewClient(clientMock)
+
+			clientMock.On("LinksCheckPost", mock.Anything, mock.Anything, mock.Anything).Return(tt.resp, nil).Once()
+
+			_, err := client.CheckLink(context.Background(), 1, exampleLink)
+
+			if tt.user {
+				assert.ErrorAs(t, err, &scrapper.ErrUserResponse{}, "CheckLink should return user error")
+			} else {
+				assert.ErrorAs(t, err, &scrapper.ErrResponse{}, "CheckLink should return error response")
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -42,7 +43,7 @@ func (c *Consumer) Run(ctx context.Context) error {
 			}
 
 			if update.SendImmediately.Value {
-				tgMessage := tgbotapi.NewMessage(update.ChatID, update.Message)
+				tgMessage := tgbotapi.NewMessage(update.ChatID, render.Event(&update.Event))
 				tgMessage.ParseMode = tgbotapi.ModeHTML
 
 				c.channels.TelegramResp() <- tgMessage
@@ -9,6 +9,7 @@ import (
 	"github.com/IBM/sarama"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/kafka"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/kafka/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	pkgkafka "github.com/es-debug/backend-academy-2024-go-template/pkg/kafka"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -22,7 +23,7 @@ func TestConsumer_Run_ImmediateSending(t *testing.T) {
 
 	update := &domain.Update{
 		ChatID:          123456,
-		Message:         "test message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test message"},
 		SendImmediately: domain.NewNull(true),
 	}
 
@@ -53,7 +54,7 @@ func TestConsumer_Run_ImmediateSending(t *testing.T) {
 	resp := <-channels.TelegramResp()
 	tgMessage, ok := resp.(tgbotapi.MessageConfig)
 	assert.True(t, ok, "expected tgbotapi.MessageConfig")
-	assert.Equal(t, update.Message, tgMessage.Text, "expected message to be sent to Telegram")
+	assert.Equal(t, render.Event(&update.Event), tgMessage.Text, "expected message to be sent to Telegram")
 
 	msgAck := <-messageChannels.Ack()
 	assert.Equal(t, msg, msgAck, "expected message to be acked")
@@ -64,7 +65,7 @@ func TestConsumer_Run_StoreInDB(t *testing.T) {
 
 	update := &domain.Update{
 		ChatID:          123456,
-		Message:         "test message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test message"},
 		SendImmediately: domain.NewNull(false),
 	}
 
@@ -103,7 +104,7 @@ func TestConsumer_Run_StoreInDBError(t *testing.T) {
 
 	update := &domain.Update{
 		ChatID:          123456,
-		Message:         "test message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test message"},
 		SendImmediately: domain.NewNull(false),
 	}
 
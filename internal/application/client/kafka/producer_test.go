@@ -40,7 +40,7 @@ func TestProducer_UpdatesPost(t *testing.T) {
 
 	testUpdate := &domain.Update{
 		URL:             "test-url",
-		Message:         "test-message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test-message"},
 		ChatID:          123456,
 		SendImmediately: domain.NewNull(true),
 	}
@@ -77,7 +77,7 @@ func TestProducer_UpdatesPost_Error(t *testing.T) {
 
 	testUpdate := &domain.Update{
 		URL:             "test-url",
-		Message:         "test-message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test-message"},
 		ChatID:          123456,
 		SendImmediately: domain.NewNull(true),
 	}
@@ -115,7 +115,7 @@ func TestProducer_UpdatesPost_CircuitBreaker(t *testing.T) {
 
 	testUpdate := &domain.Update{
 		URL:             "test-url",
-		Message:         "test-message",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test-message"},
 		ChatID:          123456,
 		SendImmediately: domain.NewNull(true),
 	}
@@ -0,0 +1,20 @@
+package filter
+
+import "fmt"
+
+// ErrParse is shown to users as is, so the message is in Russian.
+type ErrParse struct {
+	Pos     int
+	Message string
+}
+
+func NewErrParse(pos int, format string, args ...any) error {
+	return ErrParse{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e ErrParse) Error() string {
+	return fmt.Sprintf("позиция %d: %s", e.Pos+1, e.Message)
+}
@@ -0,0 +1,481 @@
+// Package filter implements filter expressions of tracked links.
+//
+// An expression is a sequence of conditions joined by AND (the default), OR
+// and NOT. A condition is either "field:value" or a bare word searched in the
+// title and the body. A leading minus negates a condition and parentheses
+// group them:
+//
+//	type:comment -user:bot ("memory leak" OR regex:"panic: .*")
+//
+// The "=" operator is a synonym of ":". Scores are compared with >=, >, <=,
+// <, = and ":". Fields without a value in the event never match.
+package filter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// ScopeField narrows a stateful check to a part of the resource, e.g. a CSS
+// selector of a page region. It is allowed at the top level only.
+const ScopeField = "selector"
+
+var kindAliases = map[string]string{
+	"pr": domain.KindPullRequest,
+	"mr": domain.KindMergeRequest,
+}
+
+var kinds = map[string]bool{
+	domain.KindIssue:        true,
+	domain.KindPullRequest:  true,
+	domain.KindMergeRequest: true,
+	domain.KindComment:      true,
+	domain.KindCodeComment:  true,
+	domain.KindReview:       true,
+	domain.KindLabeled:      true,
+	domain.KindUnlabeled:    true,
+	domain.KindClosed:       true,
+	domain.KindReopened:     true,
+	domain.KindMerged:       true,
+	domain.KindCommit:       true,
+	domain.KindCommits:      true,
+	domain.KindRelease:      true,
+	domain.KindWorkflowRun:  true,
+	domain.KindAnswer:       true,
+	domain.KindEdited:       true,
+	domain.KindAccepted:     true,
+	domain.KindUnaccepted:   true,
+	domain.KindDuplicate:    true,
+	domain.KindBounty:       true,
+	domain.KindVotes:        true,
+	domain.KindEntry:        true,
+	domain.KindVersion:      true,
+	domain.KindChange:       true,
+}
+
+// Filter is a parsed set of link filters. The zero Filter matches every event.
+type Filter struct {
+	root  node
+	scope string
+}
+
+// Parse parses filters of a link. Every filter is a separate expression and
+// an event must match all of them.
+func Parse(filters []string) (*Filter, error) {
+	f := &Filter{}
+	nodes := make([]node, 0, len(filters))
+
+	for _, expr := range filters {
+		p := parser{lexer: lexer{input: expr}}
+
+		root, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		if root == nil {
+			continue
+		}
+
+		scope, err := topScope(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if scope != "" {
+			f.scope = scope
+		}
+
+		nodes = append(nodes, root)
+	}
+
+	switch len(nodes) {
+	case 0:
+	case 1:
+		f.root = nodes[0]
+	default:
+		f.root = andNode(nodes)
+	}
+
+	return f, nil
+}
+
+func (f *Filter) Match(event *domain.Event) bool {
+	return f.root == nil || f.root.match(event)
+}
+
+// Scope returns the value of the selector condition or an empty string.
+func (f *Filter) Scope() string {
+	return f.scope
+}
+
+type node interface {
+	match(event *domain.Event) bool
+}
+
+type andNode []node
+
+func (n andNode) match(event *domain.Event) bool {
+	for _, child := range n {
+		if !child.match(event) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type orNode []node
+
+func (n orNode) match(event *domain.Event) bool {
+	for _, child := range n {
+		if child.match(event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type notNode struct {
+	child node
+}
+
+func (n notNode) match(event *domain.Event) bool {
+	return !n.child.match(event)
+}
+
+type matchFunc func(event *domain.Event) bool
+
+func (f matchFunc) match(event *domain.Event) bool {
+	return f(event)
+}
+
+type scopeNode string
+
+func (scopeNode) match(*domain.Event) bool {
+	return true
+}
+
+// topScope finds the selector condition. Under OR and NOT the scope of an
+// event would depend on the event itself, so such expressions are rejected.
+func topScope(root node) (string, error) {
+	scope := ""
+
+	var walk func(n node, top bool) error
+
+	walk = func(n node, top bool) error {
+		switch n := n.(type) {
+		case scopeNode:
+			if !top {
+				return NewErrParse(0, "поле %s нельзя использовать внутри OR и NOT", ScopeField)
+			}
+
+			scope = string(n)
+
+		case andNode:
+			for _, child := range n {
+				if err := walk(child, top); err != nil {
+					return err
+				}
+			}
+
+		case orNode:
+			for _, child := range n {
+				if err := walk(child, false); err != nil {
+					return err
+				}
+			}
+
+		case notNode:
+			return walk(n.child, false)
+		}
+
+		return nil
+	}
+
+	return scope, walk(root, true)
+}
+
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+func (p *parser) parse() (node, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenEOF {
+		return nil, nil //nolint:nilnil // an empty expression matches everything
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokenEOF {
+		return nil, NewErrParse(p.tok.pos, "лишняя закрывающая скобка")
+	}
+
+	return root, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := orNode{first}
+
+	for p.tok.kind == tokenOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, next)
+	}
+
+	if len(nodes) == 1 {
+		return first, nil
+	}
+
+	return nodes, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	nodes := andNode{}
+
+	for {
+		if p.tok.kind == tokenAnd {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, next)
+
+		switch p.tok.kind {
+		case tokenEOF, tokenOr, tokenRParen:
+			if len(nodes) == 1 {
+				return nodes[0], nil
+			}
+
+			return nodes, nil
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.tok.kind == tokenNot {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{child: child}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+
+	switch tok.kind {
+	case tokenLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		child, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind != tokenRParen {
+			return nil, NewErrParse(tok.pos, "незакрытая скобка")
+		}
+
+		return child, p.advance()
+
+	case tokenTerm:
+		n, err := newTerm(&tok)
+		if err != nil {
+			return nil, err
+		}
+
+		return n, p.advance()
+
+	case tokenWord:
+		return keyword(tok.value), p.advance()
+
+	case tokenEOF:
+		return nil, NewErrParse(tok.pos, "неожиданный конец выражения")
+
+	default:
+		return nil, NewErrParse(tok.pos, "ожидалось условие")
+	}
+}
+
+func newTerm(tok *token) (node, error) {
+	n, err := fieldNode(tok)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok.key != "score" && tok.op != ":" && tok.op != "=" {
+		return nil, NewErrParse(tok.pos, "оператор %s применим только к полю score", tok.op)
+	}
+
+	return n, nil
+}
+
+//nolint:cyclop // one case per field
+func fieldNode(tok *token) (node, error) {
+	switch tok.key {
+	case "user", "author":
+		return equals(tok.value, func(e *domain.Event) string { return e.Author }), nil
+
+	case "type", "kind":
+		kind := strings.ToLower(tok.value)
+		if alias, ok := kindAliases[kind]; ok {
+			kind = alias
+		}
+
+		if !kinds[kind] {
+			return nil, NewErrParse(tok.pos, "неизвестный тип события %q", tok.value)
+		}
+
+		return equals(kind, func(e *domain.Event) string { return e.Kind }), nil
+
+	case "status":
+		return equals(tok.value, func(e *domain.Event) string { return e.Attributes[domain.AttrStatus] }), nil
+
+	case "branch":
+		return equals(tok.value, func(e *domain.Event) string { return e.Attributes[domain.AttrBranch] }), nil
+
+	case "label":
+		return label(tok.value), nil
+
+	case "keyword":
+		return keyword(tok.value), nil
+
+	case "regex":
+		return newRegex(tok)
+
+	case "score":
+		return newScore(tok)
+
+	case ScopeField:
+		return scopeNode(tok.value), nil
+
+	default:
+		return nil, NewErrParse(tok.pos, "неизвестное поле %q", tok.key)
+	}
+}
+
+func equals(value string, field func(e *domain.Event) string) node {
+	return matchFunc(func(e *domain.Event) bool {
+		return strings.EqualFold(field(e), value)
+	})
+}
+
+// label matches the label of label events and labels of issues.
+func label(value string) node {
+	return matchFunc(func(e *domain.Event) bool {
+		if strings.EqualFold(e.Attributes[domain.AttrLabel], value) {
+			return true
+		}
+
+		for _, name := range strings.Split(e.Attributes[domain.AttrLabels], ",") {
+			if strings.EqualFold(name, value) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func keyword(value string) node {
+	value = strings.ToLower(value)
+
+	return matchFunc(func(e *domain.Event) bool {
+		return strings.Contains(strings.ToLower(e.Title), value) ||
+			strings.Contains(strings.ToLower(e.Body), value)
+	})
+}
+
+// newRegex accepts patterns with and without slashes: regex:/v\d+/.
+func newRegex(tok *token) (node, error) {
+	pattern := tok.value
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		pattern = pattern[1 : len(pattern)-1]
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, NewErrParse(tok.pos, "некорректное регулярное выражение: %s", err)
+	}
+
+	return matchFunc(func(e *domain.Event) bool {
+		return re.MatchString(e.Title) || re.MatchString(e.Body)
+	}), nil
+}
+
+func newScore(tok *token) (node, error) {
+	limit, err := strconv.Atoi(tok.value)
+	if err != nil {
+		return nil, NewErrParse(tok.pos, "score должен быть целым числом")
+	}
+
+	compare := map[string]func(score int) bool{
+		">=": func(score int) bool { return score >= limit },
+		">":  func(score int) bool { return score > limit },
+		"<=": func(score int) bool { return score <= limit },
+		"<":  func(score int) bool { return score < limit },
+		":":  func(score int) bool { return score == limit },
+		"=":  func(score int) bool { return score == limit },
+	}[tok.op]
+
+	return matchFunc(func(e *domain.Event) bool {
+		score, err := strconv.Atoi(e.Attributes[domain.AttrScore])
+		if err != nil {
+			return false
+		}
+
+		return compare(score)
+	}), nil
+}
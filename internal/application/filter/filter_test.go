@@ -0,0 +1,153 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	t.Parallel()
+
+	event := &domain.Event{
+		Kind:   domain.KindComment,
+		Author: "Alice",
+		Title:  "Memory leak in parser",
+		Body:   "panic: runtime error",
+		Attributes: map[string]string{
+			domain.AttrScore:  "7",
+			domain.AttrLabels: "bug,help wanted",
+			domain.AttrBranch: "main",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    bool
+	}{
+		{name: "empty", filters: nil, want: true},
+		{name: "blank", filters: []string{"  "}, want: true},
+		{name: "author", filters: []string{"user:alice"}, want: true},
+		{name: "legacy operator", filters: []string{"user=alice"}, want: true},
+		{name: "excluded author", filters: []string{"-user:alice"}, want: false},
+		{name: "not keyword", filters: []string{"NOT author:alice"}, want: false},
+		{name: "kind", filters: []string{"type:comment"}, want: true},
+		{name: "kind alias", filters: []string{"type:pr"}, want: false},
+		{name: "bare keyword", filters: []string{"LEAK"}, want: true},
+		{name: "quoted keyword", filters: []string{`"memory leak"`}, want: true},
+		{name: "keyword field", filters: []string{"keyword:release"}, want: false},
+		{name: "regex", filters: []string{`regex:"/panic: \w+/"`}, want: true},
+		{name: "escaped quote", filters: []string{`"error\""`}, want: false},
+		{name: "label", filters: []string{`label:"help wanted"`}, want: true},
+		{name: "missing label", filters: []string{"label:docs"}, want: false},
+		{name: "branch", filters: []string{"branch:main"}, want: true},
+		{name: "missing status", filters: []string{"status:failure"}, want: false},
+		{name: "score at least", filters: []string{"score>=7"}, want: true},
+		{name: "score greater", filters: []string{"score>7"}, want: false},
+		{name: "score equals", filters: []string{"score:7"}, want: true},
+		{name: "or", filters: []string{"type:answer OR user:alice"}, want: true},
+		{name: "and", filters: []string{"type:answer AND user:alice"}, want: false},
+		{name: "implicit and", filters: []string{"type:comment score<5"}, want: false},
+		{name: "groups", filters: []string{"type:comment (keyword:release OR score>=5)"}, want: true},
+		{name: "negated group", filters: []string{"-(user:bob OR label:bug)"}, want: false},
+		{name: "all filters", filters: []string{"type:comment", "user:bob"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := filter.Parse(tt.filters)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, f.Match(event))
+		})
+	}
+}
+
+func TestFilter_Scope(t *testing.T) {
+	t.Parallel()
+
+	f, err := filter.Parse([]string{"selector:li:nth-child(2) keyword:price"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "li:nth-child(2)", f.Scope())
+	assert.True(t, f.Match(&domain.Event{Body: "Price changed"}))
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		filters []string
+		err     string
+	}{
+		{
+			name:    "unclosed paren",
+			filters: []string{"type:comment (user:alice"},
+			err:     "позиция 14: незакрытая скобка",
+		},
+		{
+			name:    "extra paren",
+			filters: []string{"user:alice)"},
+			err:     "позиция 11: лишняя закрывающая скобка",
+		},
+		{
+			name:    "unclosed quote",
+			filters: []string{`keyword:"leak`},
+			err:     "позиция 9: незакрытая кавычка",
+		},
+		{
+			name:    "dangling operator",
+			filters: []string{"user:alice OR"},
+			err:     "позиция 14: неожиданный конец выражения",
+		},
+		{
+			name:    "missing value",
+			filters: []string{"user: alice"},
+			err:     "позиция 6: ожидалось значение",
+		},
+		{
+			name:    "unknown field",
+			filters: []string{"stars:5"},
+			err:     `позиция 1: неизвестное поле "stars"`,
+		},
+		{
+			name:    "unknown kind",
+			filters: []string{"type:discussion"},
+			err:     `позиция 1: неизвестный тип события "discussion"`,
+		},
+		{
+			name:    "comparison of text",
+			filters: []string{"user>=alice"},
+			err:     "позиция 1: оператор >= применим только к полю score",
+		},
+		{
+			name:    "score not a number",
+			filters: []string{"score>=high"},
+			err:     "позиция 1: score должен быть целым числом",
+		},
+		{
+			name:    "scope under or",
+			filters: []string{"selector:#price OR keyword:sale"},
+			err:     "позиция 1: поле selector нельзя использовать внутри OR и NOT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := filter.Parse(tt.filters)
+
+			parseErr := filter.ErrParse{}
+			require.ErrorAs(t, err, &parseErr)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
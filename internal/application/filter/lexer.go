@@ -0,0 +1,216 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenTerm
+	tokenWord
+)
+
+type token struct {
+	kind  tokenKind
+	pos   int
+	key   string
+	op    string
+	value string
+}
+
+// operators are checked in this order, so longer ones go first.
+var operators = []string{">=", "<=", ":", "=", ">", "<"}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaces()
+
+	start := l.pos
+	if l.pos == len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	switch l.input[l.pos] {
+	case '(':
+		l.pos++
+
+		return token{kind: tokenLParen, pos: start}, nil
+
+	case ')':
+		l.pos++
+
+		return token{kind: tokenRParen, pos: start}, nil
+
+	case '-':
+		l.pos++
+
+		return token{kind: tokenNot, pos: start}, nil
+
+	case '"':
+		value, err := l.quoted()
+		if err != nil {
+			return token{}, err
+		}
+
+		return token{kind: tokenWord, pos: start, value: value}, nil
+	}
+
+	if key, op, ok := l.termStart(); ok {
+		value, err := l.value()
+		if err != nil {
+			return token{}, err
+		}
+
+		return token{kind: tokenTerm, pos: start, key: key, op: op, value: value}, nil
+	}
+
+	word := l.word()
+
+	switch strings.ToUpper(word) {
+	case "AND":
+		return token{kind: tokenAnd, pos: start}, nil
+
+	case "OR":
+		return token{kind: tokenOr, pos: start}, nil
+
+	case "NOT":
+		return token{kind: tokenNot, pos: start}, nil
+
+	default:
+		return token{kind: tokenWord, pos: start, value: word}, nil
+	}
+}
+
+func (l *lexer) skipSpaces() {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+
+		l.pos += size
+	}
+}
+
+// termStart reads "key" and an operator. The position is kept when the input
+// doesn't start with a term.
+func (l *lexer) termStart() (key, op string, ok bool) {
+	end := l.pos
+	for end < len(l.input) && (isKeyByte(l.input[end])) {
+		end++
+	}
+
+	if end == l.pos {
+		return "", "", false
+	}
+
+	for _, operator := range operators {
+		if strings.HasPrefix(l.input[end:], operator) {
+			key = strings.ToLower(l.input[l.pos:end])
+			l.pos = end + len(operator)
+
+			return key, operator, true
+		}
+	}
+
+	return "", "", false
+}
+
+// value reads a quoted string or a bare value up to a space. Parentheses are
+// allowed inside bare values while balanced, e.g. in li:nth-child(2).
+func (l *lexer) value() (string, error) {
+	if l.pos < len(l.input) && l.input[l.pos] == '"' {
+		return l.quoted()
+	}
+
+	start := l.pos
+	depth := 0
+
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if unicode.IsSpace(r) {
+			break
+		}
+
+		if r == '(' {
+			depth++
+		}
+
+		if r == ')' {
+			if depth == 0 {
+				break
+			}
+
+			depth--
+		}
+
+		l.pos += size
+	}
+
+	if l.pos == start {
+		return "", NewErrParse(start, "ожидалось значение")
+	}
+
+	return l.input[start:l.pos], nil
+}
+
+func (l *lexer) word() string {
+	start := l.pos
+
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+
+		l.pos += size
+	}
+
+	return l.input[start:l.pos]
+}
+
+func (l *lexer) quoted() (string, error) {
+	start := l.pos
+	l.pos++
+
+	builder := strings.Builder{}
+
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+
+		switch {
+		case c == '"':
+			l.pos++
+
+			return builder.String(), nil
+
+		// Other backslashes are kept, so regular expressions need no doubling.
+		case c == '\\' && l.pos+1 < len(l.input) && (l.input[l.pos+1] == '"' || l.input[l.pos+1] == '\\'):
+			builder.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+
+		default:
+			builder.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return "", NewErrParse(start, "незакрытая кавычка")
+}
+
+func isKeyByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
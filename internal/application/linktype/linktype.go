@@ -0,0 +1,185 @@
+// Package linktype is the registry of supported link types. A type declares
+// URL patterns of its links, the canonical form of them and formats shown to
+// users. The name of a type is the type of its checker and the metrics label,
+// so a new source is added by registering its type once.
+package linktype
+
+import (
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/client"
+)
+
+// Unknown is the metrics label of links without a type.
+const Unknown = "unknown"
+
+type Type struct {
+	Name string
+	// Formats are shown to users when a link is not supported.
+	Formats  []string
+	Patterns []*regexp.Regexp
+	// Hosts are dedicated to the type with their subdomains: links to them
+	// must match Patterns and are never tracked by the fallback type.
+	Hosts []string
+	// IgnoreQuery drops the query of links: links with a query match Patterns
+	// only after canonicalization.
+	IgnoreQuery bool
+	// Canonicalize gets a link cleaned by the registry. Nil keeps it as is.
+	Canonicalize func(link *url.URL)
+}
+
+func (t *Type) match(link string) bool {
+	for _, pattern := range t.Patterns {
+		if pattern.MatchString(link) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Registry struct {
+	types    []*Type
+	fallback *Type
+}
+
+// NewRegistry matches links against types in the given order. The fallback
+// type gets links of other hosts, it may be nil.
+func NewRegistry(fallback *Type, types ...*Type) *Registry {
+	return &Registry{
+		types:    types,
+		fallback: fallback,
+	}
+}
+
+// Match returns false for unsupported links.
+func (r *Registry) Match(link string) (*Type, bool) {
+	for _, t := range r.types {
+		if t.match(link) {
+			return t, true
+		}
+	}
+
+	if r.fallback == nil || !r.fallback.match(link) {
+		return nil, false
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || r.isDedicated(parsed.Hostname()) {
+		return nil, false
+	}
+
+	return r.fallback, true
+}
+
+// Name returns the type name of the canonical form of the link or Unknown
+// for unsupported links.
+func (r *Registry) Name(link string) string {
+	_, t, ok := r.Canonicalize(link)
+	if !ok {
+		return Unknown
+	}
+
+	return t.Name
+}
+
+// Canonicalize lowercases the scheme and the host, drops the fragment, the
+// trailing slash and ignored queries and applies the canonicalizer of the
+// link type. Dedicated hosts are served over https only, so their links get
+// the https scheme. It returns false for unsupported links.
+func (r *Registry) Canonicalize(link string) (string, *Type, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", nil, false
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+
+	if parsed.Scheme == "http" && r.isDedicated(parsed.Hostname()) {
+		parsed.Scheme = "https"
+	}
+
+	if parsed.Path != "/" {
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+		parsed.RawPath = strings.TrimSuffix(parsed.RawPath, "/")
+	}
+
+	t, ok := r.Match(parsed.String())
+	if !ok || t.IgnoreQuery {
+		withoutQuery := *parsed
+		withoutQuery.RawQuery = ""
+		withoutQuery.ForceQuery = false
+
+		t, ok = r.Match(withoutQuery.String())
+		if !ok || !t.IgnoreQuery {
+			return "", nil, false
+		}
+
+		parsed = &withoutQuery
+	}
+
+	if t.Canonicalize != nil {
+		t.Canonicalize(parsed)
+	}
+
+	return parsed.String(), t, true
+}
+
+// Formats returns formats of all types with the fallback type last.
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0)
+
+	for _, t := range r.types {
+		formats = append(formats, t.Formats...)
+	}
+
+	if r.fallback != nil {
+		formats = append(formats, r.fallback.Formats...)
+	}
+
+	return formats
+}
+
+// Internal reports whether the link points to the local machine or a private
+// network by the host: localhost, loopback, private and link-local addresses.
+// Dedicated hosts are configured, so they may be internal. Names resolving to
+// internal addresses are rejected by the client on connection.
+func (r *Registry) Internal(link string) bool {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if r.isDedicated(host) {
+		return false
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+
+	return err == nil && !client.IsPublicAddr(addr)
+}
+
+func (r *Registry) isDedicated(host string) bool {
+	host = strings.ToLower(host)
+
+	for _, t := range r.types {
+		for _, dedicated := range t.Hosts {
+			if host == dedicated || strings.HasSuffix(host, "."+dedicated) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
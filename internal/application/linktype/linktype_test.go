@@ -0,0 +1,123 @@
+package linktype_test
+
+import (
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Name(t *testing.T) {
+	t.Parallel()
+
+	types := linktype.New(&config.GitLab{
+		BaseURL:   "https://git.example.com/",
+		Instances: map[string]string{"https://gitlab.gnome.org/": "token"},
+	})
+
+	tests := []struct {
+		link string
+		want string
+	}{
+		{link: "https://github.com/owner/repo", want: linktype.GitHub},
+		{link: "https://github.com/owner/repo/pull/7", want: linktype.GitHub},
+		{link: "https://github.com/owner/repo/tree/feature/x", want: linktype.GitHubBranch},
+		{link: "https://github.com/owner/repo/actions/workflows/ci.yml", want: linktype.GitHubWorkflow},
+		{link: "https://github.com/owner/repo/releases.atom", want: linktype.Feed},
+		{link: "https://github.com/owner", want: linktype.Unknown},
+		{link: "https://git.example.com/group/project", want: linktype.GitLab},
+		{link: "https://git.example.com/group/project/-/issues/3", want: linktype.GitLab},
+		{link: "https://git.example.com/group", want: linktype.Unknown},
+		{link: "https://gitlab.gnome.org/group/project/-/merge_requests/5", want: linktype.GitLab},
+		{link: "https://gitlab.com/group/project", want: linktype.GitLab},
+		{link: "https://salsa.debian.org/group/project", want: linktype.Page},
+		{link: "https://ru.stackoverflow.com/questions/1/title", want: linktype.StackOverflow},
+		{link: "https://stackoverflow.com/questions/1", want: linktype.StackOverflow},
+		{link: "https://stackoverflow.com/questions/1/title/2", want: linktype.Unknown},
+		{link: "https://blog.example.com/feed", want: linktype.Feed},
+		{link: "https://pkg.go.dev/golang.org/x/net", want: linktype.GoProxy},
+		{link: "https://www.npmjs.com/package/@types/node", want: linktype.NPM},
+		{link: "https://pypi.org/project/requests/", want: linktype.PyPI},
+		{link: "https://crates.io/crates/serde", want: linktype.Crates},
+		{link: "https://go.dev/doc/devel/release", want: linktype.Page},
+		{link: "https://docs.github.com/en/rest", want: linktype.Unknown},
+		{link: "invalid", want: linktype.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, types.Name(tt.link))
+		})
+	}
+}
+
+func TestRegistry_Canonicalize(t *testing.T) {
+	t.Parallel()
+
+	types := linktype.New(&config.GitLab{BaseURL: "https://gitlab.com"})
+
+	tests := []struct {
+		link string
+		want string
+		ok   bool
+	}{
+		{link: "https://GitHub.com/Owner/Repo/", want: "https://github.com/owner/repo", ok: true},
+		{link: "http://github.com/foo/bar", want: "https://github.com/foo/bar", ok: true},
+		{link: "https://github.com/Owner/Repo/tree/Main", want: "https://github.com/owner/repo/tree/Main", ok: true},
+		{link: "https://github.com/owner/repo/issues/1#issuecomment-2", want: "https://github.com/owner/repo/issues/1", ok: true},
+		{
+			link: "https://stackoverflow.com/questions/1/title?noredirect=1",
+			want: "https://stackoverflow.com/questions/1",
+			ok:   true,
+		},
+		{link: "https://math.stackexchange.com/questions/1/", want: "https://math.stackexchange.com/questions/1", ok: true},
+		{link: "http://example.com/news/", want: "http://example.com/news", ok: true},
+		{link: "https://example.com/news?page=2#top", want: "https://example.com/news?page=2", ok: true},
+		{link: "https://github.com/owner", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			t.Parallel()
+
+			link, _, ok := types.Canonicalize(tt.link)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, link)
+		})
+	}
+}
+
+func TestRegistry_Internal(t *testing.T) {
+	t.Parallel()
+
+	types := linktype.New(&config.GitLab{BaseURL: "http://10.0.0.5"})
+
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{link: "https://go.dev/doc/devel/release"},
+		{link: "https://93.184.215.14/feed"},
+		{link: "http://10.0.0.5/group/project"},
+		{link: "http://localhost:8080/feed", want: true},
+		{link: "http://api.LOCALHOST./page", want: true},
+		{link: "http://127.0.0.1/page", want: true},
+		{link: "http://[::1]:8080/page", want: true},
+		{link: "http://169.254.169.254/latest/meta-data", want: true},
+		{link: "http://192.168.1.1/rss", want: true},
+		{link: "http://172.16.0.1/rss", want: true},
+		{link: "http://10.0.0.6/feed", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.link, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, types.Internal(tt.link))
+		})
+	}
+}
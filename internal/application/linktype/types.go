@@ -0,0 +1,243 @@
+package linktype
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+)
+
+const (
+	GitHub         = "github"
+	GitHubBranch   = "github_branch"
+	GitHubWorkflow = "github_workflow"
+	GitLab         = "gitlab"
+	StackOverflow  = "stackoverflow"
+	Feed           = "feed"
+	GoProxy        = "goproxy"
+	NPM            = "npm"
+	PyPI           = "pypi"
+	Crates         = "crates"
+	Page           = "page"
+)
+
+var githubHosts = []string{"github.com"}
+
+// New returns the registry of all sources. GitLab links are supported for the
+// configured instances and gitlab.com, links of other instances are tracked
+// as pages.
+func New(cfg *config.GitLab) *Registry {
+	return NewRegistry(
+		newPage(),
+		newGitHub(),
+		newGitHubBranch(),
+		newGitHubWorkflow(),
+		newGitLab(cfg.AllInstances()),
+		newStackOverflow(),
+		newFeed(),
+		newGoProxy(),
+		newNPM(),
+		newPyPI(),
+		newCrates(),
+	)
+}
+
+func newGitHub() *Type {
+	return &Type{
+		Name: GitHub,
+		Formats: []string{
+			"https://github.com/{user}/{repo}",
+			"https://github.com/{user}/{repo}/issues",
+			"https://github.com/{user}/{repo}/releases",
+			"https://github.com/{user}/{repo}/issues/{id}",
+			"https://github.com/{user}/{repo}/pull/{id}",
+		},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)$`),
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/(issues|releases)$`),
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)$`),
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/pull/(\d+)$`),
+		},
+		Hosts:        githubHosts,
+		IgnoreQuery:  true,
+		Canonicalize: canonicalGitHub,
+	}
+}
+
+func newGitHubBranch() *Type {
+	return &Type{
+		Name:    GitHubBranch,
+		Formats: []string{"https://github.com/{user}/{repo}/tree/{branch}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/tree/([\w./-]+)$`),
+		},
+		Hosts:        githubHosts,
+		IgnoreQuery:  true,
+		Canonicalize: canonicalGitHub,
+	}
+}
+
+func newGitHubWorkflow() *Type {
+	return &Type{
+		Name:    GitHubWorkflow,
+		Formats: []string{"https://github.com/{user}/{repo}/actions/workflows/{file}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/actions/workflows/([\w.-]+)$`),
+		},
+		Hosts:        githubHosts,
+		IgnoreQuery:  true,
+		Canonicalize: canonicalGitHub,
+	}
+}
+
+func newGitLab(instances []config.GitLabInstance) *Type {
+	project := `(\w[\w.-]*(?:/\w[\w.-]*)+)`
+
+	gitlab := &Type{
+		Name:        GitLab,
+		IgnoreQuery: true,
+	}
+
+	for _, instance := range instances {
+		base := regexp.QuoteMeta(instance.BaseURL)
+
+		gitlab.Formats = append(
+			gitlab.Formats,
+			instance.BaseURL+"/{group}/{project}",
+			instance.BaseURL+"/{group}/{project}/-/issues/{id}",
+			instance.BaseURL+"/{group}/{project}/-/merge_requests/{id}",
+		)
+		gitlab.Patterns = append(
+			gitlab.Patterns,
+			regexp.MustCompile(`^`+base+`/`+project+`$`),
+			regexp.MustCompile(`^`+base+`/`+project+`/-/(issues|merge_requests)/(\d+)$`),
+		)
+
+		if parsed, err := url.Parse(instance.BaseURL); err == nil && parsed.Hostname() != "" {
+			gitlab.Hosts = append(gitlab.Hosts, strings.ToLower(parsed.Hostname()))
+		}
+	}
+
+	return gitlab
+}
+
+func newStackOverflow() *Type {
+	return &Type{
+		Name: StackOverflow,
+		Formats: []string{
+			"https://stackoverflow.com/questions/{id}/{title} (и другие сайты Stack Exchange)",
+		},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(
+				`^https://(?:(?:[\w-]+\.)*(?:stackoverflow|superuser|serverfault|askubuntu|stackapps|stackexchange)\.com|` +
+					`(?:[\w-]+\.)?mathoverflow\.net)/questions/(\d+)(?:/[\w-]+)?$`,
+			),
+		},
+		Hosts: []string{
+			"stackoverflow.com",
+			"superuser.com",
+			"serverfault.com",
+			"askubuntu.com",
+			"stackapps.com",
+			"stackexchange.com",
+			"mathoverflow.net",
+		},
+		IgnoreQuery:  true,
+		Canonicalize: canonicalStackOverflow,
+	}
+}
+
+// newFeed recognizes feeds of any site by the path: it must end with .xml,
+// .rss, .atom, /feed, /rss or /atom.
+func newFeed() *Type {
+	return &Type{
+		Name:    Feed,
+		Formats: []string{"https://{site}/{path}.rss, .atom, .xml или /feed (RSS и Atom ленты)"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(
+				`(?i)^https?://[^/?#]+(?:/[^?#]*)?(?:\.(?:xml|rss|atom)|/(?:feed|rss|atom)/?)(?:\?[^#]*)?$`,
+			),
+		},
+	}
+}
+
+func newGoProxy() *Type {
+	return &Type{
+		Name:    GoProxy,
+		Formats: []string{"https://pkg.go.dev/{module}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://pkg\.go\.dev/([\w~-]+\.[\w.~-]+(?:/[\w.~-]+)*)$`),
+		},
+		Hosts:       []string{"pkg.go.dev"},
+		IgnoreQuery: true,
+	}
+}
+
+func newNPM() *Type {
+	return &Type{
+		Name:    NPM,
+		Formats: []string{"https://www.npmjs.com/package/{name}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://www\.npmjs\.com/package/((?:@[\w.-]+/)?[\w.-]+)$`),
+		},
+		Hosts:       []string{"npmjs.com"},
+		IgnoreQuery: true,
+	}
+}
+
+func newPyPI() *Type {
+	return &Type{
+		Name:    PyPI,
+		Formats: []string{"https://pypi.org/project/{name}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://pypi\.org/project/([\w.-]+)/?$`),
+		},
+		Hosts:       []string{"pypi.org"},
+		IgnoreQuery: true,
+	}
+}
+
+func newCrates() *Type {
+	return &Type{
+		Name:    Crates,
+		Formats: []string{"https://crates.io/crates/{name}"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https://crates\.io/crates/([\w-]+)/?$`),
+		},
+		Hosts:       []string{"crates.io"},
+		IgnoreQuery: true,
+	}
+}
+
+// newPage tracks any other page, its part is selected by the selector filter.
+func newPage() *Type {
+	return &Type{
+		Name:    Page,
+		Formats: []string{"https://{site}/{path} (любая другая страница, её часть выбирается фильтром selector:{css})"},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`^https?://[^/?#\s]+(?:[/?#]\S*)?$`),
+		},
+	}
+}
+
+// canonicalGitHub lowercases the owner and the repository as GitHub ignores
+// their case. Branches and workflow files are case-sensitive.
+func canonicalGitHub(link *url.URL) {
+	segments := strings.SplitN(strings.TrimPrefix(link.Path, "/"), "/", 3)
+	for i := 0; i < len(segments) && i < 2; i++ {
+		segments[i] = strings.ToLower(segments[i])
+	}
+
+	link.Path = "/" + strings.Join(segments, "/")
+	link.RawPath = ""
+}
+
+// canonicalStackOverflow drops the title slug: it is optional and changes
+// with the title of the question.
+func canonicalStackOverflow(link *url.URL) {
+	segments := strings.SplitN(strings.TrimPrefix(link.Path, "/"), "/", 3)
+
+	link.Path = "/" + strings.Join(segments[:min(len(segments), 2)], "/")
+	link.RawPath = ""
+}
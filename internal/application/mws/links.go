@@ -8,17 +8,12 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
-	"strings"
-)
 
-const (
-	github        = "github"
-	stackOverflow = "stackoverflow"
-	unknown       = "unknown"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 )
 
 type Repository interface {
-	GetActiveLinks(ctx context.Context) (map[string]int, error)
+	GetActiveLinks(ctx context.Context) ([]string, error)
 }
 
 type Metrics interface {
@@ -37,8 +32,9 @@ func (w *customWriter) WriteHeader(status int) {
 	w.ResponseWriter.WriteHeader(status)
 }
 
-func NewLinksCounter(repo Repository, m Metrics) func(http.Handler) http.Handler {
-	mp, err := repo.GetActiveLinks(context.Background())
+// NewLinksCounter labels links with names of their types.
+func NewLinksCounter(repo Repository, m Metrics, types *linktype.Registry) func(http.Handler) http.Handler {
+	urls, err := repo.GetActiveLinks(context.Background())
 	if err != nil {
 		slog.Error(
 			"failed to get active links from repository in links middleware",
@@ -46,7 +42,12 @@ func NewLinksCounter(repo Repository, m Metrics) func(http.Handler) http.Handler
 		)
 	}
 
-	for linkType, count := range mp {
+	counts := make(map[string]int)
+	for _, url := range urls {
+		counts[types.Name(url)]++
+	}
+
+	for linkType, count := range counts {
 		m.SetActiveLinksTotal(linkType, count)
 	}
 
@@ -91,7 +92,7 @@ func NewLinksCounter(repo Repository, m Metrics) func(http.Handler) http.Handler
 				return
 			}
 
-			linkType := getLinkType(payload.Link)
+			linkType := types.Name(payload.Link)
 
 			switch r.Method {
 			case http.MethodPost:
@@ -117,16 +118,3 @@ func readAndReturnReader(r io.ReadCloser) ([]byte, io.ReadCloser, error) {
 
 	return content, io.NopCloser(bytes.NewReader(content)), nil
 }
-
-func getLinkType(link string) string {
-	switch {
-	case strings.HasPrefix(link, "https://github.com/"):
-		return github
-
-	case strings.HasPrefix(link, "https://stackoverflow.com/"):
-		return stackOverflow
-
-	default:
-		return unknown
-	}
-}
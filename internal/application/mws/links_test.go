@@ -9,11 +9,19 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/mws"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/mws/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/stretchr/testify/assert"
 )
 
+var activeLinks = []string{
+	"https://github.com/user/repo",
+	"https://github.com/user/repo/issues/1",
+	"https://stackoverflow.com/questions/123/title",
+}
+
 func TestNewLinksCounter(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -32,10 +40,7 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 				mockMetrics.On("IncActiveLinksTotal", "github").Once()
@@ -46,21 +51,70 @@ func TestNewLinksCounter(t *testing.T) {
 			method: http.MethodPost,
 			path:   "/links",
 			requestBody: map[string]string{
-				"link": "https://stackoverflow.com/questions/123",
+				"link": "https://stackoverflow.com/questions/123/title",
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 				mockMetrics.On("IncActiveLinksTotal", "stackoverflow").Once()
 			},
 		},
 		{
-			name:   "POST unknown link - success",
+			name:   "POST Stack Exchange link - success",
+			method: http.MethodPost,
+			path:   "/links",
+			requestBody: map[string]string{
+				"link": "https://math.stackexchange.com/questions/123/title",
+			},
+			responseStatus: http.StatusOK,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{}, nil)
+				mockMetrics.On("IncActiveLinksTotal", "stackoverflow").Once()
+			},
+		},
+		{
+			name:   "POST feed link - success",
+			method: http.MethodPost,
+			path:   "/links",
+			requestBody: map[string]string{
+				"link": "https://github.com/user/repo/releases.atom",
+			},
+			responseStatus: http.StatusOK,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{}, nil)
+				mockMetrics.On("IncActiveLinksTotal", "feed").Once()
+			},
+		},
+		{
+			name:   "POST gitlab link - success",
+			method: http.MethodPost,
+			path:   "/links",
+			requestBody: map[string]string{
+				"link": "https://gitlab.com/group/project/-/merge_requests/1",
+			},
+			responseStatus: http.StatusOK,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{}, nil)
+				mockMetrics.On("IncActiveLinksTotal", "gitlab").Once()
+			},
+		},
+		{
+			name:   "POST PyPI link - success",
+			method: http.MethodPost,
+			path:   "/links",
+			requestBody: map[string]string{
+				"link": "https://pypi.org/project/requests/",
+			},
+			responseStatus: http.StatusOK,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{}, nil)
+				mockMetrics.On("IncActiveLinksTotal", "pypi").Once()
+			},
+		},
+		{
+			name:   "POST page link - success",
 			method: http.MethodPost,
 			path:   "/links",
 			requestBody: map[string]string{
@@ -68,10 +122,21 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"example": 1,
-				}, nil)
-				mockMetrics.On("SetActiveLinksTotal", "example", 1).Once()
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{"https://example.com/page"}, nil)
+				mockMetrics.On("SetActiveLinksTotal", "page", 1).Once()
+				mockMetrics.On("IncActiveLinksTotal", "page").Once()
+			},
+		},
+		{
+			name:   "POST unknown link - success",
+			method: http.MethodPost,
+			path:   "/links",
+			requestBody: map[string]string{
+				"link": "https://github.com/user",
+			},
+			responseStatus: http.StatusOK,
+			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
+				mockRepo.On("GetActiveLinks", context.Background()).Return([]string{}, nil)
 				mockMetrics.On("IncActiveLinksTotal", "unknown").Once()
 			},
 		},
@@ -84,14 +149,10 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-					"example":       1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(append(activeLinks, "https://example.com/page"), nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
-				mockMetrics.On("SetActiveLinksTotal", "example", 1).Once()
+				mockMetrics.On("SetActiveLinksTotal", "page", 1).Once()
 				mockMetrics.On("DecActiveLinksTotal", "github").Once()
 			},
 		},
@@ -104,10 +165,7 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusBadRequest,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 			},
@@ -121,10 +179,7 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 			},
@@ -138,10 +193,7 @@ func TestNewLinksCounter(t *testing.T) {
 			},
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 			},
@@ -153,10 +205,7 @@ func TestNewLinksCounter(t *testing.T) {
 			requestBody:    "invalid json",
 			responseStatus: http.StatusOK,
 			setupMocks: func(mockRepo *mocks.MockRepository, mockMetrics *mocks.MockMetrics) {
-				mockRepo.On("GetActiveLinks", context.Background()).Return(map[string]int{
-					"github":        2,
-					"stackoverflow": 1,
-				}, nil)
+				mockRepo.On("GetActiveLinks", context.Background()).Return(activeLinks, nil)
 				mockMetrics.On("SetActiveLinksTotal", "github", 2).Once()
 				mockMetrics.On("SetActiveLinksTotal", "stackoverflow", 1).Once()
 			},
@@ -169,7 +218,7 @@ func TestNewLinksCounter(t *testing.T) {
 			mockMetrics := mocks.NewMockMetrics(t)
 			tc.setupMocks(mockRepo, mockMetrics)
 
-			middleware := mws.NewLinksCounter(mockRepo, mockMetrics)
+			middleware := mws.NewLinksCounter(mockRepo, mockMetrics, linktype.New(&config.GitLab{BaseURL: "https://gitlab.com"}))
 
 			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 				w.WriteHeader(tc.responseStatus)
@@ -22,23 +22,23 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 }
 
 // GetActiveLinks provides a mock function with given fields: ctx
-func (_m *MockRepository) GetActiveLinks(ctx context.Context) (map[string]int, error) {
+func (_m *MockRepository) GetActiveLinks(ctx context.Context) ([]string, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActiveLinks")
 	}
 
-	var r0 map[string]int
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]int)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
@@ -69,12 +69,12 @@ func (_c *MockRepository_GetActiveLinks_Call) Run(run func(ctx context.Context))
 	return _c
 }
 
-func (_c *MockRepository_GetActiveLinks_Call) Return(_a0 map[string]int, _a1 error) *MockRepository_GetActiveLinks_Call {
+func (_c *MockRepository_GetActiveLinks_Call) Return(_a0 []string, _a1 error) *MockRepository_GetActiveLinks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetActiveLinks_Call) RunAndReturn(run func(context.Context) (map[string]int, error)) *MockRepository_GetActiveLinks_Call {
+func (_c *MockRepository_GetActiveLinks_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockRepository_GetActiveLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
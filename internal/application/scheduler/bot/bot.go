@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/go-co-op/gocron/v2"
@@ -103,7 +104,7 @@ func updatesToText(updates []*domain.Update) string {
 	builder.WriteString("Обновления по вашим ссылкам:\n\n")
 
 	for i, update := range updates {
-		builder.WriteString(fmt.Sprintf("%d. %s", i+1, update.Message))
+		builder.WriteString(fmt.Sprintf("%d. %s", i+1, render.Event(&update.Event)))
 
 		if len(update.Tags) != 0 {
 			builder.WriteString(fmt.Sprintf("#%s\n", strings.Join(update.Tags, " #")))
@@ -8,6 +8,7 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/bot"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/bot/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -77,21 +78,21 @@ func TestScheduler_SendUpdates_Success(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 
 	update1 := &domain.Update{
-		ChatID:  1,
-		URL:     "link1",
-		Message: "message1\n",
-		Tags:    []string{"tag1"},
+		ChatID: 1,
+		URL:    "link1",
+		Event:  domain.Event{Kind: domain.KindIssue, Title: "message1"},
+		Tags:   []string{"tag1"},
 	}
 	update2 := &domain.Update{
-		ChatID:  1,
-		URL:     "link2",
-		Message: "message2\n",
-		Tags:    []string{"tag2"},
+		ChatID: 1,
+		URL:    "link2",
+		Event:  domain.Event{Kind: domain.KindIssue, Title: "message2"},
+		Tags:   []string{"tag2"},
 	}
 	update3 := &domain.Update{
-		ChatID:  2,
-		URL:     "link3",
-		Message: "message3\n",
+		ChatID: 2,
+		URL:    "link3",
+		Event:  domain.Event{Kind: domain.KindIssue, Title: "message3"},
 	}
 
 	repoMock.On("GetUpdatesChats", ctx).
@@ -116,8 +117,9 @@ func TestScheduler_SendUpdates_Success(t *testing.T) {
 	scheduler := bot.NewScheduler(cfg, repoMock, channels)
 
 	go func() {
-		expectedText1 := "Обновления по вашим ссылкам:\n\n1. message1\n#tag1\n\n2. message2\n#tag2\n\n"
-		expectedText2 := "Обновления по вашим ссылкам:\n\n1. message3\n\n"
+		expectedText1 := "Обновления по вашим ссылкам:\n\n1. " + render.Event(&update1.Event) + "#tag1\n\n" +
+			"2. " + render.Event(&update2.Event) + "#tag2\n\n"
+		expectedText2 := "Обновления по вашим ссылкам:\n\n1. " + render.Event(&update3.Event) + "\n"
 
 		msg1 := <-channels.TelegramResp()
 		m1, ok := msg1.(tgbotapi.MessageConfig)
@@ -0,0 +1,31 @@
+package scrapper
+
+import (
+	"context"
+)
+
+// cursorChecker is the link state key of cursors of cursor checks.
+const cursorChecker = "cursor"
+
+// getCursor returns an empty cursor for checkers without cursors and links
+// checked before.
+func (s *Scheduler) getCursor(ctx context.Context, checker Checher, linkID int64) (string, error) {
+	if _, ok := checker.(CursorChecher); !ok {
+		return "", nil
+	}
+
+	raw, err := s.repo.GetLinkState(ctx, linkID, cursorChecker)
+	if err != nil {
+		return "", err
+	}
+
+	return string(raw), nil
+}
+
+func (s *Scheduler) saveCursor(ctx context.Context, checker Checher, linkID int64, cursor string) error {
+	if _, ok := checker.(CursorChecher); !ok || cursor == "" {
+		return nil
+	}
+
+	return s.repo.SaveLinkState(ctx, linkID, cursorChecker, []byte(cursor))
+}
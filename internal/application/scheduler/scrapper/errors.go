@@ -0,0 +1,31 @@
+package scrapper
+
+import "fmt"
+
+type ErrRateLimited struct {
+	Source string
+}
+
+func NewErrRateLimited(source string) error {
+	return ErrRateLimited{
+		Source: source,
+	}
+}
+
+func (e ErrRateLimited) Error() string {
+	return fmt.Sprintf("scrapes of source=%q are paused until the rate limit reset", e.Source)
+}
+
+type ErrLinkBusy struct {
+	URL string
+}
+
+func NewErrLinkBusy(url string) error {
+	return ErrLinkBusy{
+		URL: url,
+	}
+}
+
+func (e ErrLinkBusy) Error() string {
+	return fmt.Sprintf("link with url=%q is being checked already", e.URL)
+}
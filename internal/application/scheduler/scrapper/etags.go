@@ -0,0 +1,43 @@
+package scrapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// etagsChecker is the link state key of ETags of conditional checks.
+const etagsChecker = "etags"
+
+// getETags returns nil for checkers without conditional requests.
+func (s *Scheduler) getETags(ctx context.Context, checker Checher, linkID int64) (map[string]string, error) {
+	if _, ok := checker.(ConditionalChecher); !ok {
+		return nil, nil
+	}
+
+	raw, err := s.repo.GetLinkState(ctx, linkID, etagsChecker)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+
+	etags := make(map[string]string)
+
+	if err := json.Unmarshal(raw, &etags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal etags: %w", err)
+	}
+
+	return etags, nil
+}
+
+func (s *Scheduler) saveETags(ctx context.Context, checker Checher, linkID int64, etags map[string]string) error {
+	if _, ok := checker.(ConditionalChecher); !ok {
+		return nil
+	}
+
+	raw, err := json.Marshal(etags)
+	if err != nil {
+		return fmt.Errorf("failed to marshal etags: %w", err)
+	}
+
+	return s.repo.SaveLinkState(ctx, linkID, etagsChecker, raw)
+}
@@ -0,0 +1,160 @@
+package scrapper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+type queuedLink struct {
+	link     *domain.CheckLink
+	queuedAt time.Time
+}
+
+// pool checks links of one source, so a slow source doesn't hold up others.
+// Links of chats with send_immediately are checked first. Both queues are
+// bounded: a link that doesn't fit is checked on the next tick.
+type pool struct {
+	source  string
+	workers int
+	urgent  chan queuedLink
+	regular chan queuedLink
+	limiter *limiter
+}
+
+func newPool(source string, workers, size int, rate float64) *pool {
+	return &pool{
+		source:  source,
+		workers: max(workers, 1),
+		urgent:  make(chan queuedLink, size),
+		regular: make(chan queuedLink, size),
+		limiter: newLimiter(rate),
+	}
+}
+
+// push returns false if the queue is full.
+func (p *pool) push(link *domain.CheckLink) bool {
+	queue := p.regular
+	if isUrgent(link) {
+		queue = p.urgent
+	}
+
+	select {
+	case queue <- queuedLink{link: link, queuedAt: time.Now()}:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// pop returns false if ctx is done.
+func (p *pool) pop(ctx context.Context) (queuedLink, bool) {
+	select {
+	case queued := <-p.urgent:
+		return queued, true
+
+	default:
+	}
+
+	select {
+	case queued := <-p.urgent:
+		return queued, true
+
+	case queued := <-p.regular:
+		return queued, true
+
+	case <-ctx.Done():
+		return queuedLink{}, false
+	}
+}
+
+func (p *pool) depth() int {
+	return len(p.urgent) + len(p.regular)
+}
+
+// drain returns links left in the queues on shutdown.
+func (p *pool) drain() []*domain.CheckLink {
+	links := make([]*domain.CheckLink, 0, p.depth())
+
+	for {
+		select {
+		case queued := <-p.urgent:
+			links = append(links, queued.link)
+
+		case queued := <-p.regular:
+			links = append(links, queued.link)
+
+		default:
+			return links
+		}
+	}
+}
+
+func isUrgent(link *domain.CheckLink) bool {
+	for _, chat := range link.Chats {
+		if chat.SendImmediately {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limiter spaces scrapes of a source evenly. A nil limiter never waits.
+type limiter struct {
+	mu    sync.Mutex
+	every time.Duration
+	next  time.Time
+}
+
+// newLimiter returns nil if rate, the number of scrapes per second, is not
+// positive.
+func newLimiter(rate float64) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &limiter{
+		every: time.Duration(float64(time.Second) / rate),
+	}
+}
+
+// wait returns false if ctx is done first.
+func (l *limiter) wait(ctx context.Context) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+
+	at := l.next
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+
+	l.next = at.Add(l.every)
+	l.mu.Unlock()
+
+	return sleep(ctx, time.Until(at))
+}
+
+// sleep returns false if ctx is done first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+
+	case <-timer.C:
+		return true
+	}
+}
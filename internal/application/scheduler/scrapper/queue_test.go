@@ -0,0 +1,364 @@
+package scrapper_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const replica = "replica"
+
+// queueTest runs a scheduler with one worker. The first claimed link blocks
+// the worker until the rest are queued.
+type queueTest struct {
+	repo    *mocks.MockRepository
+	checker *mocks.MockChecher
+	mu      sync.Mutex
+	checked []string
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func newQueueTest(t *testing.T, first *domain.CheckLink, rest ...*domain.CheckLink) *queueTest {
+	t.Helper()
+
+	q := &queueTest{
+		repo:    mocks.NewMockRepository(t),
+		checker: mocks.NewMockChecher(t),
+		blocked: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+
+	q.repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.CheckLink{first}, nil).
+		Once()
+	q.repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-q.blocked }).
+		Return(rest, nil).
+		Once()
+	q.repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { close(q.release) }).
+		Return(nil, nil).
+		Once()
+	q.repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Maybe()
+
+	q.repo.On("GetLinkState", mock.Anything, mock.Anything, "seen").Return(nil, nil).Maybe()
+	q.repo.On("SaveLinkState", mock.Anything, mock.Anything, "seen", mock.Anything).Return(nil).Maybe()
+	q.repo.On("UpdateCheckTime", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	q.checker.On("GetType").Return("github").Maybe()
+	q.checker.On("GetUpdates", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			link := args.String(1)
+
+			q.mu.Lock()
+			q.checked = append(q.checked, link)
+			q.mu.Unlock()
+
+			if link == first.URL {
+				close(q.blocked)
+				<-q.release
+			}
+		}).
+		Return(nil, nil).
+		Maybe()
+
+	return q
+}
+
+func (q *queueTest) run(t *testing.T, queueSize, wantChecks int) []string {
+	t.Helper()
+
+	metrics := mocks.NewMockMetrics(t)
+	metrics.On("ObserveScrapeDurationSeconds", "github", mock.Anything).Maybe()
+	metrics.On("IncScrapesTotal", "github", mock.Anything).Maybe()
+	metrics.On("SetScrapeQueueDepth", mock.Anything, mock.Anything).Maybe()
+	metrics.On("ObserveScrapeQueueWaitSeconds", mock.Anything, mock.Anything).Maybe()
+
+	cfg := &config.ScrapperScheduler{
+		Interval:      20 * time.Millisecond,
+		ScrapeTimeout: time.Second,
+		PageSize:      10,
+		ReplicaID:     replica,
+		LeaseTimeout:  time.Minute,
+		Workers:       1,
+		QueueSize:     queueSize,
+	}
+
+	scheduler := scrapper.NewScheduler(
+		cfg,
+		q.repo,
+		mocks.NewMockClient(t),
+		metrics,
+		linktype.New(&config.GitLab{}),
+		q.checker,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+
+		return len(q.checked) >= wantChecks
+	}, time.Second, 5*time.Millisecond, "links should be checked")
+
+	cancel()
+	require.NoError(t, <-done, "scheduler should stop")
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.checked
+}
+
+func newQueuedLink(id int64, name string, sendImmediately bool) *domain.CheckLink {
+	return &domain.CheckLink{
+		ID:    id,
+		URL:   "https://github.com/owner/" + name,
+		Chats: []domain.LinkChat{{ChatID: 10, SendImmediately: sendImmediately}},
+	}
+}
+
+func TestScheduler_Run_PrioritizesSendImmediately(t *testing.T) {
+	t.Parallel()
+
+	first := newQueuedLink(1, "first", false)
+	digest := newQueuedLink(2, "digest", false)
+	immediate := newQueuedLink(3, "immediate", true)
+
+	q := newQueueTest(t, first, digest, immediate)
+	q.repo.On("ReleaseLinks", mock.Anything, replica, mock.Anything).Return(nil).Maybe()
+
+	checked := q.run(t, 10, 3)
+
+	assert.Equal(t, []string{first.URL, immediate.URL, digest.URL}, checked, "urgent links should be checked first")
+}
+
+func TestScheduler_Run_ReleasesOverflow(t *testing.T) {
+	t.Parallel()
+
+	first := newQueuedLink(1, "first", false)
+	queued := newQueuedLink(2, "queued", false)
+	overflow := newQueuedLink(3, "overflow", false)
+
+	q := newQueueTest(t, first, queued, overflow)
+	q.repo.On("ReleaseLinks", mock.Anything, replica, []int64{overflow.ID}).Return(nil).Once()
+	q.repo.On("ReleaseLinks", mock.Anything, replica, mock.Anything).Return(nil).Maybe()
+
+	checked := q.run(t, 1, 2)
+
+	assert.Equal(t, []string{first.URL, queued.URL}, checked, "links of a full queue should wait for the next tick")
+}
+
+func TestScheduler_Run_HoldsFailedLinksUntilTickEnds(t *testing.T) {
+	t.Parallel()
+
+	link := newQueuedLink(1, "deleted", false)
+
+	var (
+		mu     sync.Mutex
+		leased bool
+		tick   time.Time
+		checks = make(map[time.Time]int)
+	)
+
+	checked := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return checks[tick]
+	}
+
+	repo := mocks.NewMockRepository(t)
+	checker := mocks.NewMockChecher(t)
+
+	// The failed link is still due: it is claimed whenever it is not leased.
+	// Further pages of a tick are claimed after the worker checked the link.
+	repo.EXPECT().ClaimCheckLinks(mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, due time.Time, _ time.Duration, _ uint) ([]*domain.CheckLink, error) {
+			mu.Lock()
+			next := !due.Equal(tick)
+			tick = due
+			mu.Unlock()
+
+			if !next {
+				assert.Eventually(t, func() bool { return checked() > 0 }, time.Second, time.Millisecond)
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if leased {
+				return nil, nil
+			}
+
+			leased = true
+
+			return []*domain.CheckLink{link}, nil
+		})
+	repo.EXPECT().ReleaseLinks(mock.Anything, replica, []int64{link.ID}).
+		RunAndReturn(func(context.Context, string, []int64) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			leased = false
+
+			return nil
+		}).
+		Maybe()
+	repo.On("GetLinkState", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+
+	checker.On("GetType").Return("github").Maybe()
+	checker.On("GetUpdates", mock.Anything, link.URL, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			checks[tick]++
+		}).
+		Return(nil, errors.New("repository not found"))
+
+	metrics := mocks.NewMockMetrics(t)
+	metrics.On("ObserveScrapeDurationSeconds", "github", mock.Anything).Maybe()
+	metrics.On("IncScrapesTotal", "github", mock.Anything).Maybe()
+	metrics.On("SetScrapeQueueDepth", mock.Anything, mock.Anything).Maybe()
+	metrics.On("ObserveScrapeQueueWaitSeconds", mock.Anything, mock.Anything).Maybe()
+
+	cfg := &config.ScrapperScheduler{
+		Interval:      50 * time.Millisecond,
+		ScrapeTimeout: time.Second,
+		PageSize:      10,
+		ReplicaID:     replica,
+		LeaseTimeout:  time.Minute,
+		Workers:       1,
+		QueueSize:     10,
+	}
+
+	scheduler := scrapper.NewScheduler(cfg, repo, mocks.NewMockClient(t), metrics, linktype.New(&config.GitLab{}), checker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(checks) >= 3
+	}, 2*time.Second, 5*time.Millisecond, "the link should be checked on every tick")
+
+	cancel()
+	require.NoError(t, <-done, "scheduler should stop")
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for due, count := range checks {
+		assert.Equal(t, 1, count, "the failed link should be checked once in the tick at %s", due)
+	}
+}
+
+// batchChecher checks all links with one request, links of errs fail alone.
+type batchChecher struct {
+	*mocks.MockChecher
+	updates map[string][]domain.Event
+	errs    map[string]error
+}
+
+func (c batchChecher) GetBatchUpdates(
+	context.Context,
+	map[string]time.Time,
+	time.Time,
+) (map[string][]domain.Event, map[string]error, error) {
+	return c.updates, c.errs, nil
+}
+
+func TestScheduler_Run_KeepsWindowOfFailedBatchLinks(t *testing.T) {
+	t.Parallel()
+
+	ok := newQueuedLink(1, "repo", false)
+	failed := newQueuedLink(2, "deleted", false)
+
+	repo := mocks.NewMockRepository(t)
+	client := mocks.NewMockClient(t)
+	checker := mocks.NewMockChecher(t)
+	released := make(chan struct{})
+
+	repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Return([]*domain.CheckLink{ok, failed}, nil).
+		Once()
+	repo.On("ClaimCheckLinks", mock.Anything, replica, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, nil).
+		Maybe()
+	repo.On("GetLinkState", mock.Anything, mock.Anything, "seen").Return(nil, nil).Twice()
+	repo.On("SaveLinkState", mock.Anything, ok.ID, "seen", mock.Anything).Return(nil).Once()
+	// UpdateCheckTime of the failed link is not expected: its window is
+	// checked again.
+	repo.On("UpdateCheckTime", mock.Anything, ok.URL, mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("ReleaseLinks", mock.Anything, replica, []int64{ok.ID, failed.ID}).
+		Run(func(mock.Arguments) { close(released) }).
+		Return(nil).
+		Once()
+	client.On("UpdatesPost", mock.Anything, updateSent(10, "1")).Return(nil).Once()
+	checker.On("GetType").Return("github").Maybe()
+
+	metrics := mocks.NewMockMetrics(t)
+	metrics.On("ObserveScrapeDurationSeconds", "github", mock.Anything).Maybe()
+	metrics.On("IncScrapesTotal", "github", mock.Anything).Maybe()
+
+	cfg := &config.ScrapperScheduler{
+		Interval:      20 * time.Millisecond,
+		ScrapeTimeout: time.Second,
+		PageSize:      10,
+		ReplicaID:     replica,
+		LeaseTimeout:  time.Minute,
+		Workers:       1,
+		QueueSize:     10,
+	}
+
+	batch := batchChecher{
+		MockChecher: checker,
+		updates:     map[string][]domain.Event{ok.URL: {newEvent("1", time.Now())}},
+		errs:        map[string]error{failed.URL: errors.New("repository not found")},
+	}
+	scheduler := scrapper.NewScheduler(cfg, repo, client, metrics, linktype.New(&config.GitLab{}), batch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Error("batch links should be released")
+	}
+
+	cancel()
+	require.NoError(t, <-done, "scheduler should stop")
+}
@@ -2,34 +2,122 @@ package scrapper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
+	"maps"
+	"os"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/go-co-op/gocron/v2"
 )
 
-const (
-	numWorkers = 5
-)
-
 type Repository interface {
-	GetCheckLinks(
+	ClaimCheckLinks(
 		ctx context.Context,
-		from, to time.Time,
+		owner string,
+		due time.Time,
+		lease time.Duration,
 		limit uint,
 	) ([]*domain.CheckLink, error)
-	UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error
+	ClaimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error)
+	ReleaseLinks(ctx context.Context, owner string, ids []int64) error
+	GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error)
+	UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time, interval time.Duration) error
+	GetLinkState(ctx context.Context, linkID int64, checker string) ([]byte, error)
+	SaveLinkState(ctx context.Context, linkID int64, checker string, state []byte) error
 }
 
+// Checher gets updates of links of the link type named as the checker type.
 type Checher interface {
-	GetUpdates(link string, from, to time.Time) ([]string, error)
+	GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error)
 	GetType() string
 }
 
+// BatchChecher checks many links with shared requests. Its links are not
+// passed to GetUpdates by the scheduler. Links failed alone are returned with
+// their errors instead of updates, they keep their window.
+type BatchChecher interface {
+	Checher
+	GetBatchUpdates(
+		ctx context.Context,
+		links map[string]time.Time,
+		to time.Time,
+	) (map[string][]domain.Event, map[string]error, error)
+}
+
+// PartialBatchChecher batches some of its links only, the rest are passed to
+// GetUpdates.
+type PartialBatchChecher interface {
+	BatchChecher
+	IsBatched(link string) bool
+}
+
+// StatefulChecher compares a link with the state saved on the previous check
+// instead of relying on the check time. Updates are grouped by scopes: values
+// of the scope filter of link chats, where the empty scope stands for chats
+// without the filter.
+type StatefulChecher interface {
+	Checher
+	GetStatefulUpdates(
+		ctx context.Context,
+		link string,
+		scopes []string,
+		state []byte,
+		to time.Time,
+	) (map[string][]domain.Event, []byte, error)
+}
+
+// ConditionalChecher sends ETags of the previous check, so unchanged resources
+// are not downloaded again. ETags are saved only when all updates are
+// delivered, otherwise undelivered updates would never be found again.
+type ConditionalChecher interface {
+	Checher
+	GetConditionalUpdates(
+		ctx context.Context,
+		link string,
+		etags map[string]string,
+		from, to time.Time,
+	) ([]domain.Event, map[string]string, error)
+}
+
+// CursorChecher continues from the cursor of the previous check, e.g. the head
+// commit of a branch, so items dated before the window but added upstream
+// after the previous check are found as well. Events found after the cursor
+// are new whatever their creation time. The cursor is saved only when all
+// updates are delivered, as ETags are.
+type CursorChecher interface {
+	Checher
+	GetCursorUpdates(
+		ctx context.Context,
+		link string,
+		cursor string,
+		from, to time.Time,
+	) ([]domain.Event, string, error)
+}
+
+// GroupingChecher returns events of single items, such as commits, and groups
+// them into messages. Delivered items are dropped before grouping, so a group
+// lists new items only, however the windows of checks overlap.
+type GroupingChecher interface {
+	Checher
+	GroupEvents(events []domain.Event) []domain.Event
+}
+
+// RateLimitedChecher reports request quotas of its source. Scrapes of the
+// source slow down when a quota is low and pause until the reset when only
+// the reserve is left.
+type RateLimitedChecher interface {
+	Checher
+	RateLimits() []domain.RateLimit
+}
+
 type Client interface {
 	UpdatesPost(ctx context.Context, update *domain.Update) error
 }
@@ -37,15 +125,37 @@ type Client interface {
 type Metrics interface {
 	ObserveScrapeDurationSeconds(scrapeType string, seconds float64)
 	IncScrapesTotal(scrapeType, status string)
+	IncScrapeTimeoutsTotal(scrapeType string)
+	SetRateLimitRemaining(source string, remaining int)
+	SetScrapeQueueDepth(scrapeType string, depth int)
+	ObserveScrapeQueueWaitSeconds(scrapeType string, seconds float64)
+	IncLateEventsTotal(source string)
 }
 
 type Scheduler struct {
-	repo     Repository
-	client   Client
-	metrics  Metrics
-	checkers []Checher
-	interval time.Duration
-	pageSize uint
+	repo          Repository
+	client        Client
+	metrics       Metrics
+	types         *linktype.Registry
+	checkers      map[string]Checher
+	batchCheckers []BatchChecher
+	pools         map[string]*pool
+	interval      time.Duration
+	lookback      time.Duration
+	scrapeTimeout time.Duration
+	pageSize      uint
+	slowdown      int
+	reserve       int
+	replica       string
+	lease         time.Duration
+	minInterval   time.Duration
+	maxInterval   time.Duration
+
+	// held are links checked by workers while the tick claims links, see
+	// holdLink.
+	mu       sync.Mutex
+	claiming bool
+	held     []*domain.CheckLink
 }
 
 func NewScheduler(
@@ -53,23 +163,75 @@ func NewScheduler(
 	repo Repository,
 	client Client,
 	metrics Metrics,
+	types *linktype.Registry,
 	checkers ...Checher,
 ) *Scheduler {
+	byType := make(map[string]Checher, len(checkers))
+	batch := make([]BatchChecher, 0)
+	pools := make(map[string]*pool, len(checkers)+1)
+
+	// links without a checker only get their check time updated
+	pools[linktype.Unknown] = newPool(linktype.Unknown, 1, cfg.QueueSize, 0)
+
+	for _, checker := range checkers {
+		source := checker.GetType()
+		byType[source] = checker
+		pools[source] = newPool(source, sourceWorkers(cfg, source), cfg.QueueSize, cfg.SourceRates[source])
+
+		if batchChecker, ok := checker.(BatchChecher); ok {
+			batch = append(batch, batchChecker)
+		}
+	}
+
 	return &Scheduler{
-		repo:     repo,
-		client:   client,
-		metrics:  metrics,
-		checkers: checkers,
-		interval: cfg.Interval,
-		pageSize: cfg.PageSize,
+		repo:          repo,
+		client:        client,
+		metrics:       metrics,
+		types:         types,
+		checkers:      byType,
+		batchCheckers: batch,
+		pools:         pools,
+		interval:      cfg.Interval,
+		lookback:      cfg.Lookback,
+		scrapeTimeout: cfg.ScrapeTimeout,
+		pageSize:      cfg.PageSize,
+		slowdown:      cfg.RateLimitSlowdown,
+		reserve:       cfg.RateLimitReserve,
+		replica:       replicaID(cfg),
+		lease:         cfg.LeaseTimeout,
+		minInterval:   cfg.MinInterval,
+		maxInterval:   cfg.MaxInterval,
 	}
 }
 
-func (s *Scheduler) Run(ctx context.Context) error {
-	ch := make(chan *domain.CheckLink)
+func sourceWorkers(cfg *config.ScrapperScheduler, source string) int {
+	if workers, ok := cfg.SourceWorkers[source]; ok {
+		return workers
+	}
+
+	return cfg.Workers
+}
 
-	for range numWorkers {
-		go s.worker(ctx, ch)
+// replicaID defaults to the host name and the process ID, so replicas on one
+// host differ as well.
+func replicaID(cfg *config.ScrapperScheduler) string {
+	if cfg.ReplicaID != "" {
+		return cfg.ReplicaID
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "scrapper"
+	}
+
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+func (s *Scheduler) Run(ctx context.Context) error {
+	for _, p := range s.pools {
+		for range p.workers {
+			go s.worker(ctx, p)
+		}
 	}
 
 	schedule, err := gocron.NewScheduler()
@@ -77,11 +239,15 @@ func (s *Scheduler) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to create scheduler: %w", err)
 	}
 
+	// replicas tick at the same boundaries of the interval, see checker. A
+	// tick is skipped while the previous one still claims links.
 	_, err = schedule.NewJob(
 		gocron.DurationJob(s.interval),
 		gocron.NewTask(func() {
-			s.checker(ctx, ch)
+			s.checker(ctx)
 		}),
+		gocron.WithStartAt(gocron.WithStartDateTime(time.Now().Truncate(s.interval).Add(s.interval))),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create scheduler job: %w", err)
@@ -92,6 +258,12 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	<-ctx.Done()
 
 	err = schedule.Shutdown()
+
+	// other replicas get links left in the queues
+	for _, p := range s.pools {
+		s.releaseLinks(ctx, p.drain()...)
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to shutdown scheduler: %w", err)
 	}
@@ -99,14 +271,31 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	return nil
 }
 
-func (s *Scheduler) checker(ctx context.Context, ch chan<- *domain.CheckLink) {
-	started := time.Now()
-	cursor := time.Time{}
+// checker claims links due before the tick and queues them to pools of their
+// sources. Claimed links are leased until they are checked, a link of a
+// crashed replica is claimed again when its lease expires. Links of full
+// queues and links checked by workers meanwhile stay leased until the end of
+// the tick, otherwise they would be claimed again with the next page.
+func (s *Scheduler) checker(ctx context.Context) {
+	started := time.Now().Round(s.interval)
+	batches := make(map[string][]*domain.CheckLink, len(s.batchCheckers))
+	overflow := make([]*domain.CheckLink, 0)
+
+	s.setClaiming(ctx, true)
+
+	defer func() {
+		s.releaseLinks(ctx, overflow...)
+		s.setClaiming(ctx, false)
+	}()
 
 	for {
-		links, err := s.repo.GetCheckLinks(ctx, cursor, started, s.pageSize)
+		links, err := s.repo.ClaimCheckLinks(ctx, s.replica, started, s.lease, s.pageSize)
 		if err != nil {
-			slog.Error("failed to get links", "err", err)
+			slog.Error("failed to claim links", "err", err)
+
+			for _, batch := range batches {
+				s.releaseLinks(ctx, batch...)
+			}
 
 			return
 		}
@@ -115,127 +304,876 @@ func (s *Scheduler) checker(ctx context.Context, ch chan<- *domain.CheckLink) {
 			break
 		}
 
-		cursor = links[len(links)-1].CheckedAt
-
 		for _, link := range links {
-			ch <- link
+			if checker, ok := s.findBatchChecker(link); ok {
+				batches[checker.GetType()] = append(batches[checker.GetType()], link)
+
+				continue
+			}
+
+			if !s.enqueue(link) {
+				overflow = append(overflow, link)
+			}
 		}
 	}
+
+	for _, checker := range s.batchCheckers {
+		s.getBatchUpdates(ctx, checker, batches[checker.GetType()])
+		s.releaseLinks(ctx, batches[checker.GetType()]...)
+	}
+}
+
+// enqueue returns false if the queue of the link source is full.
+func (s *Scheduler) enqueue(link *domain.CheckLink) bool {
+	p := s.findPool(link)
+	if !p.push(link) {
+		slog.Warn("scrape queue is full", slog.Any("source", p.source), slog.Any("url", link.URL))
+
+		return false
+	}
+
+	s.metrics.SetScrapeQueueDepth(p.source, p.depth())
+
+	return true
+}
+
+func (s *Scheduler) findPool(link *domain.CheckLink) *pool {
+	if checker := s.findChecker(link.URL); checker != nil {
+		return s.pools[checker.GetType()]
+	}
+
+	return s.pools[linktype.Unknown]
 }
 
-func (s *Scheduler) worker(ctx context.Context, ch <-chan *domain.CheckLink) {
+func (s *Scheduler) worker(ctx context.Context, p *pool) {
 	for {
-		select {
-		case <-ctx.Done():
+		queued, ok := p.pop(ctx)
+		if !ok {
 			return
+		}
+
+		s.metrics.SetScrapeQueueDepth(p.source, p.depth())
+		s.metrics.ObserveScrapeQueueWaitSeconds(p.source, time.Since(queued.queuedAt).Seconds())
 
-		case link := <-ch:
-			s.getUpdates(ctx, link)
+		if p.limiter.wait(ctx) {
+			s.CheckLink(ctx, queued.link)
 		}
+
+		s.holdLink(ctx, queued.link)
+	}
+}
+
+// holdLink keeps the checked link leased while the tick claims links. A link
+// failed or paused by the rate limit is still due, so releasing it right away
+// would make the tick claim and check it again and again.
+func (s *Scheduler) holdLink(ctx context.Context, link *domain.CheckLink) {
+	s.mu.Lock()
+
+	if s.claiming {
+		s.held = append(s.held, link)
+		s.mu.Unlock()
+
+		return
+	}
+
+	s.mu.Unlock()
+
+	s.releaseLinks(ctx, link)
+}
+
+// setClaiming releases held links when the tick stops claiming links.
+func (s *Scheduler) setClaiming(ctx context.Context, claiming bool) {
+	s.mu.Lock()
+	s.claiming = claiming
+	held := s.held
+	s.held = nil
+	s.mu.Unlock()
+
+	s.releaseLinks(ctx, held...)
+}
+
+// releaseLinks lets a link not checked in this tick, e.g. because of an error,
+// be claimed on the next tick instead of when its lease expires. Links being
+// checked on shutdown are released as well.
+func (s *Scheduler) releaseLinks(ctx context.Context, links ...*domain.CheckLink) {
+	if len(links) == 0 {
+		return
+	}
+
+	ids := make([]int64, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.ID)
+	}
+
+	if err := s.repo.ReleaseLinks(context.WithoutCancel(ctx), s.replica, ids); err != nil {
+		slog.Error(
+			"failed to release links",
+			slog.Any("links", len(ids)),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// CheckLink gets updates of the link and sends them to the link chats.
+func (s *Scheduler) CheckLink(ctx context.Context, link *domain.CheckLink) {
+	_, err := s.checkLink(ctx, link)
+
+	switch {
+	case errors.As(err, &ErrRateLimited{}):
+		slog.Debug("scrapes are paused until the rate limit reset", slog.Any("url", link.URL))
+
+	case err != nil:
+		slog.Error(
+			"failed to check link",
+			slog.Any("url", link.URL),
+			slog.Any("error", err),
+		)
 	}
 }
 
-func (s *Scheduler) getUpdates(ctx context.Context, link *domain.CheckLink) {
+// CheckLinkNow checks the link out of the schedule and returns the number of
+// updates sent to the chat, events dropped by the chat filter are not counted.
+// The link is leased for the check as scheduled ones, it returns ErrLinkBusy
+// if the link is being checked already. Scrapes of the link source are limited
+// as scheduled ones, it returns ErrRateLimited if they are paused.
+func (s *Scheduler) CheckLinkNow(ctx context.Context, url string, chatID int64) (int, error) {
+	link, err := s.repo.ClaimLink(ctx, s.replica, url, s.lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim link: %w", err)
+	}
+
+	if link == nil {
+		return 0, NewErrLinkBusy(url)
+	}
+
+	defer s.releaseLinks(ctx, link)
+
+	if !s.findPool(link).limiter.wait(ctx) {
+		return 0, fmt.Errorf("failed to wait for the source limit: %w", ctx.Err())
+	}
+
+	sent, err := s.checkLink(ctx, link)
+	if err != nil {
+		return 0, err
+	}
+
+	return sent[chatID], nil
+}
+
+// checkLink returns the number of updates sent to every chat of the link.
+func (s *Scheduler) checkLink(ctx context.Context, link *domain.CheckLink) (map[int64]int, error) {
 	tm := time.Now()
+	sent := make(map[int64]int, len(link.Chats))
 
-	var (
-		updates []string
-		err     error
-	)
+	checker := s.findChecker(link.URL)
+	if checker == nil || len(link.Chats) == 0 {
+		s.updateCheckTime(ctx, link, tm, false)
+
+		return nil, nil
+	}
+
+	if !s.waitRateLimit(ctx, checker) {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to wait for the rate limit: %w", err)
+		}
+
+		return nil, NewErrRateLimited(checker.GetType())
+	}
+
+	if statefulChecker, ok := checker.(StatefulChecher); ok {
+		return sent, s.getStatefulUpdates(ctx, statefulChecker, link, sent, tm)
+	}
+
+	seen, err := s.getSeenState(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	etags, err := s.getETags(ctx, checker, link.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get etags: %w", err)
+	}
+
+	cursor, err := s.getCursor(ctx, checker, link.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cursor: %w", err)
+	}
+
+	_, seen.cursor = checker.(CursorChecher)
+
+	updates, etags, cursor, err := s.getCheckerUpdates(ctx, checker, link, etags, cursor, tm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updates: %w", err)
+	}
+
+	if !s.deliverUpdates(ctx, link, seen, updates, sent, tm) {
+		return sent, nil
+	}
+
+	// stale ETags only make the next check download unchanged resources
+	if err := s.saveETags(ctx, checker, link.ID, etags); err != nil {
+		slog.Error(
+			"failed to save etags",
+			slog.Any("url", link.URL),
+			slog.Any("error", err),
+		)
+	}
+
+	// a stale cursor makes the next check find delivered items, they are
+	// skipped by the seen state
+	if err := s.saveCursor(ctx, checker, link.ID, cursor); err != nil {
+		slog.Error(
+			"failed to save cursor",
+			slog.Any("url", link.URL),
+			slog.Any("error", err),
+		)
+	}
+
+	return sent, nil
+}
+
+// waitRateLimit spreads the quota left above the reserve until the reset when
+// the quota is below the slowdown threshold. The most limited quota of the
+// checker wins. It returns false if scrapes are paused until the reset or ctx
+// is done, the link is checked again on the next tick then.
+func (s *Scheduler) waitRateLimit(ctx context.Context, checker Checher) bool {
+	limited, ok := checker.(RateLimitedChecher)
+	if !ok {
+		return true
+	}
+
+	var delay time.Duration
+
+	for _, limit := range limited.RateLimits() {
+		untilReset := time.Until(limit.Reset)
+		if limit.Remaining >= s.slowdown || untilReset <= 0 {
+			continue
+		}
+
+		if limit.Remaining <= s.reserve {
+			return false
+		}
+
+		delay = max(delay, untilReset/time.Duration(limit.Remaining-s.reserve))
+	}
+
+	return sleep(ctx, delay)
+}
+
+// findChecker returns nil for unsupported links and link types without a
+// checker.
+func (s *Scheduler) findChecker(link string) Checher {
+	linkType, ok := s.types.Match(link)
+	if !ok {
+		return nil
+	}
+
+	return s.checkers[linkType.Name]
+}
+
+// getStatefulUpdates saves the new state after all chats got updates. Chats
+// that got an update are recorded in the seen state, so after a failed send
+// the change is found again on the next check and sent to the rest of chats
+// only. Updates sent are counted by chats in sent.
+func (s *Scheduler) getStatefulUpdates(
+	ctx context.Context,
+	checker StatefulChecher,
+	link *domain.CheckLink,
+	sent map[int64]int,
+	tm time.Time,
+) error {
+	state, err := s.repo.GetLinkState(ctx, link.ID, checker.GetType())
+	if err != nil {
+		return fmt.Errorf("failed to get link state: %w", err)
+	}
+
+	seen, err := s.getSeenState(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	scrapeCtx, cancel := s.scrapeContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	updates, state, err := checker.GetStatefulUpdates(scrapeCtx, link.URL, linkScopes(link), state, tm)
+	s.observeScrape(checker, start, err)
+
+	if err != nil {
+		return fmt.Errorf("failed to get updates: %w", err)
+	}
+
+	fresh, delivered := s.deliverScopedUpdates(ctx, link, seen, updates, sent, tm)
+
+	seen.prune(s.fetchStart(link))
+
+	raw, err := seen.marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SaveLinkState(ctx, link.ID, seenChecker, raw); err != nil {
+		return fmt.Errorf("failed to save seen state: %w", err)
+	}
+
+	if !delivered {
+		return nil
+	}
+
+	if err := s.repo.SaveLinkState(ctx, link.ID, checker.GetType(), state); err != nil {
+		return fmt.Errorf("failed to save link state: %w", err)
+	}
+
+	s.updateCheckTime(ctx, link, tm, fresh > 0)
+
+	return nil
+}
+
+// deliverScopedUpdates sends updates of every scope to chats of the scope and
+// returns the number of updates not delivered before and false if some chat
+// didn't get an update. Updates sent are counted by chats in sent. Seen items are keyed by scopes as well: chats of one
+// scope may get the update while chats of another one don't.
+func (s *Scheduler) deliverScopedUpdates(
+	ctx context.Context,
+	link *domain.CheckLink,
+	seen *seenState,
+	updates map[string][]domain.Event,
+	sent map[int64]int,
+	tm time.Time,
+) (int, bool) {
+	fresh := 0
+	delivered := true
+
+	for scope, events := range updates {
+		chats := make([]domain.LinkChat, 0, len(link.Chats))
+		for _, chat := range link.Chats {
+			if chatScope(link, chat) == scope {
+				chats = append(chats, chat)
+			}
+		}
 
-	if len(link.Chats) != 0 {
-		for _, checker := range s.checkers {
-			updates, err = s.getCheckerUpdates(checker, link, tm)
-			if err != nil {
-				slog.Error(
-					"failed to get updates",
-					slog.Any("url", link.URL),
-					slog.Any("error", err),
-				)
+		for _, event := range events {
+			key := scope + "/" + eventKey(&event)
 
+			item, ok := seen.Items[key]
+			if ok && len(item.Chats) == 0 {
 				continue
 			}
 
-			if len(updates) != 0 {
-				break
+			fresh++
+
+			item.At = tm
+			all := true
+
+			for _, chat := range chats {
+				if slices.Contains(item.Chats, chat.ChatID) {
+					continue
+				}
+
+				if !s.sendChatUpdate(ctx, link, chat, parseFilters(link, chat), &event, sent) {
+					all = false
+
+					continue
+				}
+
+				item.Chats = append(item.Chats, chat.ChatID)
+			}
+
+			switch {
+			case all:
+				item.Chats = nil
+				seen.Items[key] = item
+
+			case len(item.Chats) > 0:
+				seen.Items[key] = item
 			}
+
+			delivered = delivered && all
+		}
+	}
+
+	return fresh, delivered
+}
+
+// updateCheckTime schedules the next check, active links are checked sooner.
+// Webhook-fed links checked on demand are not scheduled.
+func (s *Scheduler) updateCheckTime(ctx context.Context, link *domain.CheckLink, tm time.Time, active bool) {
+	if link.Webhook {
+		return
+	}
+
+	err := s.repo.UpdateCheckTime(ctx, link.URL, tm, s.nextInterval(link, active))
+	if err != nil {
+		slog.Error(
+			"failed to update check time",
+			slog.Any("url", link.URL),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// nextInterval drops to the minimum after new events and doubles while the
+// link is idle up to the maximum. The shortest interval pinned by link chats
+// overrides it.
+func (s *Scheduler) nextInterval(link *domain.CheckLink, active bool) time.Duration {
+	var pinned time.Duration
+
+	for _, chat := range link.Chats {
+		if chat.Interval > 0 && (pinned == 0 || chat.Interval < pinned) {
+			pinned = chat.Interval
 		}
 	}
 
-	s.sendUpdates(ctx, link, updates)
+	switch {
+	case pinned > 0:
+		return pinned
 
-	if len(updates) != 0 || err == nil {
-		err := s.repo.UpdateCheckTime(ctx, link.URL, tm)
+	case active || link.Interval < s.minInterval:
+		return s.minInterval
+
+	default:
+		return min(2*link.Interval, s.maxInterval)
+	}
+}
+
+// findBatchChecker returns false if the link must be checked separately.
+// Links without chats are never batched as they are not checked at all.
+func (s *Scheduler) findBatchChecker(link *domain.CheckLink) (BatchChecher, bool) {
+	if len(link.Chats) == 0 {
+		return nil, false
+	}
+
+	checker, ok := s.findChecker(link.URL).(BatchChecher)
+	if partial, isPartial := checker.(PartialBatchChecher); isPartial && !partial.IsBatched(link.URL) {
+		return nil, false
+	}
+
+	return checker, ok
+}
+
+func (s *Scheduler) getBatchUpdates(
+	ctx context.Context,
+	checker BatchChecher,
+	links []*domain.CheckLink,
+) {
+	if len(links) == 0 || !s.waitRateLimit(ctx, checker) {
+		return
+	}
+
+	tm := time.Now()
+
+	froms := make(map[string]time.Time, len(links))
+	seen := make(map[string]*seenState, len(links))
+	checked := make([]*domain.CheckLink, 0, len(links))
+
+	for _, link := range links {
+		state, err := s.getSeenState(ctx, link)
 		if err != nil {
 			slog.Error(
-				"failed to update check time",
+				"failed to get seen state",
+				slog.Any("url", link.URL),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		froms[link.URL] = s.fetchStart(link)
+		seen[link.URL] = state
+		checked = append(checked, link)
+	}
+
+	if len(checked) == 0 {
+		return
+	}
+
+	// the batch is a single scrape and gets a single timeout
+	scrapeCtx, cancel := s.scrapeContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	updates, linkErrs, err := checker.GetBatchUpdates(scrapeCtx, froms, tm)
+	s.observeScrape(checker, start, err)
+
+	if err != nil {
+		slog.Error(
+			"failed to get batch updates",
+			slog.Any("type", checker.GetType()),
+			slog.Any("links", len(checked)),
+			slog.Any("error", err),
+		)
+
+		return
+	}
+
+	for _, link := range checked {
+		if err, ok := linkErrs[link.URL]; ok {
+			slog.Error(
+				"failed to get link updates",
 				slog.Any("url", link.URL),
 				slog.Any("error", err),
 			)
+
+			continue
 		}
+
+		s.deliverUpdates(ctx, link, seen[link.URL], updates[link.URL], nil, tm)
 	}
 }
 
 func (s *Scheduler) getCheckerUpdates(
+	ctx context.Context,
 	checker Checher,
 	link *domain.CheckLink,
+	etags map[string]string,
+	cursor string,
 	tm time.Time,
-) ([]string, error) {
+) ([]domain.Event, map[string]string, string, error) {
+	scrapeCtx, cancel := s.scrapeContext(ctx)
+	defer cancel()
+
+	var (
+		updates []domain.Event
+		err     error
+	)
+
 	start := time.Now()
-	updates, err := checker.GetUpdates(link.URL, link.CheckedAt, tm)
-	duration := time.Since(start).Seconds()
 
-	s.metrics.ObserveScrapeDurationSeconds(checker.GetType(), duration)
+	switch checker := checker.(type) {
+	case ConditionalChecher:
+		updates, etags, err = checker.GetConditionalUpdates(scrapeCtx, link.URL, etags, s.fetchStart(link), tm)
+
+	case CursorChecher:
+		updates, cursor, err = checker.GetCursorUpdates(scrapeCtx, link.URL, cursor, s.fetchStart(link), tm)
+
+	default:
+		updates, err = checker.GetUpdates(scrapeCtx, link.URL, s.fetchStart(link), tm)
+	}
+
+	s.observeScrape(checker, start, err)
+
+	return updates, etags, cursor, err
+}
+
+// scrapeContext limits the time of a single scrape. Zero timeout means no
+// limit.
+func (s *Scheduler) scrapeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.scrapeTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, s.scrapeTimeout)
+}
+
+func (s *Scheduler) observeScrape(checker Checher, start time.Time, err error) {
+	scrapeType := checker.GetType()
+
+	s.metrics.ObserveScrapeDurationSeconds(scrapeType, time.Since(start).Seconds())
 
 	status := "success"
 	if err != nil {
 		status = "error"
 	}
 
-	s.metrics.IncScrapesTotal(checker.GetType(), status)
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.metrics.IncScrapeTimeoutsTotal(scrapeType)
+	}
+
+	s.metrics.IncScrapesTotal(scrapeType, status)
 
-	return updates, err
+	if limited, ok := checker.(RateLimitedChecher); ok {
+		for _, limit := range limited.RateLimits() {
+			s.metrics.SetRateLimitRemaining(limit.Source, limit.Remaining)
+		}
+	}
 }
 
-func (s *Scheduler) sendUpdates(ctx context.Context, link *domain.CheckLink, updates []string) {
-	for _, update := range updates {
-		for _, chat := range link.Chats {
-			if !isValidUpdate(update, chat.Filters) {
-				continue
-			}
+// windowStart overlaps the checked window with the previous one by the
+// lookback. Events found twice are skipped by the seen state.
+func (s *Scheduler) windowStart(link *domain.CheckLink) time.Time {
+	return link.CheckedAt.Add(-s.lookback)
+}
 
-			err := s.client.UpdatesPost(ctx, &domain.Update{
-				ChatID:          chat.ChatID,
-				URL:             link.URL,
-				Message:         update,
-				Tags:            chat.Tags,
-				SendImmediately: domain.NewNull(chat.SendImmediately),
-			})
-			if err != nil {
-				slog.Error(
-					"failed to send updates",
-					slog.Any("url", link.URL),
-					slog.Any("chats", link.Chats),
-					slog.Any("error", err),
-				)
-			}
+// fetchStart extends the window by one more lookback. Events of the extension
+// not seen before appeared upstream after their window was checked, they are
+// sent and reported as late.
+func (s *Scheduler) fetchStart(link *domain.CheckLink) time.Time {
+	return s.windowStart(link).Add(-s.lookback)
+}
+
+func (s *Scheduler) getSeenState(ctx context.Context, link *domain.CheckLink) (*seenState, error) {
+	raw, err := s.repo.GetLinkState(ctx, link.ID, seenChecker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seen state: %w", err)
+	}
+
+	return newSeenState(link, raw)
+}
+
+// DeliverUpdates sends events pushed by webhooks to chats of the links. The
+// seen state is shared with checks, so an event found by a check as well is
+// sent once. The check time is kept: a webhook doesn't replace a check.
+func (s *Scheduler) DeliverUpdates(ctx context.Context, updates map[string][]domain.Event) error {
+	links, err := s.repo.GetLinksByURLs(ctx, slices.Collect(maps.Keys(updates)))
+	if err != nil {
+		return fmt.Errorf("failed to get links: %w", err)
+	}
+
+	for _, link := range links {
+		if len(link.Chats) == 0 {
+			continue
+		}
+
+		seen, err := s.getSeenState(ctx, link)
+		if err != nil {
+			return err
 		}
+
+		s.deliverEvents(ctx, link, seen, updates[link.URL], nil, time.Now())
 	}
+
+	return nil
 }
 
-func isValidUpdate(update string, filters []string) bool {
-	for _, filter := range filters {
-		if strings.HasPrefix(filter, "user=") {
-			hasAuthor := strings.Contains(
-				update,
-				fmt.Sprintf("<b>Автор</b>: <i>%s</i>\n", strings.TrimPrefix(filter, "user=")),
-			)
+// deliverUpdates sends events that are not delivered yet. The check time is
+// kept while some chat didn't get an event, so the event is found again on the
+// next check and sent to the rest of chats only. It returns false then.
+func (s *Scheduler) deliverUpdates(
+	ctx context.Context,
+	link *domain.CheckLink,
+	seen *seenState,
+	updates []domain.Event,
+	sent map[int64]int,
+	tm time.Time,
+) bool {
+	fresh, ok := s.deliverEvents(ctx, link, seen, updates, sent, tm)
+	if !ok {
+		return false
+	}
 
-			if hasAuthor {
-				return false
-			}
+	s.updateCheckTime(ctx, link, tm, fresh > 0)
+
+	return true
+}
+
+// deliverEvents returns the number of events not delivered before and false
+// if some chat didn't get an event or the seen state is not saved. Updates
+// sent are counted by chats in sent unless it is nil.
+func (s *Scheduler) deliverEvents(
+	ctx context.Context,
+	link *domain.CheckLink,
+	seen *seenState,
+	updates []domain.Event,
+	sent map[int64]int,
+	tm time.Time,
+) (int, bool) {
+	filters := make(map[int64]*filter.Filter, len(link.Chats))
+	for _, chat := range link.Chats {
+		filters[chat.ChatID] = parseFilters(link, chat)
+	}
+
+	s.reportLateEvents(link, seen, updates)
+
+	fresh := 0
+	delivered := true
+
+	for _, event := range s.groupEvents(link, seen, updates) {
+		if !seen.isDelivered(&event) {
+			fresh++
+		}
+
+		if !s.deliverEvent(ctx, link, seen, filters, &event, sent, tm) {
+			delivered = false
 		}
 	}
 
+	seen.prune(s.fetchStart(link))
+
+	raw, err := seen.marshal()
+	if err == nil {
+		err = s.repo.SaveLinkState(ctx, link.ID, seenChecker, raw)
+	}
+
+	if err != nil {
+		slog.Error(
+			"failed to save seen state",
+			slog.Any("url", link.URL),
+			slog.Any("error", err),
+		)
+
+		return fresh, false
+	}
+
+	return fresh, delivered
+}
+
+func (s *Scheduler) deliverEvent(
+	ctx context.Context,
+	link *domain.CheckLink,
+	seen *seenState,
+	filters map[int64]*filter.Filter,
+	event *domain.Event,
+	sent map[int64]int,
+	tm time.Time,
+) bool {
+	if seen.isDelivered(event) {
+		return true
+	}
+
+	keys := eventKeys(event)
+	item := seen.item(keys)
+
+	item.At = event.CreatedAt
+	if item.At.IsZero() || seen.cursor {
+		item.At = tm
+	}
+
+	delivered := true
+
+	for _, chat := range link.Chats {
+		if slices.Contains(item.Chats, chat.ChatID) {
+			continue
+		}
+
+		if !s.sendChatUpdate(ctx, link, chat, filters[chat.ChatID], event, sent) {
+			delivered = false
+
+			continue
+		}
+
+		item.Chats = append(item.Chats, chat.ChatID)
+	}
+
+	switch {
+	case delivered:
+		item.Chats = nil
+		seen.setItem(keys, item)
+
+	case len(item.Chats) > 0:
+		seen.setItem(keys, item)
+	}
+
+	return delivered
+}
+
+// reportLateEvents logs and counts events of the window extension that were
+// not seen before, see fetchStart.
+func (s *Scheduler) reportLateEvents(link *domain.CheckLink, seen *seenState, updates []domain.Event) {
+	windowStart := s.windowStart(link)
+
+	for _, event := range updates {
+		if !seen.isLate(&event, windowStart) {
+			continue
+		}
+
+		slog.Warn(
+			"late event found",
+			slog.Any("url", link.URL),
+			slog.Any("source", event.Source),
+			slog.Any("kind", event.Kind),
+			slog.Any("created_at", event.CreatedAt),
+			slog.Any("window_start", windowStart),
+		)
+
+		s.metrics.IncLateEventsTotal(event.Source)
+	}
+}
+
+// groupEvents drops delivered items of a grouping checker before grouping.
+func (s *Scheduler) groupEvents(link *domain.CheckLink, seen *seenState, updates []domain.Event) []domain.Event {
+	checker, ok := s.findChecker(link.URL).(GroupingChecher)
+	if !ok {
+		return updates
+	}
+
+	fresh := make([]domain.Event, 0, len(updates))
+
+	for _, event := range updates {
+		if !seen.isDelivered(&event) {
+			fresh = append(fresh, event)
+		}
+	}
+
+	if len(fresh) == 0 {
+		return fresh
+	}
+
+	return checker.GroupEvents(fresh)
+}
+
+// sendChatUpdate returns false if the event is not sent. Events not matching
+// the chat filter are skipped and count as sent, but only events actually
+// sent are counted in sent.
+func (s *Scheduler) sendChatUpdate(
+	ctx context.Context,
+	link *domain.CheckLink,
+	chat domain.LinkChat,
+	chatFilter *filter.Filter,
+	event *domain.Event,
+	sent map[int64]int,
+) bool {
+	if !chatFilter.Match(event) {
+		return true
+	}
+
+	err := s.client.UpdatesPost(ctx, &domain.Update{
+		ChatID:          chat.ChatID,
+		URL:             link.URL,
+		Event:           *event,
+		Tags:            chat.Tags,
+		SendImmediately: domain.NewNull(chat.SendImmediately),
+	})
+	if err != nil {
+		slog.Error(
+			"failed to send updates",
+			slog.Any("url", link.URL),
+			slog.Any("chat", chat.ChatID),
+			slog.Any("error", err),
+		)
+
+		return false
+	}
+
+	if sent != nil {
+		sent[chat.ChatID]++
+	}
+
 	return true
 }
+
+// parseFilters returns a filter matching every event for invalid filters.
+// Filters are validated when the link is added, so it happens only to filters
+// saved before that.
+func parseFilters(link *domain.CheckLink, chat domain.LinkChat) *filter.Filter {
+	chatFilter, err := filter.Parse(chat.Filters)
+	if err != nil {
+		slog.Warn(
+			"ignored invalid filters",
+			slog.Any("url", link.URL),
+			slog.Any("chat", chat.ChatID),
+			slog.Any("error", err),
+		)
+
+		return &filter.Filter{}
+	}
+
+	return chatFilter
+}
+
+// chatScope returns the part of the resource checked for the chat. Chats get
+// updates of their own scope only.
+func chatScope(link *domain.CheckLink, chat domain.LinkChat) string {
+	return parseFilters(link, chat).Scope()
+}
+
+func linkScopes(link *domain.CheckLink) []string {
+	scopes := make([]string, 0, len(link.Chats))
+
+	for _, chat := range link.Chats {
+		if scope := chatScope(link, chat); !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
@@ -0,0 +1,685 @@
+package scrapper_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exampleLink   = "https://github.com/owner/repo"
+	lookback      = 10 * time.Minute
+	scrapeTimeout = 50 * time.Millisecond
+	minInterval   = 5 * time.Minute
+	maxInterval   = 24 * time.Hour
+)
+
+var checkedAt = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type testScheduler struct {
+	scheduler *scrapper.Scheduler
+	repo      *mocks.MockRepository
+	client    *mocks.MockClient
+	metrics   *mocks.MockMetrics
+	checker   *mocks.MockChecher
+}
+
+func newTestScheduler(t *testing.T) *testScheduler {
+	t.Helper()
+
+	repo := mocks.NewMockRepository(t)
+	client := mocks.NewMockClient(t)
+	metrics := mocks.NewMockMetrics(t)
+	checker := mocks.NewMockChecher(t)
+
+	metrics.On("ObserveScrapeDurationSeconds", "github", mock.Anything).Maybe()
+	metrics.On("IncScrapesTotal", "github", mock.Anything).Maybe()
+	checker.On("GetType").Return("github").Maybe()
+
+	cfg := &config.ScrapperScheduler{
+		MinInterval:   minInterval,
+		MaxInterval:   maxInterval,
+		Lookback:      lookback,
+		ScrapeTimeout: scrapeTimeout,
+	}
+
+	return &testScheduler{
+		scheduler: scrapper.NewScheduler(cfg, repo, client, metrics, linktype.New(&config.GitLab{}), checker),
+		repo:      repo,
+		client:    client,
+		metrics:   metrics,
+		checker:   checker,
+	}
+}
+
+func newLink(chatIDs ...int64) *domain.CheckLink {
+	chats := make([]domain.LinkChat, 0, len(chatIDs))
+	for _, id := range chatIDs {
+		chats = append(chats, domain.LinkChat{ChatID: id})
+	}
+
+	return &domain.CheckLink{
+		ID:        1,
+		URL:       exampleLink,
+		Chats:     chats,
+		CheckedAt: checkedAt,
+	}
+}
+
+func newEvent(id string, createdAt time.Time) domain.Event {
+	return domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindComment,
+		ExternalID: id,
+		CreatedAt:  createdAt,
+	}
+}
+
+// updateSent matches an update with the given chat and event ID.
+func updateSent(chatID int64, id string) any {
+	return mock.MatchedBy(func(update *domain.Update) bool {
+		return update.ChatID == chatID && update.Event.ExternalID == id
+	})
+}
+
+func TestScheduler_CheckLink_SkipsSeenEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	first := newEvent("1", checkedAt.Add(-time.Minute))
+	second := newEvent("2", checkedAt.Add(time.Minute))
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{first, second}, nil).
+		Twice()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "2")).Return(nil).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	// The first event was created before the link was checked last time.
+	s.scheduler.CheckLink(ctx, link)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+
+	// The same window again: nothing is sent twice.
+	s.scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_ReportsLateEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	nextCheck := checkedAt.Add(30 * time.Minute)
+	first := newEvent("1", checkedAt.Add(time.Minute))
+	// created before the first check, but published upstream after it
+	late := newEvent("late", checkedAt.Add(15*time.Minute))
+	fresh := newEvent("fresh", nextCheck.Add(time.Minute))
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{first}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	s.scheduler.CheckLink(ctx, link)
+
+	link.CheckedAt = nextCheck
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, nextCheck.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{late, fresh}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "late")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "fresh")).Return(nil).Once()
+	s.metrics.On("IncLateEventsTotal", domain.SourceGitHub).Once()
+
+	// The late event is before the window of the second check, it is found
+	// in the extension only.
+	s.scheduler.CheckLink(ctx, link)
+}
+
+// groupingChecher groups all events into one listing their IDs.
+type groupingChecher struct {
+	*mocks.MockChecher
+}
+
+func (c groupingChecher) GroupEvents(events []domain.Event) []domain.Event {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ExternalID)
+	}
+
+	group := events[0]
+	group.Body = strings.Join(ids, ",")
+	group.Parts = ids
+
+	return []domain.Event{group}
+}
+
+func TestScheduler_CheckLink_GroupsUndeliveredItems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	cfg := &config.ScrapperScheduler{MinInterval: minInterval, MaxInterval: maxInterval, Lookback: lookback}
+	scheduler := scrapper.NewScheduler(
+		cfg, s.repo, s.client, s.metrics, linktype.New(&config.GitLab{}), groupingChecher{s.checker},
+	)
+
+	first := newEvent("1", checkedAt.Add(time.Minute))
+	second := newEvent("2", checkedAt.Add(2*time.Minute))
+	third := newEvent("3", checkedAt.Add(3*time.Minute))
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{second, first}, nil).
+		Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{third, second}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, mock.MatchedBy(func(update *domain.Update) bool {
+		return update.Event.Body == "2,1"
+	})).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, mock.MatchedBy(func(update *domain.Update) bool {
+		return update.Event.Body == "3"
+	})).Return(nil).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	scheduler.CheckLink(ctx, link)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+
+	// The windows overlap: the second item was sent in the first group.
+	scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_AdaptiveInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		pinned   []time.Duration
+		events   []domain.Event
+		want     time.Duration
+	}{
+		{name: "new link", want: minInterval},
+		{name: "idle link backs off", interval: time.Hour, want: 2 * time.Hour},
+		{name: "backoff is capped", interval: 20 * time.Hour, want: maxInterval},
+		{
+			name:     "new events reset backoff",
+			interval: 4 * time.Hour,
+			events:   []domain.Event{newEvent("1", checkedAt.Add(time.Minute))},
+			want:     minInterval,
+		},
+		{
+			name:     "shortest pinned interval wins",
+			interval: 4 * time.Hour,
+			pinned:   []time.Duration{0, 30 * time.Minute, 10 * time.Minute},
+			want:     10 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			s := newTestScheduler(t)
+			link := newLink(10)
+			link.Interval = tt.interval
+
+			for i, pinned := range tt.pinned {
+				link.Chats = append(link.Chats, domain.LinkChat{ChatID: int64(20 + i), Interval: pinned})
+			}
+
+			s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+			s.repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).Return(nil).Maybe()
+			s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+				Return(tt.events, nil).
+				Once()
+			s.client.On("UpdatesPost", ctx, mock.Anything).Return(nil).Maybe()
+			s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, tt.want).Return(nil).Once()
+
+			s.scheduler.CheckLink(ctx, link)
+		})
+	}
+}
+
+func TestScheduler_CheckLink_PartialDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10, 20)
+	event := newEvent("1", checkedAt.Add(time.Minute))
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{event}, nil).
+		Twice()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "1")).Return(errors.New("bot is down")).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+
+	// The check time is kept, so the event is found again.
+	s.scheduler.CheckLink(ctx, link)
+
+	var state struct {
+		Items map[string]struct {
+			Chats []int64 `json:"chats"`
+		} `json:"items"`
+	}
+
+	require.NoError(t, json.Unmarshal(saved, &state))
+	assert.Equal(t, []int64{10}, state.Items["github/comment/1"].Chats)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "1")).Return(nil).Once()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Once()
+
+	s.scheduler.CheckLink(ctx, link)
+}
+
+// statefulChecher reports the same change until its state is saved.
+type statefulChecher struct {
+	*mocks.MockChecher
+}
+
+func (c statefulChecher) GetStatefulUpdates(
+	_ context.Context,
+	_ string,
+	_ []string,
+	state []byte,
+	_ time.Time,
+) (map[string][]domain.Event, []byte, error) {
+	if string(state) == "changed" {
+		return map[string][]domain.Event{}, state, nil
+	}
+
+	return map[string][]domain.Event{"": {newEvent("change", checkedAt.Add(time.Minute))}}, []byte("changed"), nil
+}
+
+func TestScheduler_CheckLink_StatefulPartialDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10, 20)
+
+	cfg := &config.ScrapperScheduler{MinInterval: minInterval, MaxInterval: maxInterval, Lookback: lookback}
+	scheduler := scrapper.NewScheduler(
+		cfg, s.repo, s.client, s.metrics, linktype.New(&config.GitLab{}), statefulChecher{s.checker},
+	)
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "github").Return(nil, nil).Twice()
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "change")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "change")).Return(errors.New("bot is down")).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+
+	// The checker state is not saved, so the change is found again.
+	scheduler.CheckLink(ctx, link)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "change")).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "github", []byte("changed")).Return(nil).Once()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Only the chat that didn't get the change gets it now.
+	scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_SaveStateError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{newEvent("1", checkedAt.Add(time.Minute))}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).
+		Return(errors.New("db is down")).
+		Once()
+
+	// UpdateCheckTime is not expected: the window is checked again.
+	s.scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_CheckerError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return(nil, errors.New("rate limited")).
+		Once()
+
+	s.scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.EXPECT().GetUpdates(mock.Anything, exampleLink, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ string, _, _ time.Time) ([]domain.Event, error) {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}).
+		Once()
+	s.metrics.On("IncScrapeTimeoutsTotal", "github").Once()
+
+	start := time.Now()
+	s.scheduler.CheckLink(ctx, link)
+
+	assert.Less(t, time.Since(start), time.Second, "scrape should be stopped by the timeout")
+}
+
+func TestScheduler_CheckLink_SavesETagsAfterDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mocks.NewMockRepository(t)
+	client := mocks.NewMockClient(t)
+	metrics := mocks.NewMockMetrics(t)
+	checker := mocks.NewMockConditionalChecher(t)
+
+	metrics.On("ObserveScrapeDurationSeconds", "github", mock.Anything).Maybe()
+	metrics.On("IncScrapesTotal", "github", mock.Anything).Maybe()
+	checker.On("GetType").Return("github").Maybe()
+
+	cfg := &config.ScrapperScheduler{Lookback: lookback}
+	scheduler := scrapper.NewScheduler(cfg, repo, client, metrics, linktype.New(&config.GitLab{}), checker)
+	link := newLink(10)
+
+	repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	repo.On("GetLinkState", ctx, link.ID, "etags").Return([]byte(`{"issues":"old"}`), nil).Once()
+	checker.On("GetConditionalUpdates", mock.Anything, exampleLink, map[string]string{"issues": "old"}, mock.Anything, mock.Anything).
+		Return([]domain.Event{newEvent("1", checkedAt.Add(time.Minute))}, map[string]string{"issues": "new"}, nil).
+		Once()
+	client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).Return(nil).Once()
+	repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("SaveLinkState", ctx, link.ID, "etags", []byte(`{"issues":"new"}`)).Return(nil).Once()
+
+	scheduler.CheckLink(ctx, link)
+}
+
+// cursorChecher reports a rebased commit dated long before the window after
+// the cursor "old".
+type cursorChecher struct {
+	*mocks.MockChecher
+}
+
+func (c cursorChecher) GetCursorUpdates(
+	_ context.Context,
+	_ string,
+	cursor string,
+	_, _ time.Time,
+) ([]domain.Event, string, error) {
+	if cursor != "old" {
+		return []domain.Event{}, cursor, nil
+	}
+
+	return []domain.Event{newEvent("rebased", checkedAt.Add(-24*time.Hour))}, "new", nil
+}
+
+func TestScheduler_CheckLink_SavesCursorAfterDelivery(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	cfg := &config.ScrapperScheduler{MinInterval: minInterval, MaxInterval: maxInterval, Lookback: lookback}
+	scheduler := scrapper.NewScheduler(
+		cfg, s.repo, s.client, s.metrics, linktype.New(&config.GitLab{}), cursorChecher{s.checker},
+	)
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.repo.On("GetLinkState", ctx, link.ID, "cursor").Return([]byte("old"), nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "rebased")).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).Return(nil).Once()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "cursor", []byte("new")).Return(nil).Once()
+
+	// The commit is older than the window but new after the cursor.
+	scheduler.CheckLink(ctx, link)
+}
+
+func TestScheduler_CheckLink_PausedByRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := mocks.NewMockRepository(t)
+	checker := mocks.NewMockRateLimitedChecher(t)
+
+	checker.On("GetType").Return("github").Maybe()
+	checker.On("RateLimits").Return([]domain.RateLimit{{
+		Source:    "github",
+		Remaining: 50,
+		Reset:     time.Now().Add(time.Hour),
+	}})
+
+	cfg := &config.ScrapperScheduler{
+		Lookback:          lookback,
+		RateLimitSlowdown: 1000,
+		RateLimitReserve:  100,
+	}
+
+	scheduler := scrapper.NewScheduler(
+		cfg,
+		repo,
+		mocks.NewMockClient(t),
+		mocks.NewMockMetrics(t),
+		linktype.New(&config.GitLab{}),
+		checker,
+	)
+
+	// Neither GetUpdates nor UpdateCheckTime is expected: the link stays due.
+	scheduler.CheckLink(ctx, newLink(10))
+
+	repo.On("ClaimLink", ctx, mock.Anything, exampleLink, mock.Anything).Return(newLink(10), nil).Once()
+	repo.On("ReleaseLinks", mock.Anything, mock.Anything, []int64{1}).Return(nil).Once()
+
+	_, err := scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	assert.ErrorAs(t, err, &scrapper.ErrRateLimited{}, "checks out of the schedule should be paused as well")
+}
+
+func TestScheduler_CheckLinkNow(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+
+	var saved []byte
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{newEvent("1", checkedAt), newEvent("2", checkedAt.Add(time.Minute))}, nil).
+		Twice()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "2")).Return(nil).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, minInterval).Return(nil).Once()
+	s.repo.On("ClaimLink", ctx, mock.Anything, exampleLink, mock.Anything).Return(link, nil).Twice()
+	s.repo.On("ReleaseLinks", mock.Anything, mock.Anything, []int64{link.ID}).Return(nil).Twice()
+
+	updates, err := s.scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates, "both events should be new")
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, 2*minInterval).Return(nil).Once()
+
+	link.Interval = minInterval
+
+	updates, err = s.scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	require.NoError(t, err)
+	assert.Zero(t, updates, "delivered events should not be counted again")
+}
+
+func TestScheduler_CheckLinkNow_Leased(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+
+	// A scheduled check holds the lease: neither GetUpdates nor ReleaseLinks
+	// is expected.
+	s.repo.On("ClaimLink", ctx, mock.Anything, exampleLink, mock.Anything).Return(nil, nil).Once()
+
+	_, err := s.scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	assert.ErrorAs(t, err, &scrapper.ErrLinkBusy{}, "a leased link should not be checked twice")
+}
+
+func TestScheduler_CheckLinkNow_Webhook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+	link.Webhook = true
+
+	s.repo.On("ClaimLink", ctx, mock.Anything, exampleLink, mock.Anything).Return(link, nil).Once()
+	s.repo.On("ReleaseLinks", mock.Anything, mock.Anything, []int64{link.ID}).Return(nil).Once()
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{newEvent("1", checkedAt.Add(time.Minute))}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).Return(nil).Once()
+
+	// UpdateCheckTime is not expected: webhook-fed links are not scheduled.
+	updates, err := s.scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updates)
+}
+
+func TestScheduler_CheckLinkNow_CountsFilteredUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10, 20)
+	link.Chats[0].Filters = []string{"type:comment"}
+
+	release := newEvent("2", checkedAt.Add(time.Minute))
+	release.Kind = domain.KindRelease
+
+	s.repo.On("ClaimLink", ctx, mock.Anything, exampleLink, mock.Anything).Return(link, nil).Once()
+	s.repo.On("ReleaseLinks", mock.Anything, mock.Anything, []int64{link.ID}).Return(nil).Once()
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.checker.On("GetUpdates", mock.Anything, exampleLink, checkedAt.Add(-2*lookback), mock.Anything).
+		Return([]domain.Event{newEvent("1", checkedAt.Add(time.Minute)), release}, nil).
+		Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "1")).Return(nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(20, "2")).Return(nil).Once()
+	s.repo.On("SaveLinkState", ctx, link.ID, "seen", mock.Anything).Return(nil).Once()
+	s.repo.On("UpdateCheckTime", ctx, exampleLink, mock.Anything, mock.Anything).Return(nil).Once()
+
+	// The release is dropped by the filter of the chat.
+	updates, err := s.scheduler.CheckLinkNow(ctx, exampleLink, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updates, "only updates sent to the chat should be counted")
+}
+
+func TestScheduler_DeliverUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := newTestScheduler(t)
+	link := newLink(10)
+	event := newEvent("1", checkedAt.Add(time.Minute))
+
+	updates := map[string][]domain.Event{
+		exampleLink:             {event},
+		exampleLink + "/issues": {event},
+	}
+
+	var saved []byte
+
+	s.repo.On("GetLinksByURLs", ctx, mock.MatchedBy(func(urls []string) bool { return len(urls) == 2 })).
+		Return([]*domain.CheckLink{link}, nil).
+		Twice()
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(nil, nil).Once()
+	s.client.On("UpdatesPost", ctx, updateSent(10, "1")).Return(nil).Once()
+	s.repo.EXPECT().SaveLinkState(ctx, link.ID, "seen", mock.Anything).
+		Run(func(_ context.Context, _ int64, _ string, state []byte) { saved = state }).
+		Return(nil).
+		Twice()
+
+	require.NoError(t, s.scheduler.DeliverUpdates(ctx, updates))
+
+	s.repo.On("GetLinkState", ctx, link.ID, "seen").Return(saved, nil).Once()
+
+	// A redelivered webhook is not sent twice, the check time is never updated.
+	require.NoError(t, s.scheduler.DeliverUpdates(ctx, updates))
+}
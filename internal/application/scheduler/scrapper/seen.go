@@ -0,0 +1,182 @@
+package scrapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// seenChecker is the link state key of delivered events of time window checks.
+const seenChecker = "seen"
+
+// seenState tracks events delivered from a link. Time windows of checks
+// overlap by the lookback, so items found at a window boundary or published
+// late upstream are returned again and skipped here.
+type seenState struct {
+	// Since is the high-water mark. Items before it are pruned, so events
+	// created before it are never sent again.
+	Since time.Time           `json:"since"`
+	Items map[string]seenItem `json:"items"`
+
+	// cursor is set for events of cursor checkers. They are new whatever
+	// their creation time, so their items are kept by the time they were
+	// found.
+	cursor bool
+}
+
+// seenItem holds chats that got the event while some chats didn't because of
+// send errors. The event is delivered when Chats is empty.
+type seenItem struct {
+	At    time.Time `json:"at"`
+	Chats []int64   `json:"chats,omitempty"`
+}
+
+// newSeenState starts the high-water mark at the last check of the link, so
+// events before the link was tracked are not sent.
+func newSeenState(link *domain.CheckLink, raw []byte) (*seenState, error) {
+	state := &seenState{
+		Since: link.CheckedAt,
+		Items: make(map[string]seenItem),
+	}
+
+	if raw == nil {
+		return state, nil
+	}
+
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal seen state: %w", err)
+	}
+
+	if state.Items == nil {
+		state.Items = make(map[string]seenItem)
+	}
+
+	return state, nil
+}
+
+// isDelivered reports whether every part of the event is delivered.
+func (s *seenState) isDelivered(event *domain.Event) bool {
+	if !s.cursor && !event.CreatedAt.IsZero() && event.CreatedAt.Before(s.Since) {
+		return true
+	}
+
+	for _, key := range eventKeys(event) {
+		item, ok := s.Items[key]
+		if !ok || len(item.Chats) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isLate reports whether the event created after the high-water mark and
+// before the window start was never seen. Items are kept down to the mark, so
+// the event was not found by previous checks.
+func (s *seenState) isLate(event *domain.Event, windowStart time.Time) bool {
+	if event.CreatedAt.IsZero() || event.CreatedAt.Before(s.Since) || !event.CreatedAt.Before(windowStart) {
+		return false
+	}
+
+	for _, key := range eventKeys(event) {
+		if _, ok := s.Items[key]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// item returns chats that got every undelivered part of the event.
+func (s *seenState) item(keys []string) seenItem {
+	var (
+		item  seenItem
+		first = true
+	)
+
+	for _, key := range keys {
+		part, ok := s.Items[key]
+		if ok && len(part.Chats) == 0 {
+			continue
+		}
+
+		if first {
+			item.Chats = slices.Clone(part.Chats)
+			first = false
+
+			continue
+		}
+
+		item.Chats = slices.DeleteFunc(item.Chats, func(chat int64) bool {
+			return !slices.Contains(part.Chats, chat)
+		})
+	}
+
+	return item
+}
+
+// setItem keeps delivered parts as they are.
+func (s *seenState) setItem(keys []string, item seenItem) {
+	for _, key := range keys {
+		if part, ok := s.Items[key]; ok && len(part.Chats) == 0 {
+			continue
+		}
+
+		s.Items[key] = seenItem{
+			At:    item.At,
+			Chats: slices.Clone(item.Chats),
+		}
+	}
+}
+
+// prune moves the high-water mark to the start of the fetched range and drops
+// items before it.
+func (s *seenState) prune(from time.Time) {
+	if from.After(s.Since) {
+		s.Since = from
+	}
+
+	for key, item := range s.Items {
+		if item.At.Before(s.Since) {
+			delete(s.Items, key)
+		}
+	}
+}
+
+func (s *seenState) marshal() ([]byte, error) {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal seen state: %w", err)
+	}
+
+	return raw, nil
+}
+
+// eventKeys returns keys of parts of grouped events and the key of the event
+// otherwise.
+func eventKeys(event *domain.Event) []string {
+	if len(event.Parts) == 0 {
+		return []string{eventKey(event)}
+	}
+
+	keys := make([]string, 0, len(event.Parts))
+	for _, part := range event.Parts {
+		keys = append(keys, event.Source+"/"+event.Kind+"/"+part)
+	}
+
+	return keys
+}
+
+// eventKey falls back to the creation time for events without an external ID.
+func eventKey(event *domain.Event) string {
+	id := event.ExternalID
+	if id == "" {
+		id = event.URL + "@" + strconv.FormatInt(event.CreatedAt.UnixNano(), 10)
+	}
+
+	return event.Source + "/" + event.Kind + "/" + id
+}
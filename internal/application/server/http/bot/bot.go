@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/bot"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -29,8 +30,10 @@ func (s *Server) UpdatesPost(
 	ctx context.Context,
 	req *bot.LinkUpdate,
 ) (bot.UpdatesPostRes, error) {
+	event := linkEventToEvent(req.GetEvent().Value)
+
 	if req.GetSendImmediately().Value {
-		msg := tgbotapi.NewMessage(req.GetChatID().Value, req.GetMessage().Value)
+		msg := tgbotapi.NewMessage(req.GetChatID().Value, render.Event(&event))
 		msg.ParseMode = tgbotapi.ModeHTML
 
 		s.channels.TelegramResp() <- msg
@@ -39,10 +42,10 @@ func (s *Server) UpdatesPost(
 	}
 
 	err := s.repo.AddUpdate(ctx, &domain.Update{
-		ChatID:  req.GetChatID().Value,
-		URL:     req.URL.Value.String(),
-		Message: req.GetMessage().Value,
-		Tags:    req.GetTags(),
+		ChatID: req.GetChatID().Value,
+		URL:    req.URL.Value.String(),
+		Event:  event,
+		Tags:   req.GetTags(),
 	})
 	if err != nil {
 		return &bot.ApiErrorResponse{
@@ -53,3 +56,17 @@ func (s *Server) UpdatesPost(
 
 	return &bot.UpdatesPostOK{}, nil
 }
+
+func linkEventToEvent(event bot.LinkEvent) domain.Event {
+	return domain.Event{
+		Source:     event.Source.Value,
+		Kind:       event.Kind.Value,
+		ExternalID: event.ExternalID.Value,
+		Author:     event.Author.Value,
+		Title:      event.Title.Value,
+		URL:        event.URL.Value,
+		Body:       event.Body.Value,
+		CreatedAt:  event.CreatedAt.Value,
+		Attributes: event.Attributes.Value,
+	}
+}
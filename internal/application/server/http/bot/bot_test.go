@@ -7,9 +7,11 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/bot"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/bot/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	api "github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/bot"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -19,6 +21,32 @@ import (
 
 const exampleURL = "https://example.com"
 
+var (
+	testEvent = domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindIssue,
+		ExternalID: "1",
+		Author:     "user",
+		Title:      "title",
+		URL:        exampleURL,
+		Body:       "body",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+		Attributes: map[string]string{domain.AttrStatus: "open"},
+	}
+
+	testLinkEvent = api.LinkEvent{
+		Source:     api.NewOptString(domain.SourceGitHub),
+		Kind:       api.NewOptString(domain.KindIssue),
+		ExternalID: api.NewOptString("1"),
+		Author:     api.NewOptString("user"),
+		Title:      api.NewOptString("title"),
+		URL:        api.NewOptString(exampleURL),
+		Body:       api.NewOptString("body"),
+		CreatedAt:  api.NewOptDateTime(time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)),
+		Attributes: api.NewOptLinkEventAttributes(api.LinkEventAttributes{domain.AttrStatus: "open"}),
+	}
+)
+
 func TestServer_UpdatesPost_Success(t *testing.T) {
 	t.Parallel()
 
@@ -32,22 +60,21 @@ func TestServer_UpdatesPost_Success(t *testing.T) {
 	require.NoError(t, err, "url parse error")
 
 	tags := []string{"tag1", "tag2"}
-	msg := "msg1"
 	chatID := int64(12345)
 
 	req := &api.LinkUpdate{
 		URL:             api.NewOptURI(*parsedURL),
 		ChatID:          api.NewOptInt64(chatID),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(false),
 	}
 
 	repoMock.On("AddUpdate", ctx, &domain.Update{
-		ChatID:  chatID,
-		URL:     exampleURL,
-		Message: msg,
-		Tags:    tags,
+		ChatID: chatID,
+		URL:    exampleURL,
+		Event:  testEvent,
+		Tags:   tags,
 	}).Return(nil).Once()
 
 	res, err := server.UpdatesPost(ctx, req)
@@ -69,22 +96,21 @@ func TestServer_UpdatesPost_Error(t *testing.T) {
 	require.NoError(t, err, "url parse error")
 
 	tags := []string{"tag1", "tag2"}
-	msg := "msg"
 	chatID := int64(12345)
 
 	req := &api.LinkUpdate{
 		URL:             api.NewOptURI(*parsedURL),
 		ChatID:          api.NewOptInt64(chatID),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(false),
 	}
 
 	repoMock.On("AddUpdate", ctx, &domain.Update{
-		ChatID:  chatID,
-		URL:     exampleURL,
-		Message: msg,
-		Tags:    tags,
+		ChatID: chatID,
+		URL:    exampleURL,
+		Event:  testEvent,
+		Tags:   tags,
 	}).Return(errors.New("database error")).Once()
 
 	res, err := server.UpdatesPost(ctx, req)
@@ -112,13 +138,12 @@ func TestServer_ImmediatelyUpdatesPost_Success(t *testing.T) {
 	require.NoError(t, err, "url parse error")
 
 	tags := []string{"tag1", "tag2"}
-	msg := "msg"
 	chatID := int64(12345)
 
 	req := &api.LinkUpdate{
 		URL:             api.NewOptURI(*parsedURL),
 		ChatID:          api.NewOptInt64(chatID),
-		Message:         api.NewOptString(msg),
+		Event:           api.NewOptLinkEvent(testLinkEvent),
 		Tags:            tags,
 		SendImmediately: api.NewOptBool(true),
 	}
@@ -133,7 +158,7 @@ func TestServer_ImmediatelyUpdatesPost_Success(t *testing.T) {
 
 		tgMessage, ok := resp.(tgbotapi.MessageConfig)
 		require.True(t, ok, "invalid message type")
-		assert.Equal(t, msg, tgMessage.Text, "message error")
+		assert.Equal(t, render.Event(&testEvent), tgMessage.Text, "message error")
 	}()
 
 	res, err := server.UpdatesPost(ctx, req)
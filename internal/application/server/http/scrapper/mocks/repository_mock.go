@@ -4,11 +4,10 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -71,29 +70,29 @@ func (_c *MockRepository_DeleteChat_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
-// GetCheckLinks provides a mock function with given fields: ctx, from, to, limit
-func (_m *MockRepository) GetCheckLinks(ctx context.Context, from time.Time, to time.Time, limit uint) ([]*domain.CheckLink, error) {
-	ret := _m.Called(ctx, from, to, limit)
+// GetLinksByURLs provides a mock function with given fields: ctx, urls
+func (_m *MockRepository) GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error) {
+	ret := _m.Called(ctx, urls)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCheckLinks")
+		panic("no return value specified for GetLinksByURLs")
 	}
 
 	var r0 []*domain.CheckLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint) ([]*domain.CheckLink, error)); ok {
-		return rf(ctx, from, to, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]*domain.CheckLink, error)); ok {
+		return rf(ctx, urls)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint) []*domain.CheckLink); ok {
-		r0 = rf(ctx, from, to, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*domain.CheckLink); ok {
+		r0 = rf(ctx, urls)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.CheckLink)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint) error); ok {
-		r1 = rf(ctx, from, to, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, urls)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -101,33 +100,31 @@ func (_m *MockRepository) GetCheckLinks(ctx context.Context, from time.Time, to
 	return r0, r1
 }
 
-// MockRepository_GetCheckLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCheckLinks'
-type MockRepository_GetCheckLinks_Call struct {
+// MockRepository_GetLinksByURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLinksByURLs'
+type MockRepository_GetLinksByURLs_Call struct {
 	*mock.Call
 }
 
-// GetCheckLinks is a helper method to define mock.On call
+// GetLinksByURLs is a helper method to define mock.On call
 //   - ctx context.Context
-//   - from time.Time
-//   - to time.Time
-//   - limit uint
-func (_e *MockRepository_Expecter) GetCheckLinks(ctx interface{}, from interface{}, to interface{}, limit interface{}) *MockRepository_GetCheckLinks_Call {
-	return &MockRepository_GetCheckLinks_Call{Call: _e.mock.On("GetCheckLinks", ctx, from, to, limit)}
+//   - urls []string
+func (_e *MockRepository_Expecter) GetLinksByURLs(ctx interface{}, urls interface{}) *MockRepository_GetLinksByURLs_Call {
+	return &MockRepository_GetLinksByURLs_Call{Call: _e.mock.On("GetLinksByURLs", ctx, urls)}
 }
 
-func (_c *MockRepository_GetCheckLinks_Call) Run(run func(ctx context.Context, from time.Time, to time.Time, limit uint)) *MockRepository_GetCheckLinks_Call {
+func (_c *MockRepository_GetLinksByURLs_Call) Run(run func(ctx context.Context, urls []string)) *MockRepository_GetLinksByURLs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(uint))
+		run(args[0].(context.Context), args[1].([]string))
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetCheckLinks_Call) Return(_a0 []*domain.CheckLink, _a1 error) *MockRepository_GetCheckLinks_Call {
+func (_c *MockRepository_GetLinksByURLs_Call) Return(_a0 []*domain.CheckLink, _a1 error) *MockRepository_GetLinksByURLs_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockRepository_GetCheckLinks_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, uint) ([]*domain.CheckLink, error)) *MockRepository_GetCheckLinks_Call {
+func (_c *MockRepository_GetLinksByURLs_Call) RunAndReturn(run func(context.Context, []string) ([]*domain.CheckLink, error)) *MockRepository_GetLinksByURLs_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -298,6 +295,104 @@ func (_c *MockRepository_RegisterChat_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// SetLinkInterval provides a mock function with given fields: ctx, chatID, url, interval
+func (_m *MockRepository) SetLinkInterval(ctx context.Context, chatID int64, url string, interval time.Duration) error {
+	ret := _m.Called(ctx, chatID, url, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLinkInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, chatID, url, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetLinkInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLinkInterval'
+type MockRepository_SetLinkInterval_Call struct {
+	*mock.Call
+}
+
+// SetLinkInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - url string
+//   - interval time.Duration
+func (_e *MockRepository_Expecter) SetLinkInterval(ctx interface{}, chatID interface{}, url interface{}, interval interface{}) *MockRepository_SetLinkInterval_Call {
+	return &MockRepository_SetLinkInterval_Call{Call: _e.mock.On("SetLinkInterval", ctx, chatID, url, interval)}
+}
+
+func (_c *MockRepository_SetLinkInterval_Call) Run(run func(ctx context.Context, chatID int64, url string, interval time.Duration)) *MockRepository_SetLinkInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetLinkInterval_Call) Return(_a0 error) *MockRepository_SetLinkInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetLinkInterval_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *MockRepository_SetLinkInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLinkWebhook provides a mock function with given fields: ctx, chatID, url, webhook
+func (_m *MockRepository) SetLinkWebhook(ctx context.Context, chatID int64, url string, webhook bool) error {
+	ret := _m.Called(ctx, chatID, url, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLinkWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, bool) error); ok {
+		r0 = rf(ctx, chatID, url, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepository_SetLinkWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLinkWebhook'
+type MockRepository_SetLinkWebhook_Call struct {
+	*mock.Call
+}
+
+// SetLinkWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - url string
+//   - webhook bool
+func (_e *MockRepository_Expecter) SetLinkWebhook(ctx interface{}, chatID interface{}, url interface{}, webhook interface{}) *MockRepository_SetLinkWebhook_Call {
+	return &MockRepository_SetLinkWebhook_Call{Call: _e.mock.On("SetLinkWebhook", ctx, chatID, url, webhook)}
+}
+
+func (_c *MockRepository_SetLinkWebhook_Call) Run(run func(ctx context.Context, chatID int64, url string, webhook bool)) *MockRepository_SetLinkWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockRepository_SetLinkWebhook_Call) Return(_a0 error) *MockRepository_SetLinkWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepository_SetLinkWebhook_Call) RunAndReturn(run func(context.Context, int64, string, bool) error) *MockRepository_SetLinkWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TrackLink provides a mock function with given fields: ctx, link
 func (_m *MockRepository) TrackLink(ctx context.Context, link *domain.Link) (*domain.Link, error) {
 	ret := _m.Called(ctx, link)
@@ -417,54 +512,6 @@ func (_c *MockRepository_UntrackLink_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
-// UpdateCheckTime provides a mock function with given fields: ctx, url, checkedAt
-func (_m *MockRepository) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error {
-	ret := _m.Called(ctx, url, checkedAt)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateCheckTime")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
-		r0 = rf(ctx, url, checkedAt)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// MockRepository_UpdateCheckTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCheckTime'
-type MockRepository_UpdateCheckTime_Call struct {
-	*mock.Call
-}
-
-// UpdateCheckTime is a helper method to define mock.On call
-//   - ctx context.Context
-//   - url string
-//   - checkedAt time.Time
-func (_e *MockRepository_Expecter) UpdateCheckTime(ctx interface{}, url interface{}, checkedAt interface{}) *MockRepository_UpdateCheckTime_Call {
-	return &MockRepository_UpdateCheckTime_Call{Call: _e.mock.On("UpdateCheckTime", ctx, url, checkedAt)}
-}
-
-func (_c *MockRepository_UpdateCheckTime_Call) Run(run func(ctx context.Context, url string, checkedAt time.Time)) *MockRepository_UpdateCheckTime_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
-	})
-	return _c
-}
-
-func (_c *MockRepository_UpdateCheckTime_Call) Return(_a0 error) *MockRepository_UpdateCheckTime_Call {
-	_c.Call.Return(_a0)
-	return _c
-}
-
-func (_c *MockRepository_UpdateCheckTime_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *MockRepository_UpdateCheckTime_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
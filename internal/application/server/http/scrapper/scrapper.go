@@ -9,6 +9,10 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/filter"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	scheduler "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	repository "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/scrapper"
@@ -25,21 +29,32 @@ type Repository interface {
 		chatID int64,
 		tag string,
 	) ([]*domain.Link, error)
-	GetCheckLinks(
-		ctx context.Context,
-		from, to time.Time,
-		limit uint,
-	) ([]*domain.CheckLink, error)
-	UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error
+	SetLinkInterval(ctx context.Context, chatID int64, url string, interval time.Duration) error
+	SetLinkWebhook(ctx context.Context, chatID int64, url string, webhook bool) error
+	GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error)
+}
+
+type LinkChecker interface {
+	CheckLinkNow(ctx context.Context, url string, chatID int64) (int, error)
 }
 
+// minPinnedInterval keeps pinned intervals from exhausting quotas of sources.
+const minPinnedInterval = time.Minute
+
 type Server struct {
-	repo Repository
+	repo    Repository
+	types   *linktype.Registry
+	checker LinkChecker
+	// webhooks is false if the webhook endpoint is disabled.
+	webhooks bool
 }
 
-func NewServer(repo Repository) *Server {
+func NewServer(repo Repository, types *linktype.Registry, checker LinkChecker, cfg *config.GitHub) *Server {
 	return &Server{
-		repo: repo,
+		repo:     repo,
+		types:    types,
+		checker:  checker,
+		webhooks: cfg.WebhookSecret != "",
 	}
 }
 
@@ -84,9 +99,32 @@ func (s *Server) LinksPost(
 	req *scrapper.AddLinkRequest,
 	params scrapper.LinksPostParams,
 ) (scrapper.LinksPostRes, error) {
+	// descriptions of bad requests are shown to users
+	canonical, _, ok := s.types.Canonicalize(req.Link.Value.String())
+	if !ok {
+		return &scrapper.ApiErrorResponse{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: scrapper.NewOptString("Ссылка не поддерживается"),
+		}, nil
+	}
+
+	if s.types.Internal(canonical) {
+		return &scrapper.ApiErrorResponse{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: scrapper.NewOptString("Ссылки на внутренние адреса не поддерживаются"),
+		}, nil
+	}
+
+	if _, err := filter.Parse(req.Filters); err != nil {
+		return &scrapper.ApiErrorResponse{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: scrapper.NewOptString(fmt.Sprintf("Некорректный фильтр: %s", err)),
+		}, nil
+	}
+
 	link, err := s.repo.TrackLink(ctx, &domain.Link{
 		ChatID:          params.TgChatID,
-		URL:             req.Link.Value.String(),
+		URL:             canonical,
 		Tags:            req.Tags,
 		Filters:         req.Filters,
 		SendImmediately: domain.NewNull(req.SendImmediately.Value),
@@ -146,7 +184,12 @@ func (s *Server) LinksDelete(
 	req *scrapper.RemoveLinkRequest,
 	params scrapper.LinksDeleteParams,
 ) (scrapper.LinksDeleteRes, error) {
-	link, err := s.repo.UntrackLink(ctx, params.TgChatID, req.Link.Value.String())
+	linkURL := req.Link.Value.String()
+	if canonical, _, ok := s.types.Canonicalize(linkURL); ok {
+		linkURL = canonical
+	}
+
+	link, err := s.repo.UntrackLink(ctx, params.TgChatID, linkURL)
 
 	switch {
 	case errors.As(err, &repository.ErrUnregister{}):
@@ -178,6 +221,174 @@ func (s *Server) LinksDelete(
 	}
 }
 
+// LinksIntervalPut pins the check interval of the link, a request without the
+// interval returns the link to the adaptive schedule.
+func (s *Server) LinksIntervalPut(
+	ctx context.Context,
+	req *scrapper.SetLinkIntervalRequest,
+	params scrapper.LinksIntervalPutParams,
+) (scrapper.LinksIntervalPutRes, error) {
+	linkURL := req.Link.Value.String()
+	if canonical, _, ok := s.types.Canonicalize(linkURL); ok {
+		linkURL = canonical
+	}
+
+	var interval time.Duration
+
+	if req.IntervalSeconds.Set {
+		seconds := req.IntervalSeconds.Value
+		if seconds < int64(minPinnedInterval/time.Second) || seconds > math.MaxInt64/int64(time.Second) {
+			return &scrapper.LinksIntervalPutBadRequest{
+				Code: scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+				Description: scrapper.NewOptString(
+					fmt.Sprintf("Интервал должен быть не меньше %s", minPinnedInterval),
+				),
+			}, nil
+		}
+
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	err := s.repo.SetLinkInterval(ctx, params.TgChatID, linkURL, interval)
+
+	switch {
+	case errors.As(err, &repository.ErrLinkNotFound{}):
+		return &scrapper.LinksIntervalPutNotFound{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusNotFound)),
+			Description: scrapper.NewOptString("Ссылка не отслеживается"),
+		}, nil
+
+	case err != nil:
+		return &scrapper.LinksIntervalPutBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusInternalServerError)),
+			Description: scrapper.NewOptString(err.Error()),
+		}, nil
+
+	default:
+		return &scrapper.LinksIntervalPutOK{}, nil
+	}
+}
+
+// LinksWebhookPut switches the link between updates from GitHub webhooks and
+// scheduled checks. The mark is shared by all chats of the link, so only links
+// covered by webhooks can be marked while the webhook endpoint is enabled.
+func (s *Server) LinksWebhookPut(
+	ctx context.Context,
+	req *scrapper.SetLinkWebhookRequest,
+	params scrapper.LinksWebhookPutParams,
+) (scrapper.LinksWebhookPutRes, error) {
+	linkURL, tpe, ok := s.types.Canonicalize(req.Link.Value.String())
+	if !ok {
+		return &scrapper.LinksWebhookPutNotFound{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusNotFound)),
+			Description: scrapper.NewOptString("Ссылка не отслеживается"),
+		}, nil
+	}
+
+	webhook := req.Webhook.Value
+
+	switch {
+	case webhook && !s.webhooks:
+		return &scrapper.LinksWebhookPutBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: scrapper.NewOptString("Вебхуки не настроены"),
+		}, nil
+
+	case webhook && tpe.Name != linktype.GitHub && tpe.Name != linktype.GitHubBranch:
+		return &scrapper.LinksWebhookPutBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusBadRequest)),
+			Description: scrapper.NewOptString("Вебхуки доступны только для репозиториев и веток GitHub"),
+		}, nil
+	}
+
+	err := s.repo.SetLinkWebhook(ctx, params.TgChatID, linkURL, webhook)
+
+	switch {
+	case errors.As(err, &repository.ErrLinkNotFound{}):
+		return &scrapper.LinksWebhookPutNotFound{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusNotFound)),
+			Description: scrapper.NewOptString("Ссылка не отслеживается"),
+		}, nil
+
+	case err != nil:
+		return &scrapper.LinksWebhookPutBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusInternalServerError)),
+			Description: scrapper.NewOptString(err.Error()),
+		}, nil
+
+	default:
+		return &scrapper.LinksWebhookPutOK{}, nil
+	}
+}
+
+// LinksCheckPost checks the link out of the schedule. Updates are sent to all
+// chats of the link, the response has the number of them.
+func (s *Server) LinksCheckPost(
+	ctx context.Context,
+	req *scrapper.CheckLinkRequest,
+	params scrapper.LinksCheckPostParams,
+) (scrapper.LinksCheckPostRes, error) {
+	linkURL := req.Link.Value.String()
+	if canonical, _, ok := s.types.Canonicalize(linkURL); ok {
+		linkURL = canonical
+	}
+
+	links, err := s.repo.GetLinksByURLs(ctx, []string{linkURL})
+	if err != nil {
+		return &scrapper.LinksCheckPostBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusInternalServerError)),
+			Description: scrapper.NewOptString(err.Error()),
+		}, nil
+	}
+
+	link := findChatLink(links, params.TgChatID)
+	if link == nil {
+		return &scrapper.LinksCheckPostNotFound{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusNotFound)),
+			Description: scrapper.NewOptString("Ссылка не отслеживается"),
+		}, nil
+	}
+
+	updates, err := s.checker.CheckLinkNow(ctx, link.URL, params.TgChatID)
+
+	switch {
+	case errors.As(err, &scheduler.ErrRateLimited{}):
+		return &scrapper.LinksCheckPostTooManyRequests{}, nil
+
+	case errors.As(err, &scheduler.ErrLinkBusy{}):
+		return &scrapper.LinksCheckPostConflict{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusConflict)),
+			Description: scrapper.NewOptString("Ссылка уже проверяется"),
+		}, nil
+
+	case err != nil:
+		return &scrapper.LinksCheckPostBadRequest{
+			Code:        scrapper.NewOptString(http.StatusText(http.StatusInternalServerError)),
+			Description: scrapper.NewOptString(err.Error()),
+		}, nil
+	}
+
+	//nolint:gosec //has overflow check
+	var count = int32(min(updates, math.MaxInt32))
+
+	return &scrapper.CheckLinkResponse{
+		Updates: scrapper.NewOptInt32(count),
+	}, nil
+}
+
+// findChatLink returns nil if the chat doesn't track the link.
+func findChatLink(links []*domain.CheckLink, chatID int64) *domain.CheckLink {
+	for _, link := range links {
+		for _, chat := range link.Chats {
+			if chat.ChatID == chatID {
+				return link
+			}
+		}
+	}
+
+	return nil
+}
+
 func domainLinksToResponse(links []*domain.Link) scrapper.LinksGetRes {
 	respLinks := make([]scrapper.LinkResponse, 0, len(links))
 
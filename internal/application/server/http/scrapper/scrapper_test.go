@@ -6,9 +6,13 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	scheduler "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/scrapper/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	repository "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/scrapper"
 	api "github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/scrapper"
@@ -19,6 +23,8 @@ import (
 
 const validURL = "http://example.com"
 
+var types = linktype.New(&config.GitLab{BaseURL: "https://gitlab.com"})
+
 func TestTgChatIDPost_Success(t *testing.T) {
 	t.Parallel()
 
@@ -27,7 +33,7 @@ func TestTgChatIDPost_Success(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("RegisterChat", ctx, int64(123)).Return(nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.TgChatIDPostParams{ID: 123}
 	res, err := srv.TgChatIDPost(ctx, params)
 	require.NoError(t, err, "Expected no error on successful registration")
@@ -45,7 +51,7 @@ func TestTgChatIDPost_Error(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("RegisterChat", ctx, int64(456)).Return(expectedErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.TgChatIDPostParams{ID: 456}
 
 	res, err := srv.TgChatIDPost(ctx, params)
@@ -75,7 +81,7 @@ func TestTgChatIDDelete_Success(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("DeleteChat", ctx, int64(789)).Return(nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.TgChatIDDeleteParams{ID: 789}
 	res, err := srv.TgChatIDDelete(ctx, params)
 	require.NoError(t, err, "Expected no error on successful delete")
@@ -93,7 +99,7 @@ func TestTgChatIDDelete_NotFound(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("DeleteChat", ctx, int64(101)).Return(unregErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.TgChatIDDeleteParams{ID: 101}
 
 	res, err := srv.TgChatIDDelete(ctx, params)
@@ -112,7 +118,7 @@ func TestTgChatIDDelete_GenericError(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("DeleteChat", ctx, int64(202)).Return(genErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.TgChatIDDeleteParams{ID: 202}
 	res, err := srv.TgChatIDDelete(ctx, params)
 	require.NoError(t, err, "Expected no transport error")
@@ -151,7 +157,7 @@ func TestLinksPost_Success(t *testing.T) {
 		SendImmediately: domain.NewNull(true),
 	}, nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 
 	req := &api.AddLinkRequest{
 		Link:            api.NewOptURI(*parsedValidURL),
@@ -175,6 +181,40 @@ func TestLinksPost_Success(t *testing.T) {
 	assert.Equal(t, req.Filters, linkResp.Filters, "Expected filters to match")
 }
 
+func TestLinksPost_CanonicalURL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	const canonicalURL = "https://github.com/owner/repo"
+
+	parsedURL, err := url.Parse("http://github.com/Owner/Repo/")
+	require.NoError(t, err, "Expected no error on URL")
+
+	repoMock := mocks.NewMockRepository(t)
+	repoMock.On("TrackLink", ctx, mock.MatchedBy(func(link *domain.Link) bool {
+		return link.URL == canonicalURL
+	})).Return(&domain.Link{
+		ID:     1001,
+		ChatID: 333,
+		URL:    canonicalURL,
+	}, nil).Once()
+
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
+
+	req := &api.AddLinkRequest{
+		Link: api.NewOptURI(*parsedURL),
+	}
+	params := api.LinksPostParams{TgChatID: 333}
+
+	res, err := srv.LinksPost(ctx, req, params)
+	require.NoError(t, err, "Expected no error on successful link tracking")
+
+	linkResp, ok := res.(*api.LinkResponse)
+	require.True(t, ok, "Expected response to be LinkResponse")
+	assert.Equal(t, canonicalURL, linkResp.URL.Value.String(), "Expected canonical URL")
+}
+
 func TestLinksPost_TrackLinkError(t *testing.T) {
 	t.Parallel()
 
@@ -187,7 +227,7 @@ func TestLinksPost_TrackLinkError(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("TrackLink", ctx, mock.Anything).Return(nil, expectedErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 
 	req := &api.AddLinkRequest{
 		Link:            api.NewOptURI(*parsedValidURL),
@@ -216,6 +256,103 @@ func TestLinksPost_TrackLinkError(t *testing.T) {
 	)
 }
 
+func TestLinksPost_InvalidFilters(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	parsedValidURL, err := url.Parse(validURL)
+	require.NoError(t, err, "Expected no error on valid URL")
+
+	repoMock := mocks.NewMockRepository(t)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
+
+	req := &api.AddLinkRequest{
+		Link:    api.NewOptURI(*parsedValidURL),
+		Filters: []string{"type:comment (user:alice"},
+	}
+	params := api.LinksPostParams{TgChatID: 555}
+
+	res, err := srv.LinksPost(ctx, req, params)
+	require.NoError(t, err, "Expected no transport error")
+
+	apiErr, ok := res.(*api.ApiErrorResponse)
+	require.True(t, ok, "Expected response to be ApiErrorResponse")
+	assert.Equal(
+		t,
+		http.StatusText(http.StatusBadRequest),
+		apiErr.Code.Value,
+		"Expected error code to match",
+	)
+	assert.Equal(t, "Некорректный фильтр: позиция 14: незакрытая скобка", apiErr.Description.Value)
+}
+
+func TestLinksPost_UnsupportedLink(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// github.com links must match formats of GitHub, they aren't tracked as pages
+	parsedURL, err := url.Parse("https://github.com/owner")
+	require.NoError(t, err, "Expected no error on URL")
+
+	repoMock := mocks.NewMockRepository(t)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
+
+	req := &api.AddLinkRequest{
+		Link: api.NewOptURI(*parsedURL),
+	}
+	params := api.LinksPostParams{TgChatID: 555}
+
+	res, err := srv.LinksPost(ctx, req, params)
+	require.NoError(t, err, "Expected no transport error")
+
+	apiErr, ok := res.(*api.ApiErrorResponse)
+	require.True(t, ok, "Expected response to be ApiErrorResponse")
+	assert.Equal(
+		t,
+		http.StatusText(http.StatusBadRequest),
+		apiErr.Code.Value,
+		"Expected error code to match",
+	)
+	assert.Equal(t, "Ссылка не поддерживается", apiErr.Description.Value)
+}
+
+func TestLinksPost_InternalLink(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	for _, link := range []string{
+		"http://169.254.169.254/latest/meta-data",
+		"http://localhost:8080/feed",
+		"http://10.0.0.1/rss",
+	} {
+		t.Run(link, func(t *testing.T) {
+			t.Parallel()
+
+			parsedURL, err := url.Parse(link)
+			require.NoError(t, err, "Expected no error on URL")
+
+			repoMock := mocks.NewMockRepository(t)
+			srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
+
+			req := &api.AddLinkRequest{
+				Link: api.NewOptURI(*parsedURL),
+			}
+			params := api.LinksPostParams{TgChatID: 555}
+
+			res, err := srv.LinksPost(ctx, req, params)
+			require.NoError(t, err, "Expected no transport error")
+
+			apiErr, ok := res.(*api.ApiErrorResponse)
+			require.True(t, ok, "Expected response to be ApiErrorResponse")
+			assert.Equal(t, http.StatusText(http.StatusBadRequest), apiErr.Code.Value, "Expected error code to match")
+			assert.Equal(t, "Ссылки на внутренние адреса не поддерживаются", apiErr.Description.Value)
+		})
+	}
+}
+
 func TestLinksGet_Success(t *testing.T) {
 	t.Parallel()
 
@@ -240,7 +377,7 @@ func TestLinksGet_Success(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("ListLinks", ctx, int64(777)).Return(links, nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.LinksGetParams{TgChatID: 777}
 	res, err := srv.LinksGet(ctx, params)
 	require.NoError(t, err, "Expected no error on successful listing")
@@ -272,7 +409,7 @@ func TestLinksGetBy_Success(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("ListLinksByTag", ctx, int64(777), "tag").Return(links, nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.LinksGetParams{TgChatID: 777, Tag: api.NewOptString("tag")}
 	res, err := srv.LinksGet(ctx, params)
 	require.NoError(t, err, "Expected no error on successful listing")
@@ -301,7 +438,7 @@ func TestLinksGet_Error(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("ListLinks", ctx, int64(888)).Return(nil, expectedErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 	params := api.LinksGetParams{TgChatID: 888}
 
 	res, err := srv.LinksGet(ctx, params)
@@ -332,7 +469,7 @@ func TestLinksDelete_NotFound(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("UntrackLink", ctx, int64(111), mock.Anything).Return(nil, unregErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 
 	req := &api.RemoveLinkRequest{
 		Link: api.NewOptURI(*parsedValidURL),
@@ -358,7 +495,7 @@ func TestLinksDelete_GenericError(t *testing.T) {
 	repoMock := mocks.NewMockRepository(t)
 	repoMock.On("UntrackLink", ctx, int64(222), mock.Anything).Return(nil, genErr).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 
 	req := &api.RemoveLinkRequest{
 		Link: api.NewOptURI(*parsedValidURL),
@@ -392,7 +529,7 @@ func TestLinksDelete_Success(t *testing.T) {
 		Filters: []string{"b"},
 	}, nil).Once()
 
-	srv := scrapper.NewServer(repoMock)
+	srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
 
 	req := &api.RemoveLinkRequest{
 		Link: api.NewOptURI(*parsedValidURL),
@@ -412,3 +549,264 @@ func TestLinksDelete_Success(t *testing.T) {
 	assert.Equal(t, []string{"a"}, linkResp.Tags, "Expected tags to match")
 	assert.Equal(t, []string{"b"}, linkResp.Filters, "Expected filters to match")
 }
+
+func TestLinksIntervalPut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		link     string
+		seconds  api.OptInt64
+		interval time.Duration
+		repoErr  error
+		want     api.LinksIntervalPutRes
+	}{
+		{
+			name:     "pinned",
+			link:     "https://GitHub.com/Owner/Repo/",
+			seconds:  api.NewOptInt64(600),
+			interval: 10 * time.Minute,
+			want:     &api.LinksIntervalPutOK{},
+		},
+		{
+			name: "adaptive",
+			link: validURL,
+			want: &api.LinksIntervalPutOK{},
+		},
+		{
+			name:     "not tracked",
+			link:     validURL,
+			seconds:  api.NewOptInt64(60),
+			interval: time.Minute,
+			repoErr:  repository.NewErrLinkNotFound(validURL),
+			want:     &api.LinksIntervalPutNotFound{},
+		},
+		{
+			name:    "too short",
+			link:    validURL,
+			seconds: api.NewOptInt64(59),
+			want:    &api.LinksIntervalPutBadRequest{},
+		},
+		{
+			name:     "repository error",
+			link:     validURL,
+			seconds:  api.NewOptInt64(60),
+			interval: time.Minute,
+			repoErr:  errors.New("update error"),
+			want:     &api.LinksIntervalPutBadRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsedURL, err := url.Parse(tt.link)
+			require.NoError(t, err, "Expected no error on valid URL")
+
+			canonical, _, ok := types.Canonicalize(tt.link)
+			require.True(t, ok, "Expected link to be supported")
+
+			repoMock := mocks.NewMockRepository(t)
+			if _, bad := tt.want.(*api.LinksIntervalPutBadRequest); !bad || tt.repoErr != nil {
+				repoMock.On("SetLinkInterval", ctx, int64(555), canonical, tt.interval).Return(tt.repoErr).Once()
+			}
+
+			srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{})
+
+			req := &api.SetLinkIntervalRequest{
+				Link:            api.NewOptURI(*parsedURL),
+				IntervalSeconds: tt.seconds,
+			}
+			params := api.LinksIntervalPutParams{TgChatID: 555}
+
+			res, err := srv.LinksIntervalPut(ctx, req, params)
+			require.NoError(t, err, "Expected no transport error")
+			assert.IsType(t, tt.want, res, "Expected response type to match")
+		})
+	}
+}
+
+func TestLinksWebhookPut(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		link     string
+		webhook  bool
+		secret   string
+		repoErr  error
+		wantRepo bool
+		want     api.LinksWebhookPutRes
+	}{
+		{
+			name:     "repository",
+			link:     "https://GitHub.com/Owner/Repo/",
+			webhook:  true,
+			secret:   "secret",
+			wantRepo: true,
+			want:     &api.LinksWebhookPutOK{},
+		},
+		{
+			name:     "branch",
+			link:     "https://github.com/owner/repo/tree/main",
+			webhook:  true,
+			secret:   "secret",
+			wantRepo: true,
+			want:     &api.LinksWebhookPutOK{},
+		},
+		{
+			name:     "polling",
+			link:     validURL,
+			wantRepo: true,
+			want:     &api.LinksWebhookPutOK{},
+		},
+		{
+			name:    "webhooks disabled",
+			link:    "https://github.com/owner/repo",
+			webhook: true,
+			want:    &api.LinksWebhookPutBadRequest{},
+		},
+		{
+			name:    "not github",
+			link:    validURL,
+			webhook: true,
+			secret:  "secret",
+			want:    &api.LinksWebhookPutBadRequest{},
+		},
+		{
+			name:     "not tracked",
+			link:     "https://github.com/owner/repo",
+			webhook:  true,
+			secret:   "secret",
+			repoErr:  repository.NewErrLinkNotFound("https://github.com/owner/repo"),
+			wantRepo: true,
+			want:     &api.LinksWebhookPutNotFound{},
+		},
+		{
+			name:     "repository error",
+			link:     "https://github.com/owner/repo",
+			secret:   "secret",
+			repoErr:  errors.New("update error"),
+			wantRepo: true,
+			want:     &api.LinksWebhookPutBadRequest{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsedURL, err := url.Parse(tt.link)
+			require.NoError(t, err, "Expected no error on valid URL")
+
+			canonical, _, ok := types.Canonicalize(tt.link)
+			require.True(t, ok, "Expected link to be supported")
+
+			repoMock := mocks.NewMockRepository(t)
+			if tt.wantRepo {
+				repoMock.On("SetLinkWebhook", ctx, int64(555), canonical, tt.webhook).Return(tt.repoErr).Once()
+			}
+
+			srv := scrapper.NewServer(repoMock, types, mocks.NewMockLinkChecker(t), &config.GitHub{WebhookSecret: tt.secret})
+
+			req := &api.SetLinkWebhookRequest{
+				Link:    api.NewOptURI(*parsedURL),
+				Webhook: api.NewOptBool(tt.webhook),
+			}
+			params := api.LinksWebhookPutParams{TgChatID: 555}
+
+			res, err := srv.LinksWebhookPut(ctx, req, params)
+			require.NoError(t, err, "Expected no transport error")
+			assert.IsType(t, tt.want, res, "Expected response type to match")
+		})
+	}
+}
+
+func TestLinksCheckPost(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	link := &domain.CheckLink{
+		ID:    1,
+		URL:   "https://github.com/owner/repo",
+		Chats: []domain.LinkChat{{ChatID: 555}, {ChatID: 777}},
+	}
+
+	tests := []struct {
+		name       string
+		links      []*domain.CheckLink
+		repoErr    error
+		updates    int
+		checkerErr error
+		want       api.LinksCheckPostRes
+	}{
+		{
+			name:    "checked",
+			links:   []*domain.CheckLink{link},
+			updates: 3,
+			want:    &api.CheckLinkResponse{Updates: api.NewOptInt32(3)},
+		},
+		{
+			name:  "not tracked",
+			links: []*domain.CheckLink{},
+			want: &api.LinksCheckPostNotFound{
+				Code:        api.NewOptString(http.StatusText(http.StatusNotFound)),
+				Description: api.NewOptString("Ссылка не отслеживается"),
+			},
+		},
+		{
+			name:       "rate limited",
+			links:      []*domain.CheckLink{link},
+			checkerErr: scheduler.NewErrRateLimited("github"),
+			want:       &api.LinksCheckPostTooManyRequests{},
+		},
+		{
+			name:       "being checked",
+			links:      []*domain.CheckLink{link},
+			checkerErr: scheduler.NewErrLinkBusy(link.URL),
+			want: &api.LinksCheckPostConflict{
+				Code:        api.NewOptString(http.StatusText(http.StatusConflict)),
+				Description: api.NewOptString("Ссылка уже проверяется"),
+			},
+		},
+		{
+			name:    "repository error",
+			repoErr: errors.New("select error"),
+			want: &api.LinksCheckPostBadRequest{
+				Code:        api.NewOptString(http.StatusText(http.StatusInternalServerError)),
+				Description: api.NewOptString("select error"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parsedURL, err := url.Parse("https://GitHub.com/Owner/Repo/")
+			require.NoError(t, err, "Expected no error on valid URL")
+
+			repoMock := mocks.NewMockRepository(t)
+			repoMock.On("GetLinksByURLs", ctx, []string{link.URL}).Return(tt.links, tt.repoErr).Once()
+
+			checkerMock := mocks.NewMockLinkChecker(t)
+			if len(tt.links) > 0 {
+				checkerMock.On("CheckLinkNow", ctx, link.URL, int64(555)).Return(tt.updates, tt.checkerErr).Once()
+			}
+
+			srv := scrapper.NewServer(repoMock, types, checkerMock, &config.GitHub{})
+
+			req := &api.CheckLinkRequest{Link: api.NewOptURI(*parsedURL)}
+			params := api.LinksCheckPostParams{TgChatID: 555}
+
+			res, err := srv.LinksCheckPost(ctx, req, params)
+			require.NoError(t, err, "Expected no transport error")
+			assert.Equal(t, tt.want, res, "Expected response to match")
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// maxPayloadSize is the limit of GitHub webhook payloads.
+const maxPayloadSize = 25 << 20
+
+type Parser interface {
+	ParseEvent(event string, payload []byte) (map[string][]domain.Event, error)
+}
+
+type Deliverer interface {
+	DeliverUpdates(ctx context.Context, updates map[string][]domain.Event) error
+}
+
+// Server receives GitHub webhooks. Payloads are accepted only with the
+// signature of the configured secret, so the endpoint is disabled without it.
+type Server struct {
+	secret    []byte
+	parser    Parser
+	deliverer Deliverer
+}
+
+func New(cfg *config.GitHub, parser Parser, deliverer Deliverer) *Server {
+	return &Server{
+		secret:    []byte(cfg.WebhookSecret),
+		parser:    parser,
+		deliverer: deliverer,
+	}
+}
+
+func (s *Server) RegisterRoutes(mux *http.ServeMux) {
+	if len(s.secret) == 0 {
+		return
+	}
+
+	mux.HandleFunc("POST /webhooks/github", s.githubHandler)
+}
+
+func (s *Server) githubHandler(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+
+		return
+	}
+
+	if !s.verify(payload, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+
+		return
+	}
+
+	event := r.Header.Get("X-GitHub-Event")
+	delivery := r.Header.Get("X-GitHub-Delivery")
+
+	updates, err := s.parser.ParseEvent(event, payload)
+	if err != nil {
+		slog.Error(
+			"failed to parse webhook",
+			slog.Any("event", event),
+			slog.Any("delivery", delivery),
+			slog.Any("error", err),
+		)
+
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+
+		return
+	}
+
+	if len(updates) != 0 {
+		if err := s.deliverer.DeliverUpdates(r.Context(), updates); err != nil {
+			slog.Error(
+				"failed to deliver webhook updates",
+				slog.Any("event", event),
+				slog.Any("delivery", delivery),
+				slog.Any("error", err),
+			)
+
+			http.Error(w, "failed to deliver updates", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify checks the "sha256=" prefixed HMAC of the payload.
+func (s *Server) verify(payload []byte, signature string) bool {
+	sum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+
+	got, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,101 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/webhook"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/webhook/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	secret  = "secret"
+	payload = `{
+		"action": "published",
+		"repository": {"full_name": "example/repo"},
+		"release": {"id": 4, "tag_name": "v1.0.0"}
+	}`
+)
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newRequest(signature string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", "release")
+	req.Header.Set("X-GitHub-Delivery", "delivery")
+	req.Header.Set("X-Hub-Signature-256", signature)
+
+	return req
+}
+
+func newMux(t *testing.T, cfg *config.GitHub, deliverer webhook.Deliverer) *http.ServeMux {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	webhook.New(cfg, github.NewWebhook(), deliverer).RegisterRoutes(mux)
+
+	return mux
+}
+
+func TestServer_Delivers(t *testing.T) {
+	t.Parallel()
+
+	deliverer := mocks.NewMockDeliverer(t)
+	deliverer.On("DeliverUpdates", mock.Anything, mock.MatchedBy(func(updates map[string][]domain.Event) bool {
+		return len(updates["https://github.com/example/repo"]) == 1 &&
+			len(updates["https://github.com/example/repo/releases"]) == 1
+	})).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	newMux(t, &config.GitHub{WebhookSecret: secret}, deliverer).ServeHTTP(rr, newRequest(sign(payload)))
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestServer_InvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	for _, signature := range []string{"", "sha256=00", sign(payload + " ")} {
+		rr := httptest.NewRecorder()
+		newMux(t, &config.GitHub{WebhookSecret: secret}, mocks.NewMockDeliverer(t)).ServeHTTP(rr, newRequest(signature))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	}
+}
+
+func TestServer_DeliveryFailed(t *testing.T) {
+	t.Parallel()
+
+	deliverer := mocks.NewMockDeliverer(t)
+	deliverer.On("DeliverUpdates", mock.Anything, mock.Anything).Return(errors.New("db is down")).Once()
+
+	rr := httptest.NewRecorder()
+	newMux(t, &config.GitHub{WebhookSecret: secret}, deliverer).ServeHTTP(rr, newRequest(sign(payload)))
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
+func TestServer_DisabledWithoutSecret(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	newMux(t, &config.GitHub{}, mocks.NewMockDeliverer(t)).ServeHTTP(rr, newRequest(sign(payload)))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
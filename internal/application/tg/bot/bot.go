@@ -102,6 +102,18 @@ func (b *Bot) setCommands() error {
 			Command:     "list",
 			Description: "Показать список отслеживаемых ссылок",
 		},
+		tgbotapi.BotCommand{
+			Command:     "interval",
+			Description: "Изменить интервал проверки ссылки",
+		},
+		tgbotapi.BotCommand{
+			Command:     "webhook",
+			Description: "Получать обновления ссылки из вебхуков",
+		},
+		tgbotapi.BotCommand{
+			Command:     "check",
+			Description: "Проверить ссылку сейчас",
+		},
 	)
 
 	if _, err := b.api.Request(commands); err != nil {
@@ -32,7 +32,7 @@ func (h *Callbacker) Handle(_ context.Context, state *State) *fsm.Result[*State]
 		}
 
 	case state.Message == trackAddFilters.String():
-		ans := "Введите фильтры через пробел.\nПример: user:dummy type:comment"
+		ans := "Введите выражение фильтра.\nПример: type:comment -user:dummy (keyword:release OR score>=5)"
 		msg := tgbotapi.NewEditMessageText(state.ChatID, state.MessageID, ans)
 		h.channels.TelegramResp() <- msg
 
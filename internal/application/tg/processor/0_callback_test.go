@@ -75,7 +75,7 @@ func TestHandleTrackAddFilters(t *testing.T) {
 		require.True(t, ok, "not tg edit message")
 		assert.Equal(
 			t,
-			"Введите фильтры через пробел.\nПример: user:dummy type:comment",
+			"Введите выражение фильтра.\nПример: type:comment -user:dummy (keyword:release OR score>=5)",
 			msg.Text,
 			"wrong message",
 		)
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"strings"
 
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
 )
@@ -14,6 +15,17 @@ func NewCommander() *Commander {
 }
 
 func (h *Commander) Handle(_ context.Context, state *State) *fsm.Result[*State] {
+	// "/check {link}" checks the link without asking for it.
+	if link, ok := strings.CutPrefix(state.Message, "/check "); ok && strings.TrimSpace(link) != "" {
+		state.Message = strings.TrimSpace(link)
+
+		return &fsm.Result[*State]{
+			NextState:        checkRun,
+			IsAutoTransition: true,
+			Result:           state,
+		}
+	}
+
 	switch state.Message {
 	case "/start":
 		return &fsm.Result[*State]{
@@ -43,6 +55,27 @@ func (h *Commander) Handle(_ context.Context, state *State) *fsm.Result[*State]
 			Result:           state,
 		}
 
+	case "/interval":
+		return &fsm.Result[*State]{
+			NextState:        interval,
+			IsAutoTransition: true,
+			Result:           state,
+		}
+
+	case "/webhook":
+		return &fsm.Result[*State]{
+			NextState:        webhook,
+			IsAutoTransition: true,
+			Result:           state,
+		}
+
+	case "/check":
+		return &fsm.Result[*State]{
+			NextState:        check,
+			IsAutoTransition: true,
+			Result:           state,
+		}
+
 	case "/list":
 		return &fsm.Result[*State]{
 			NextState:        list,
@@ -68,6 +68,54 @@ func TestHandle_ListCommand(t *testing.T) {
 	assert.Equal(t, state, result.Result, "Result should contain the original state")
 }
 
+func TestHandle_CheckCommand(t *testing.T) {
+	t.Parallel()
+
+	commander := processor.NewCommander()
+	state := &processor.State{Message: "/check"}
+	result := commander.Handle(context.Background(), state)
+
+	assert.Equal(t, "check", result.NextState.String(), "NextState should be check")
+	assert.True(t, result.IsAutoTransition, "IsAutoTransition should be true")
+	assert.Equal(t, state, result.Result, "Result should contain the original state")
+}
+
+func TestHandle_CheckCommandWithLink(t *testing.T) {
+	t.Parallel()
+
+	commander := processor.NewCommander()
+	state := &processor.State{Message: "/check  https://github.com/owner/repo"}
+	result := commander.Handle(context.Background(), state)
+
+	assert.Equal(t, "check_run", result.NextState.String(), "NextState should be checkRun")
+	assert.True(t, result.IsAutoTransition, "IsAutoTransition should be true")
+	assert.Equal(t, "https://github.com/owner/repo", result.Result.Message, "Message should contain the link")
+}
+
+func TestHandle_IntervalCommand(t *testing.T) {
+	t.Parallel()
+
+	commander := processor.NewCommander()
+	state := &processor.State{Message: "/interval"}
+	result := commander.Handle(context.Background(), state)
+
+	assert.Equal(t, "interval", result.NextState.String(), "NextState should be interval")
+	assert.True(t, result.IsAutoTransition, "IsAutoTransition should be true")
+	assert.Equal(t, state, result.Result, "Result should contain the original state")
+}
+
+func TestHandle_WebhookCommand(t *testing.T) {
+	t.Parallel()
+
+	commander := processor.NewCommander()
+	state := &processor.State{Message: "/webhook"}
+	result := commander.Handle(context.Background(), state)
+
+	assert.Equal(t, "webhook", result.NextState.String(), "NextState should be webhook")
+	assert.True(t, result.IsAutoTransition, "IsAutoTransition should be true")
+	assert.Equal(t, state, result.Result, "Result should contain the original state")
+}
+
 func TestHandleUnknownCommand(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,31 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const checkPrompt = "Введите ссылку, которую хотите проверить."
+
+type Checker struct {
+	channels Channels
+}
+
+func NewChecker(channels Channels) *Checker {
+	return &Checker{
+		channels: channels,
+	}
+}
+
+func (h *Checker) Handle(_ context.Context, state *State) *fsm.Result[*State] {
+	msg := tgbotapi.NewMessage(state.ChatID, checkPrompt)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		NextState:        checkRun,
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
@@ -0,0 +1,46 @@
+package processor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle_Checker(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	checker := processor.NewChecker(channels)
+
+	state := &processor.State{
+		ChatID: 123,
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ans := <-channels.TelegramResp()
+		msg, ok := ans.(tgbotapi.MessageConfig)
+		require.True(t, ok, "not tg message")
+
+		assert.Equal(t, "Введите ссылку, которую хотите проверить.", msg.Text, "Message should prompt for the link")
+		assert.Equal(t, state.ChatID, msg.ChatID, "ChatID should match the state's ChatID")
+	}()
+
+	result := checker.Handle(context.Background(), state)
+
+	assert.Equal(t, "check_run", result.NextState.String(), "NextState should be checkRun")
+	assert.False(t, result.IsAutoTransition, "IsAutoTransition should be false")
+	assert.Equal(t, state, result.Result, "Result should contain the state")
+
+	wg.Wait()
+}
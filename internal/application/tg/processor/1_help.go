@@ -11,6 +11,9 @@ const helperAnswer = `📌 Доступные команды:
 - /track – подписаться на обновления
 - /track – отписаться от обновлений
 - /list – показать все подписки
+- /interval – изменить интервал проверки ссылки
+- /webhook – получать обновления ссылки из вебхуков GitHub
+- /check – проверить ссылку сейчас
 - /help – справка по командам
 `
 
@@ -35,6 +35,9 @@ func TestHandleHelper(t *testing.T) {
 - /track – подписаться на обновления
 - /track – отписаться от обновлений
 - /list – показать все подписки
+- /interval – изменить интервал проверки ссылки
+- /webhook – получать обновления ссылки из вебхуков GitHub
+- /check – проверить ссылку сейчас
 - /help – справка по командам
 `, msg.Text, "Message text should match helperAnswer")
 		assert.Equal(t, state.ChatID, msg.ChatID, "ChatID should match the state's ChatID")
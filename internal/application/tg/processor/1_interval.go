@@ -0,0 +1,32 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const intervalPrompt = "Введите ссылку и интервал проверки через пробел, например " +
+	"https://github.com/owner/repo 30m. Чтобы интервал подбирался автоматически, введите auto вместо интервала."
+
+type Intervaler struct {
+	channels Channels
+}
+
+func NewIntervaler(channels Channels) *Intervaler {
+	return &Intervaler{
+		channels: channels,
+	}
+}
+
+func (h *Intervaler) Handle(_ context.Context, state *State) *fsm.Result[*State] {
+	msg := tgbotapi.NewMessage(state.ChatID, intervalPrompt)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		NextState:        intervalSet,
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
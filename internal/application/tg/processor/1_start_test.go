@@ -49,6 +49,9 @@ func TestHandleStater(t *testing.T) {
 - /track – подписаться на обновления
 - /untrack – отписаться от обновлений
 - /list – показать все подписки
+- /interval – изменить интервал проверки ссылки
+- /webhook – получать обновления ссылки из вебхуков GitHub
+- /check – проверить ссылку сейчас
 - /help – справка по командам
 
 Начни с /track и будь в курсе важных событий! 🚀
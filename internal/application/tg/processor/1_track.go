@@ -11,7 +11,11 @@ import (
 const trackerAnswer = `Введите ссылку на ресурс, который хотите отслеживать.
 Доступные сайты:
 	- GitHub
-	- StackOverflow
+	- GitLab
+	- StackOverflow и другие сайты Stack Exchange
+	- RSS и Atom ленты
+	- Go модули, npm, PyPI и crates.io
+	- Любые другие веб-страницы
 `
 
 type Tracker struct {
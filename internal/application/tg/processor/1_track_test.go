@@ -35,7 +35,11 @@ func TestHandle_Tracker(t *testing.T) {
 		assert.Equal(t, `Введите ссылку на ресурс, который хотите отслеживать.
 Доступные сайты:
 	- GitHub
-	- StackOverflow
+	- GitLab
+	- StackOverflow и другие сайты Stack Exchange
+	- RSS и Atom ленты
+	- Go модули, npm, PyPI и crates.io
+	- Любые другие веб-страницы
 `, msg.Text, "Message text should match trackerAnswer")
 		assert.Equal(t, state.ChatID, msg.ChatID, "ChatID should match the state's ChatID")
 	}()
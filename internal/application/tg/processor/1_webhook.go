@@ -0,0 +1,32 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const webhookPrompt = "Введите ссылку на репозиторий или ветку GitHub и on или off через пробел, например " +
+	"https://github.com/owner/repo on. С on обновления приходят из вебхуков GitHub, с off ссылка проверяется по расписанию."
+
+type Webhooker struct {
+	channels Channels
+}
+
+func NewWebhooker(channels Channels) *Webhooker {
+	return &Webhooker{
+		channels: channels,
+	}
+}
+
+func (h *Webhooker) Handle(_ context.Context, state *State) *fsm.Result[*State] {
+	msg := tgbotapi.NewMessage(state.ChatID, webhookPrompt)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		NextState:        webhookSet,
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
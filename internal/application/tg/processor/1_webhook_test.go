@@ -0,0 +1,47 @@
+package processor_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle_Webhooker(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	webhooker := processor.NewWebhooker(channels)
+
+	state := &processor.State{
+		ChatID: 123,
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ans := <-channels.TelegramResp()
+		msg, ok := ans.(tgbotapi.MessageConfig)
+		require.True(t, ok, "not tg message")
+
+		assert.True(t, strings.HasPrefix(msg.Text, "Введите ссылку на репозиторий"), "Message should prompt for the link")
+		assert.Equal(t, state.ChatID, msg.ChatID, "ChatID should match the state's ChatID")
+	}()
+
+	result := webhooker.Handle(context.Background(), state)
+
+	assert.Equal(t, "webhook_set", result.NextState.String(), "NextState should be webhookSet")
+	assert.False(t, result.IsAutoTransition, "IsAutoTransition should be false")
+	assert.Equal(t, state, result.Result, "Result should contain the state")
+
+	wg.Wait()
+}
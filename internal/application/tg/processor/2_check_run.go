@@ -0,0 +1,68 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type CheckRunner struct {
+	client   Client
+	channels Channels
+}
+
+func NewCheckRunner(client Client, channels Channels) *CheckRunner {
+	return &CheckRunner{
+		client:   client,
+		channels: channels,
+	}
+}
+
+func (h *CheckRunner) Handle(ctx context.Context, state *State) *fsm.Result[*State] {
+	link := strings.TrimSpace(state.Message)
+
+	updates, err := h.client.CheckLink(ctx, state.ChatID, link)
+	userErr := &scrapper.ErrUserResponse{}
+
+	if errors.As(err, userErr) {
+		// The state is not set yet when "/check {link}" gets here, so the
+		// next one is named explicitly.
+		msg := tgbotapi.NewMessage(state.ChatID, userErr.Message+". "+checkPrompt)
+		h.channels.TelegramResp() <- msg
+
+		return &fsm.Result[*State]{
+			NextState:        checkRun,
+			IsAutoTransition: false,
+			Result:           state,
+		}
+	}
+
+	if err != nil {
+		state.ShowError = "ошибка при проверке ссылки"
+
+		return &fsm.Result[*State]{
+			NextState:        fail,
+			IsAutoTransition: true,
+			Result:           state,
+			Error:            fmt.Errorf("h.client.CheckLink(ctx, %d, %q): %w", state.ChatID, link, err),
+		}
+	}
+
+	ans := fmt.Sprintf("Найдено новых обновлений: %d", updates)
+	if updates == 0 {
+		ans = "Новых обновлений нет"
+	}
+
+	msg := tgbotapi.NewMessage(state.ChatID, ans)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
@@ -0,0 +1,112 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRunner_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		updates int
+		answer  string
+	}{
+		{name: "updates", updates: 3, answer: "Найдено новых обновлений: 3"},
+		{name: "no updates", updates: 0, answer: "Новых обновлений нет"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels := domain.NewChannels()
+			client := mocks.NewMockClient(t)
+			client.On("CheckLink", context.Background(), int64(42), "https://example.com").
+				Return(tt.updates, nil).
+				Once()
+
+			handler := processor.NewCheckRunner(client, channels)
+			state := &processor.State{Message: " https://example.com ", ChatID: 42}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ans := <-channels.TelegramResp()
+				msg, ok := ans.(tgbotapi.MessageConfig)
+				require.True(t, ok, "not tg message")
+				assert.Equal(t, tt.answer, msg.Text)
+			}()
+
+			result := handler.Handle(context.Background(), state)
+			assert.Empty(t, result.NextState.String(), "Expected the dialog to end")
+			assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestCheckRunner_Handle_UserError(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	client := mocks.NewMockClient(t)
+	client.On("CheckLink", context.Background(), int64(42), "https://example.com").
+		Return(0, scrapper.ErrUserResponse{Message: `Ссылка "https://example.com" не найдена`}).
+		Once()
+
+	handler := processor.NewCheckRunner(client, channels)
+	state := &processor.State{Message: "https://example.com", ChatID: 42, FSMState: "command"}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ans := <-channels.TelegramResp()
+		msg, ok := ans.(tgbotapi.MessageConfig)
+		require.True(t, ok, "not tg message")
+		assert.Contains(t, msg.Text, "не найдена", "Expected the user error in the answer")
+	}()
+
+	result := handler.Handle(context.Background(), state)
+	assert.Equal(t, "check_run", result.NextState.String(), "Expected to wait for another link")
+	assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+	wg.Wait()
+}
+
+func TestCheckRunner_Handle_Error(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	client := mocks.NewMockClient(t)
+	client.On("CheckLink", context.Background(), int64(42), "https://example.com").
+		Return(0, errors.New("scrapper is down")).
+		Once()
+
+	handler := processor.NewCheckRunner(client, channels)
+	state := &processor.State{Message: "https://example.com", ChatID: 42}
+
+	result := handler.Handle(context.Background(), state)
+	assert.Equal(t, "fail", result.NextState.String(), "Expected fail state")
+	assert.True(t, result.IsAutoTransition, "Expected auto transition is true")
+	assert.Equal(t, "ошибка при проверке ссылки", result.Result.ShowError)
+	assert.Error(t, result.Error, "Expected error")
+}
@@ -0,0 +1,107 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// autoInterval returns the link to the adaptive schedule.
+const autoInterval = "auto"
+
+type IntervalSetter struct {
+	client   Client
+	channels Channels
+}
+
+func NewIntervalSetter(client Client, channels Channels) *IntervalSetter {
+	return &IntervalSetter{
+		client:   client,
+		channels: channels,
+	}
+}
+
+func (h *IntervalSetter) Handle(ctx context.Context, state *State) *fsm.Result[*State] {
+	link, interval, ok := parseInterval(state.Message)
+	if !ok {
+		return h.retry(state, "Некорректный формат. "+intervalPrompt)
+	}
+
+	err := h.client.SetLinkInterval(ctx, state.ChatID, link, interval)
+	userErr := &scrapper.ErrUserResponse{}
+
+	if errors.As(err, userErr) {
+		return h.retry(state, userErr.Message+". "+intervalPrompt)
+	}
+
+	if err != nil {
+		state.ShowError = "ошибка при изменении интервала"
+
+		return &fsm.Result[*State]{
+			NextState:        fail,
+			IsAutoTransition: true,
+			Result:           state,
+			Error: fmt.Errorf(
+				"h.client.SetLinkInterval(ctx, %d, %q, %s)",
+				state.ChatID,
+				link,
+				interval,
+			),
+		}
+	}
+
+	ans := fmt.Sprintf("Ссылка будет проверяться раз в %s", interval)
+	if interval == 0 {
+		ans = "Интервал проверки ссылки будет подбираться автоматически"
+	}
+
+	msg := tgbotapi.NewMessage(state.ChatID, ans)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
+
+func (h *IntervalSetter) retry(state *State, ans string) *fsm.Result[*State] {
+	msg := tgbotapi.NewMessage(state.ChatID, ans)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		NextState:        state.FSMState,
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
+
+// parseInterval parses "{link} {duration}" or "{link} auto", the minimum
+// interval is checked by the scrapper.
+func parseInterval(message string) (string, time.Duration, bool) {
+	fields := strings.Fields(message)
+	if len(fields) != 2 {
+		return "", 0, false
+	}
+
+	if _, err := url.Parse(fields[0]); err != nil {
+		return "", 0, false
+	}
+
+	if strings.EqualFold(fields[1], autoInterval) {
+		return fields[0], 0, true
+	}
+
+	interval, err := time.ParseDuration(fields[1])
+	if err != nil || interval <= 0 {
+		return "", 0, false
+	}
+
+	return fields[0], interval, true
+}
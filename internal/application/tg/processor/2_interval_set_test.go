@@ -0,0 +1,145 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalSetter_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		message  string
+		interval time.Duration
+		answer   string
+	}{
+		{
+			name:     "pinned",
+			message:  "https://example.com 30m",
+			interval: 30 * time.Minute,
+			answer:   "Ссылка будет проверяться раз в 30m0s",
+		},
+		{
+			name:    "auto",
+			message: "https://example.com AUTO",
+			answer:  "Интервал проверки ссылки будет подбираться автоматически",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels := domain.NewChannels()
+			client := mocks.NewMockClient(t)
+			client.On("SetLinkInterval", context.Background(), int64(42), "https://example.com", tt.interval).
+				Return(nil).
+				Once()
+
+			handler := processor.NewIntervalSetter(client, channels)
+			state := &processor.State{Message: tt.message, ChatID: 42, FSMState: "interval_set"}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ans := <-channels.TelegramResp()
+				msg, ok := ans.(tgbotapi.MessageConfig)
+				require.True(t, ok, "not tg message")
+				assert.Equal(t, tt.answer, msg.Text)
+			}()
+
+			result := handler.Handle(context.Background(), state)
+			assert.Empty(t, result.NextState.String(), "Expected the dialog to end")
+			assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestIntervalSetter_Handle_Retry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		err     error
+	}{
+		{name: "no interval", message: "https://example.com"},
+		{name: "invalid interval", message: "https://example.com often"},
+		{name: "negative interval", message: "https://example.com -5m"},
+		{
+			name:    "user error",
+			message: "https://example.com 10s",
+			err:     scrapper.ErrUserResponse{Message: "Интервал должен быть не меньше 1m0s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels := domain.NewChannels()
+			client := mocks.NewMockClient(t)
+
+			if tt.err != nil {
+				client.On("SetLinkInterval", context.Background(), int64(42), "https://example.com", 10*time.Second).
+					Return(tt.err).
+					Once()
+			}
+
+			handler := processor.NewIntervalSetter(client, channels)
+			state := &processor.State{Message: tt.message, ChatID: 42, FSMState: "interval_set"}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ans := <-channels.TelegramResp()
+				_, ok := ans.(tgbotapi.MessageConfig)
+				assert.True(t, ok, "not tg message")
+			}()
+
+			result := handler.Handle(context.Background(), state)
+			assert.Equal(t, "interval_set", result.NextState.String(), "Expected the same state")
+			assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestIntervalSetter_Handle_Error(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	client := mocks.NewMockClient(t)
+	client.On("SetLinkInterval", context.Background(), int64(42), "https://example.com", time.Hour).
+		Return(errors.New("scrapper is down")).
+		Once()
+
+	handler := processor.NewIntervalSetter(client, channels)
+	state := &processor.State{Message: "https://example.com 1h", ChatID: 42, FSMState: "interval_set"}
+
+	result := handler.Handle(context.Background(), state)
+	assert.Equal(t, "fail", result.NextState.String(), "Expected fail state")
+	assert.True(t, result.IsAutoTransition, "Expected auto transition is true")
+	assert.Error(t, result.Error, "Expected error")
+}
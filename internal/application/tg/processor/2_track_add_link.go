@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"regexp"
 	"slices"
 	"strings"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -16,33 +16,21 @@ import (
 type TrackLinkAdder struct {
 	client   Client
 	channels Channels
-	formats  []string
-	regexes  []*regexp.Regexp
+	types    *linktype.Registry
 }
 
-func NewTrackLinkAdder(client Client, channels Channels) *TrackLinkAdder {
+func NewTrackLinkAdder(client Client, channels Channels, types *linktype.Registry) *TrackLinkAdder {
 	return &TrackLinkAdder{
 		client:   client,
 		channels: channels,
-		formats: []string{
-			"https://stackoverflow.com/questions/{id}/{title}",
-			"https://github.com/{user}/{repo}",
-			// "https://github.com/{user}/{repo}/issues/{id}",
-			// "https://github.com/{user}/pull/{id}",
-		},
-		regexes: []*regexp.Regexp{
-			regexp.MustCompile(`^https://stackoverflow\.com/questions/(\d+)/([\w-]+)$`),
-			regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)$`),
-			// regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)$`),
-			// regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/pull/(\d+)$`),
-		},
+		types:    types,
 	}
 }
 
 func (h *TrackLinkAdder) Handle(ctx context.Context, state *State) *fsm.Result[*State] {
-	if !h.isValidLink(state.Message) {
+	if _, _, ok := h.types.Canonicalize(state.Message); !ok {
 		ans := "Неверный формат ссылки. Используйте следующие форматы:\n- "
-		ans += strings.Join(h.formats, "\n- ")
+		ans += strings.Join(h.types.Formats(), "\n- ")
 
 		msg := tgbotapi.NewMessage(state.ChatID, ans)
 		h.channels.TelegramResp() <- msg
@@ -93,16 +81,8 @@ func (h *TrackLinkAdder) Handle(ctx context.Context, state *State) *fsm.Result[*
 	return updateField(ctx, state, h.channels.TelegramResp(), update)
 }
 
-func (h *TrackLinkAdder) isValidLink(link string) bool {
-	for _, reges := range h.regexes {
-		if reges.MatchString(link) {
-			return true
-		}
-	}
-
-	return false
-}
-
+// IsLinkExists compares canonical forms of links, so the same link written
+// differently is found too.
 func (h *TrackLinkAdder) IsLinkExists(
 	ctx context.Context,
 	url string,
@@ -113,13 +93,24 @@ func (h *TrackLinkAdder) IsLinkExists(
 		return false, fmt.Errorf("failed to get links: %w", err)
 	}
 
+	url = h.canonical(url)
+
 	exists := slices.ContainsFunc(links, func(link *domain.Link) bool {
-		return link.URL == url
+		return h.canonical(link.URL) == url
 	})
 
 	return exists, nil
 }
 
+// canonical keeps unsupported links as is.
+func (h *TrackLinkAdder) canonical(link string) string {
+	if canonical, _, ok := h.types.Canonicalize(link); ok {
+		return canonical
+	}
+
+	return link
+}
+
 func createKeyboard(link *domain.Link) tgbotapi.InlineKeyboardMarkup {
 	rows := make([][]tgbotapi.InlineKeyboardButton, 0)
 
@@ -6,8 +6,10 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -15,6 +17,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var types = linktype.New(&config.GitLab{BaseURL: "https://gitlab.com"})
+
 func TestHandleTrackLinkAdder(t *testing.T) {
 	t.Parallel()
 
@@ -67,50 +71,248 @@ func TestHandleTrackLinkAdder(t *testing.T) {
 				},
 			},
 		},
-		// {
-		// 	name: "gh issue link",
-		// 	state: &processor.State{
-		// 		Message: "https://github.com/LLIEPJIOK/forum/issues/1",
-		// 		ChatID:  1,
-		// 		Object: &domain.Link{
-		// 			ChatID: 1,
-		// 		},
-		// 	},
-		// 	exp: &fsm.Result[*processor.State]{
-		// 		NextState:        "callback",
-		// 		IsAutoTransition: false,
-		// 		Result: &processor.State{
-		// 			Message: "https://github.com/LLIEPJIOK/forum/issues/1",
-		// 			ChatID:  1,
-		// 			Object: &domain.Link{
-		// 				URL:    "https://github.com/LLIEPJIOK/forum/issues/1",
-		// 				ChatID: 1,
-		// 			},
-		// 		},
-		// 	},
-		// },
-		// {
-		// 	name: "gh pr link",
-		// 	state: &processor.State{
-		// 		Message: "https://github.com/aleksander-git/telegram-torrent/pull/7",
-		// 		ChatID:  1,
-		// 		Object: &domain.Link{
-		// 			ChatID: 1,
-		// 		},
-		// 	},
-		// 	exp: &fsm.Result[*processor.State]{
-		// 		NextState:        "callback",
-		// 		IsAutoTransition: false,
-		// 		Result: &processor.State{
-		// 			Message: "https://github.com/aleksander-git/telegram-torrent/pull/7",
-		// 			ChatID:  1,
-		// 			Object: &domain.Link{
-		// 				URL:    "https://github.com/aleksander-git/telegram-torrent/pull/7",
-		// 				ChatID: 1,
-		// 			},
-		// 		},
-		// 	},
-		// },
+		{
+			name: "stack exchange link",
+			state: &processor.State{
+				Message: "https://ru.stackoverflow.com/questions/1606101/kak-sdelat-zapros",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://ru.stackoverflow.com/questions/1606101/kak-sdelat-zapros",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://ru.stackoverflow.com/questions/1606101/kak-sdelat-zapros",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gh releases link",
+			state: &processor.State{
+				Message: "https://github.com/LLIEPJIOK/forum/releases",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://github.com/LLIEPJIOK/forum/releases",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://github.com/LLIEPJIOK/forum/releases",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gh branch link",
+			state: &processor.State{
+				Message: "https://github.com/LLIEPJIOK/forum/tree/feature/hw-3",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://github.com/LLIEPJIOK/forum/tree/feature/hw-3",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://github.com/LLIEPJIOK/forum/tree/feature/hw-3",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "feed link",
+			state: &processor.State{
+				Message: "https://go.dev/blog/feed.atom",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://go.dev/blog/feed.atom",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://go.dev/blog/feed.atom",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gh issue link",
+			state: &processor.State{
+				Message: "https://github.com/LLIEPJIOK/forum/issues/1",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://github.com/LLIEPJIOK/forum/issues/1",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://github.com/LLIEPJIOK/forum/issues/1",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gitlab project link",
+			state: &processor.State{
+				Message: "https://gitlab.com/gitlab-org/cli",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://gitlab.com/gitlab-org/cli",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://gitlab.com/gitlab-org/cli",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gitlab mr link",
+			state: &processor.State{
+				Message: "https://gitlab.example.com/group/sub/project/-/merge_requests/12",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://gitlab.example.com/group/sub/project/-/merge_requests/12",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://gitlab.example.com/group/sub/project/-/merge_requests/12",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "go module link",
+			state: &processor.State{
+				Message: "https://pkg.go.dev/github.com/jackc/pgx/v5",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://pkg.go.dev/github.com/jackc/pgx/v5",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://pkg.go.dev/github.com/jackc/pgx/v5",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "npm scoped package link",
+			state: &processor.State{
+				Message: "https://www.npmjs.com/package/@types/node",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://www.npmjs.com/package/@types/node",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://www.npmjs.com/package/@types/node",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "page link",
+			state: &processor.State{
+				Message: "https://go.dev/doc/devel/release",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://go.dev/doc/devel/release",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://go.dev/doc/devel/release",
+						ChatID: 1,
+					},
+				},
+			},
+		},
+		{
+			name: "gh pr link",
+			state: &processor.State{
+				Message: "https://github.com/aleksander-git/telegram-torrent/pull/7",
+				ChatID:  1,
+				Object: &domain.Link{
+					ChatID: 1,
+				},
+			},
+			exp: &fsm.Result[*processor.State]{
+				NextState:        "callback",
+				IsAutoTransition: false,
+				Result: &processor.State{
+					Message: "https://github.com/aleksander-git/telegram-torrent/pull/7",
+					ChatID:  1,
+					Object: &domain.Link{
+						URL:    "https://github.com/aleksander-git/telegram-torrent/pull/7",
+						ChatID: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -122,7 +324,7 @@ func TestHandleTrackLinkAdder(t *testing.T) {
 			client := mocks.NewMockClient(t)
 			client.On("GetLinks", ctx, int64(1), "").Return(nil, nil)
 
-			handler := processor.NewTrackLinkAdder(client, channels)
+			handler := processor.NewTrackLinkAdder(client, channels, types)
 
 			wg := sync.WaitGroup{}
 			wg.Add(1)
@@ -262,7 +464,7 @@ func TestHandleTrackLinkAdderInvalidLinks(t *testing.T) {
 			ctx := context.Background()
 			channels := domain.NewChannels()
 			client := mocks.NewMockClient(t)
-			handler := processor.NewTrackLinkAdder(client, channels)
+			handler := processor.NewTrackLinkAdder(client, channels, types)
 
 			go func() {
 				ans := <-channels.TelegramResp()
@@ -270,8 +472,23 @@ func TestHandleTrackLinkAdderInvalidLinks(t *testing.T) {
 				require.True(t, ok, "not tg message")
 
 				text := `Неверный формат ссылки. Используйте следующие форматы:
-- https://stackoverflow.com/questions/{id}/{title}
-- https://github.com/{user}/{repo}`
+- https://github.com/{user}/{repo}
+- https://github.com/{user}/{repo}/issues
+- https://github.com/{user}/{repo}/releases
+- https://github.com/{user}/{repo}/issues/{id}
+- https://github.com/{user}/{repo}/pull/{id}
+- https://github.com/{user}/{repo}/tree/{branch}
+- https://github.com/{user}/{repo}/actions/workflows/{file}
+- https://gitlab.com/{group}/{project}
+- https://gitlab.com/{group}/{project}/-/issues/{id}
+- https://gitlab.com/{group}/{project}/-/merge_requests/{id}
+- https://stackoverflow.com/questions/{id}/{title} (и другие сайты Stack Exchange)
+- https://{site}/{path}.rss, .atom, .xml или /feed (RSS и Atom ленты)
+- https://pkg.go.dev/{module}
+- https://www.npmjs.com/package/{name}
+- https://pypi.org/project/{name}
+- https://crates.io/crates/{name}
+- https://{site}/{path} (любая другая страница, её часть выбирается фильтром selector:{css})`
 				assert.Equal(
 					t,
 					text,
@@ -308,7 +525,7 @@ func TestHandleInvalidObject(t *testing.T) {
 	client := mocks.NewMockClient(t)
 	client.On("GetLinks", ctx, int64(1), "").Return(nil, nil)
 
-	handler := processor.NewTrackLinkAdder(client, channels)
+	handler := processor.NewTrackLinkAdder(client, channels, types)
 
 	res := handler.Handle(ctx, state)
 	assert.Equal(t, exp, res, "wrong result")
@@ -341,9 +558,10 @@ func TestTrackLinkAdder_Handle_LinkExists(t *testing.T) {
 	ctx := context.Background()
 	channels := domain.NewChannels()
 	client := mocks.NewMockClient(t)
+	// the link is saved in the canonical form, without the title
 	client.On("GetLinks", ctx, int64(1), "").Return([]*domain.Link{
 		{
-			URL: "https://stackoverflow.com/questions/79476948/androidmanifest-xml-file-raising-errors-with-no-exception",
+			URL: "https://stackoverflow.com/questions/79476948",
 		},
 	}, nil)
 
@@ -365,7 +583,7 @@ func TestTrackLinkAdder_Handle_LinkExists(t *testing.T) {
 		)
 	}()
 
-	handler := processor.NewTrackLinkAdder(client, channels)
+	handler := processor.NewTrackLinkAdder(client, channels, types)
 
 	res := handler.Handle(ctx, state)
 	assert.Equal(t, exp, res, "wrong result")
@@ -411,7 +629,7 @@ func TestTrackLinkAdder_Handle_GetLinksError(t *testing.T) {
 	wg := sync.WaitGroup{}
 	wg.Add(1)
 
-	handler := processor.NewTrackLinkAdder(client, channels)
+	handler := processor.NewTrackLinkAdder(client, channels, types)
 
 	res := handler.Handle(ctx, state)
 	assert.Equal(t, res.NextState, exp.NextState, "wrong next state")
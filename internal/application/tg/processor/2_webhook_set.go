@@ -0,0 +1,108 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	webhookOn  = "on"
+	webhookOff = "off"
+)
+
+type WebhookSetter struct {
+	client   Client
+	channels Channels
+}
+
+func NewWebhookSetter(client Client, channels Channels) *WebhookSetter {
+	return &WebhookSetter{
+		client:   client,
+		channels: channels,
+	}
+}
+
+func (h *WebhookSetter) Handle(ctx context.Context, state *State) *fsm.Result[*State] {
+	link, webhook, ok := parseWebhook(state.Message)
+	if !ok {
+		return h.retry(state, "Некорректный формат. "+webhookPrompt)
+	}
+
+	err := h.client.SetLinkWebhook(ctx, state.ChatID, link, webhook)
+	userErr := &scrapper.ErrUserResponse{}
+
+	if errors.As(err, userErr) {
+		return h.retry(state, userErr.Message+". "+webhookPrompt)
+	}
+
+	if err != nil {
+		state.ShowError = "ошибка при изменении способа получения обновлений"
+
+		return &fsm.Result[*State]{
+			NextState:        fail,
+			IsAutoTransition: true,
+			Result:           state,
+			Error: fmt.Errorf(
+				"h.client.SetLinkWebhook(ctx, %d, %q, %t)",
+				state.ChatID,
+				link,
+				webhook,
+			),
+		}
+	}
+
+	ans := "Обновления ссылки будут приходить из вебхуков GitHub"
+	if !webhook {
+		ans = "Ссылка будет проверяться по расписанию"
+	}
+
+	msg := tgbotapi.NewMessage(state.ChatID, ans)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
+
+func (h *WebhookSetter) retry(state *State, ans string) *fsm.Result[*State] {
+	msg := tgbotapi.NewMessage(state.ChatID, ans)
+	h.channels.TelegramResp() <- msg
+
+	return &fsm.Result[*State]{
+		NextState:        state.FSMState,
+		IsAutoTransition: false,
+		Result:           state,
+	}
+}
+
+// parseWebhook parses "{link} on" or "{link} off", the link type is checked
+// by the scrapper.
+func parseWebhook(message string) (string, bool, bool) {
+	fields := strings.Fields(message)
+	if len(fields) != 2 {
+		return "", false, false
+	}
+
+	if _, err := url.Parse(fields[0]); err != nil {
+		return "", false, false
+	}
+
+	switch strings.ToLower(fields[1]) {
+	case webhookOn:
+		return fields[0], true, true
+
+	case webhookOff:
+		return fields[0], false, true
+
+	default:
+		return "", false, false
+	}
+}
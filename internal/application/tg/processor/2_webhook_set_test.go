@@ -0,0 +1,145 @@
+package processor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/processor/mocks"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookLink = "https://github.com/owner/repo"
+
+func TestWebhookSetter_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		webhook bool
+		answer  string
+	}{
+		{
+			name:    "on",
+			message: webhookLink + " on",
+			webhook: true,
+			answer:  "Обновления ссылки будут приходить из вебхуков GitHub",
+		},
+		{
+			name:    "off",
+			message: webhookLink + " OFF",
+			answer:  "Ссылка будет проверяться по расписанию",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels := domain.NewChannels()
+			client := mocks.NewMockClient(t)
+			client.On("SetLinkWebhook", context.Background(), int64(42), webhookLink, tt.webhook).
+				Return(nil).
+				Once()
+
+			handler := processor.NewWebhookSetter(client, channels)
+			state := &processor.State{Message: tt.message, ChatID: 42, FSMState: "webhook_set"}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ans := <-channels.TelegramResp()
+				msg, ok := ans.(tgbotapi.MessageConfig)
+				require.True(t, ok, "not tg message")
+				assert.Equal(t, tt.answer, msg.Text)
+			}()
+
+			result := handler.Handle(context.Background(), state)
+			assert.Empty(t, result.NextState.String(), "Expected the dialog to end")
+			assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestWebhookSetter_Handle_Retry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		err     error
+	}{
+		{name: "no switch", message: webhookLink},
+		{name: "invalid switch", message: webhookLink + " yes"},
+		{
+			name:    "user error",
+			message: webhookLink + " on",
+			err:     scrapper.ErrUserResponse{Message: "Вебхуки не настроены"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			channels := domain.NewChannels()
+			client := mocks.NewMockClient(t)
+
+			if tt.err != nil {
+				client.On("SetLinkWebhook", context.Background(), int64(42), webhookLink, true).
+					Return(tt.err).
+					Once()
+			}
+
+			handler := processor.NewWebhookSetter(client, channels)
+			state := &processor.State{Message: tt.message, ChatID: 42, FSMState: "webhook_set"}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ans := <-channels.TelegramResp()
+				_, ok := ans.(tgbotapi.MessageConfig)
+				assert.True(t, ok, "not tg message")
+			}()
+
+			result := handler.Handle(context.Background(), state)
+			assert.Equal(t, "webhook_set", result.NextState.String(), "Expected the same state")
+			assert.False(t, result.IsAutoTransition, "Expected auto transition is false")
+
+			wg.Wait()
+		})
+	}
+}
+
+func TestWebhookSetter_Handle_Error(t *testing.T) {
+	t.Parallel()
+
+	channels := domain.NewChannels()
+	client := mocks.NewMockClient(t)
+	client.On("SetLinkWebhook", context.Background(), int64(42), webhookLink, false).
+		Return(errors.New("scrapper is down")).
+		Once()
+
+	handler := processor.NewWebhookSetter(client, channels)
+	state := &processor.State{Message: webhookLink + " off", ChatID: 42, FSMState: "webhook_set"}
+
+	result := handler.Handle(context.Background(), state)
+	assert.Equal(t, "fail", result.NextState.String(), "Expected fail state")
+	assert.True(t, result.IsAutoTransition, "Expected auto transition is true")
+	assert.Error(t, result.Error, "Expected error")
+}
@@ -20,7 +20,12 @@ func NewTrackFilterAdder(channels Channels) *TrackFilterAdder {
 
 func (h *TrackFilterAdder) Handle(ctx context.Context, state *State) *fsm.Result[*State] {
 	update := func(link *domain.Link, value string) *domain.Link {
-		link.Filters = strings.Fields(value)
+		// The whole message is one expression, it may contain spaces and
+		// parentheses.
+		link.Filters = nil
+		if value = strings.TrimSpace(value); value != "" {
+			link.Filters = []string{value}
+		}
 
 		return link
 	}
@@ -57,7 +57,7 @@ func TestTrackFilterAdder_Handle(t *testing.T) {
 		Filters: nil,
 	}
 	state := &processor.State{
-		Message:  "filter1 filter2 filter3",
+		Message:  " type:comment (user:alice OR score>=5) ",
 		ChatID:   456,
 		FSMState: "initial",
 		Object:   link,
@@ -83,7 +83,7 @@ func TestTrackFilterAdder_Handle(t *testing.T) {
 	updatedLink, ok := state.Object.(*domain.Link)
 	require.True(t, ok, "Expected state.Object to be of type *domain.Link")
 
-	expectedFilters := strings.Fields(state.Message)
+	expectedFilters := []string{strings.TrimSpace(state.Message)}
 	assert.Equal(
 		t,
 		expectedFilters,
@@ -2,6 +2,7 @@ package processor_test
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -23,7 +24,7 @@ func TestTrackFlow(t *testing.T) {
 	metrics.On("ObserveTGRequestsDurationSeconds", "callback", mock.Anything).Times(4)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	proc := processor.New(client, channels, cache, metrics)
+	proc := processor.New(client, channels, cache, metrics, types)
 
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -97,7 +98,7 @@ func TestTrackFlow(t *testing.T) {
 	metrics.On("ObserveTGRequestsDurationSeconds", "track_add_filters", mock.Anything).Once()
 	channels.TelegramReq() <- domain.TelegramRequest{
 		ChatID:  1,
-		Message: "filter1 filter2",
+		Message: "type:release OR user:alice",
 		Type:    domain.Message,
 	}
 	<-channels.TelegramResp()
@@ -123,7 +124,7 @@ func TestTrackFlow(t *testing.T) {
 	// save
 	client.On("AddLink", ctx, mock.MatchedBy(func(link *domain.Link) bool {
 		return link.URL == "https://github.com/LLIEPJIOK/nginxparser" && link.ChatID == 1 &&
-			link.Filters[0] == "filter1" && link.Filters[1] == "filter2" &&
+			slices.Equal(link.Filters, []string{"type:release OR user:alice"}) &&
 			link.Tags[0] == "tag1" && link.Tags[1] == "tag2"
 	})).Return(nil).Once()
 	cache.On("InvalidateListLinks", mock.Anything, int64(1)).
@@ -143,7 +144,7 @@ func TestUntrackFlow(t *testing.T) {
 	metrics := mocks.NewMockMetrics(t)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	proc := processor.New(client, channels, cache, metrics)
+	proc := processor.New(client, channels, cache, metrics, types)
 
 	wg := sync.WaitGroup{}
 	wg.Add(1)
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	domain "github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	mock "github.com/stretchr/testify/mock"
@@ -69,6 +70,64 @@ func (_c *MockClient_AddLink_Call) RunAndReturn(run func(context.Context, *domai
 	return _c
 }
 
+// CheckLink provides a mock function with given fields: ctx, chatID, linkURL
+func (_m *MockClient) CheckLink(ctx context.Context, chatID int64, linkURL string) (int, error) {
+	ret := _m.Called(ctx, chatID, linkURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckLink")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (int, error)); ok {
+		return rf(ctx, chatID, linkURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) int); ok {
+		r0 = rf(ctx, chatID, linkURL)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, chatID, linkURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_CheckLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckLink'
+type MockClient_CheckLink_Call struct {
+	*mock.Call
+}
+
+// CheckLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkURL string
+func (_e *MockClient_Expecter) CheckLink(ctx interface{}, chatID interface{}, linkURL interface{}) *MockClient_CheckLink_Call {
+	return &MockClient_CheckLink_Call{Call: _e.mock.On("CheckLink", ctx, chatID, linkURL)}
+}
+
+func (_c *MockClient_CheckLink_Call) Run(run func(ctx context.Context, chatID int64, linkURL string)) *MockClient_CheckLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_CheckLink_Call) Return(_a0 int, _a1 error) *MockClient_CheckLink_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_CheckLink_Call) RunAndReturn(run func(context.Context, int64, string) (int, error)) *MockClient_CheckLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteLink provides a mock function with given fields: ctx, chatID, linkURL
 func (_m *MockClient) DeleteLink(ctx context.Context, chatID int64, linkURL string) error {
 	ret := _m.Called(ctx, chatID, linkURL)
@@ -224,6 +283,104 @@ func (_c *MockClient_RegisterChat_Call) RunAndReturn(run func(context.Context, i
 	return _c
 }
 
+// SetLinkInterval provides a mock function with given fields: ctx, chatID, linkURL, interval
+func (_m *MockClient) SetLinkInterval(ctx context.Context, chatID int64, linkURL string, interval time.Duration) error {
+	ret := _m.Called(ctx, chatID, linkURL, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLinkInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, chatID, linkURL, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_SetLinkInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLinkInterval'
+type MockClient_SetLinkInterval_Call struct {
+	*mock.Call
+}
+
+// SetLinkInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkURL string
+//   - interval time.Duration
+func (_e *MockClient_Expecter) SetLinkInterval(ctx interface{}, chatID interface{}, linkURL interface{}, interval interface{}) *MockClient_SetLinkInterval_Call {
+	return &MockClient_SetLinkInterval_Call{Call: _e.mock.On("SetLinkInterval", ctx, chatID, linkURL, interval)}
+}
+
+func (_c *MockClient_SetLinkInterval_Call) Run(run func(ctx context.Context, chatID int64, linkURL string, interval time.Duration)) *MockClient_SetLinkInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockClient_SetLinkInterval_Call) Return(_a0 error) *MockClient_SetLinkInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_SetLinkInterval_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *MockClient_SetLinkInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLinkWebhook provides a mock function with given fields: ctx, chatID, linkURL, webhook
+func (_m *MockClient) SetLinkWebhook(ctx context.Context, chatID int64, linkURL string, webhook bool) error {
+	ret := _m.Called(ctx, chatID, linkURL, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLinkWebhook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, bool) error); ok {
+		r0 = rf(ctx, chatID, linkURL, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_SetLinkWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLinkWebhook'
+type MockClient_SetLinkWebhook_Call struct {
+	*mock.Call
+}
+
+// SetLinkWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - chatID int64
+//   - linkURL string
+//   - webhook bool
+func (_e *MockClient_Expecter) SetLinkWebhook(ctx interface{}, chatID interface{}, linkURL interface{}, webhook interface{}) *MockClient_SetLinkWebhook_Call {
+	return &MockClient_SetLinkWebhook_Call{Call: _e.mock.On("SetLinkWebhook", ctx, chatID, linkURL, webhook)}
+}
+
+func (_c *MockClient_SetLinkWebhook_Call) Run(run func(ctx context.Context, chatID int64, linkURL string, webhook bool)) *MockClient_SetLinkWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockClient_SetLinkWebhook_Call) Return(_a0 error) *MockClient_SetLinkWebhook_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_SetLinkWebhook_Call) RunAndReturn(run func(context.Context, int64, string, bool) error) *MockClient_SetLinkWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClient(t interface {
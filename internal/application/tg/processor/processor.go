@@ -4,7 +4,9 @@ import (
 	"context"
 	"log/slog"
 	"sync"
+	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/fsm"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -17,6 +19,9 @@ type Client interface {
 	AddLink(ctx context.Context, link *domain.Link) error
 	DeleteLink(ctx context.Context, chatID int64, linkURL string) error
 	GetLinks(ctx context.Context, chatID int64, tag string) ([]*domain.Link, error)
+	SetLinkInterval(ctx context.Context, chatID int64, linkURL string, interval time.Duration) error
+	SetLinkWebhook(ctx context.Context, chatID int64, linkURL string, webhook bool) error
+	CheckLink(ctx context.Context, chatID int64, linkURL string) (int, error)
 }
 
 type Channels interface {
@@ -52,7 +57,13 @@ type Processor struct {
 	states   map[int64]*State
 }
 
-func New(client Client, channels Channels, cache Cache, metrics Metrics) *Processor {
+func New(
+	client Client,
+	channels Channels,
+	cache Cache,
+	metrics Metrics,
+	types *linktype.Registry,
+) *Processor {
 	fsmBuilder := fsm.NewBuilder[*State]()
 	fsmBuilder.
 		AddState(callback, NewCallbacker(channels)).
@@ -60,7 +71,7 @@ func New(client Client, channels Channels, cache Cache, metrics Metrics) *Proces
 		AddState(start, NewStater(client, channels)).
 		AddState(help, NewHelper(channels)).
 		AddState(track, NewTracker(channels)).
-		AddState(trackAddLink, NewTrackLinkAdder(client, channels)).
+		AddState(trackAddLink, NewTrackLinkAdder(client, channels, types)).
 		AddState(trackAddFilters, NewTrackFilterAdder(channels)).
 		AddState(trackAddTags, NewTrackTagAdder(channels)).
 		AddState(trackAddSetTime, NewTrackAddTimeSetter(channels)).
@@ -73,6 +84,12 @@ func New(client Client, channels Channels, cache Cache, metrics Metrics) *Proces
 		AddState(listByTag, NewByTagLister(client, channels, cache)).
 		AddState(untrack, NewUntracker(channels)).
 		AddState(untrackDeleteLink, NewUntrackLinkDeleter(client, channels, cache)).
+		AddState(interval, NewIntervaler(channels)).
+		AddState(intervalSet, NewIntervalSetter(client, channels)).
+		AddState(webhook, NewWebhooker(channels)).
+		AddState(webhookSet, NewWebhookSetter(client, channels)).
+		AddState(check, NewChecker(channels)).
+		AddState(checkRun, NewCheckRunner(client, channels)).
 		AddState(fail, NewFailer(channels)).
 		AddTransition(callback, trackAddTags).
 		AddTransition(callback, trackAddFilters).
@@ -88,6 +105,10 @@ func New(client Client, channels Channels, cache Cache, metrics Metrics) *Proces
 		AddTransition(command, track).
 		AddTransition(command, list).
 		AddTransition(command, untrack).
+		AddTransition(command, interval).
+		AddTransition(command, webhook).
+		AddTransition(command, check).
+		AddTransition(command, checkRun).
 		AddTransition(command, fail).
 		AddTransition(start, fail).
 		AddTransition(track, trackAddLink).
@@ -107,7 +128,13 @@ func New(client Client, channels Channels, cache Cache, metrics Metrics) *Proces
 		AddTransition(listAll, fail).
 		AddTransition(listByTag, fail).
 		AddTransition(untrack, untrackDeleteLink).
-		AddTransition(untrackDeleteLink, fail)
+		AddTransition(untrackDeleteLink, fail).
+		AddTransition(interval, intervalSet).
+		AddTransition(intervalSet, fail).
+		AddTransition(webhook, webhookSet).
+		AddTransition(webhookSet, fail).
+		AddTransition(check, checkRun).
+		AddTransition(checkRun, fail)
 
 	return &Processor{
 		client:   client,
@@ -29,6 +29,15 @@ const (
 	untrack           fsm.State = "untrack"
 	untrackDeleteLink fsm.State = "untrack_delete_link"
 
+	interval    fsm.State = "interval"
+	intervalSet fsm.State = "interval_set"
+
+	webhook    fsm.State = "webhook"
+	webhookSet fsm.State = "webhook_set"
+
+	check    fsm.State = "check"
+	checkRun fsm.State = "check_run"
+
 	fail fsm.State = "fail"
 )
 
@@ -0,0 +1,210 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// maxBodySize limits the quote of the event. Checkers shorten bodies on their
+// own, this limit only keeps messages within Telegram bounds.
+const maxBodySize = 1000
+
+var sourceNames = map[string]string{
+	domain.SourceGitHub:        "Github",
+	domain.SourceGitLab:        "GitLab",
+	domain.SourceStackOverflow: "StackOverflow",
+	domain.SourceGo:            "Go",
+	domain.SourceNPM:           "npm",
+	domain.SourcePyPI:          "PyPI",
+	domain.SourceCrates:        "crates.io",
+}
+
+// headers are looked up by "source/kind" first and by kind then. %s is
+// replaced with the source name.
+var headers = map[string]string{
+	domain.KindIssue:        "Новое Issue на %s!",
+	domain.KindPullRequest:  "Новый Pull Request на %s!",
+	domain.KindMergeRequest: "Новый Merge Request на %s!",
+	domain.KindComment:      "Новый комментарий на %s!",
+	domain.KindCodeComment:  "Новый комментарий к коду на %s!",
+	domain.KindReview:       "Новое ревью на %s!",
+	domain.KindLabeled:      "Добавлена метка на %s!",
+	domain.KindUnlabeled:    "Удалена метка на %s!",
+	domain.KindClosed:       "Закрыто на %s!",
+	domain.KindReopened:     "Переоткрыто на %s!",
+	domain.KindMerged:       "Pull Request слит на %s!",
+	domain.KindCommit:       "Новый коммит на %s!",
+	domain.KindCommits:      "Новые коммиты на %s!",
+	domain.KindRelease:      "Новый релиз на %s!",
+	domain.KindWorkflowRun:  "Завершён запуск workflow на %s!",
+	domain.KindAnswer:       "Новый ответ на %s!",
+	domain.KindEdited:       "Вопрос отредактирован на %s!",
+	domain.KindAccepted:     "Ответ принят на %s!",
+	domain.KindUnaccepted:   "Ответ больше не принят на %s!",
+	domain.KindDuplicate:    "Вопрос отмечен как дубликат на %s!",
+	domain.KindBounty:       "Объявлена награда на %s!",
+	domain.KindVotes:        "Изменился рейтинг на %s!",
+	domain.KindEntry:        "Новая запись в ленте!",
+	domain.KindVersion:      "Новая версия на %s!",
+	domain.KindChange:       "Изменения на странице!",
+
+	domain.SourceGitLab + "/" + domain.KindMerged:          "Merge Request слит на %s!",
+	domain.SourceStackOverflow + "/" + domain.KindClosed:   "Вопрос закрыт на %s!",
+	domain.SourceStackOverflow + "/" + domain.KindReopened: "Вопрос переоткрыт на %s!",
+}
+
+var titleLabels = map[string]string{
+	domain.SourceStackOverflow: "Вопрос",
+}
+
+// attributes are rendered in this order after the common fields.
+var attributes = []struct {
+	key   string
+	label string
+	code  bool
+	yesNo bool
+}{
+	{key: domain.AttrFeed, label: "Лента"},
+	{key: domain.AttrStatus, label: "Статус"},
+	{key: domain.AttrBranch, label: "Ветка"},
+	{key: domain.AttrCommit, label: "Коммит", code: true},
+	{key: domain.AttrCommits, label: "Коммиты"},
+	{key: domain.AttrTag, label: "Тег", code: true},
+	{key: domain.AttrPrerelease, label: "Пре-релиз", yesNo: true},
+	{key: domain.AttrVersion, label: "Версия", code: true},
+	{key: domain.AttrLabel, label: "Метка"},
+	{key: domain.AttrLabels, label: "Метки"},
+	{key: domain.AttrVotes, label: "Голоса"},
+	{key: domain.AttrBounty, label: "Награда"},
+	{key: domain.AttrSelector, label: "Селектор", code: true},
+}
+
+// Event renders the event as a Telegram HTML message.
+func Event(event *domain.Event) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("<b>%s</b>\n", header(event)))
+
+	titleLabel, ok := titleLabels[event.Source]
+	if !ok {
+		titleLabel = "Название"
+	}
+
+	builder.WriteString(
+		fmt.Sprintf(
+			"<b>%s</b>: <a href=%q>%s</a>\n",
+			titleLabel,
+			event.URL,
+			html.EscapeString(event.Title),
+		),
+	)
+
+	if event.Author != "" {
+		builder.WriteString(fmt.Sprintf("<b>Автор</b>: <i>%s</i>\n", html.EscapeString(event.Author)))
+	}
+
+	builder.WriteString(
+		fmt.Sprintf(
+			"<b>Время создания</b>: <i>%s</i>\n",
+			event.CreatedAt.Local().Format("15:04 02.01.2006"),
+		),
+	)
+
+	for _, attr := range attributes {
+		value, ok := event.Attributes[attr.key]
+		if !ok {
+			continue
+		}
+
+		if attr.yesNo {
+			value = yesNo(value)
+		}
+
+		format := "<b>%s</b>: <i>%s</i>\n"
+		if attr.code {
+			format = "<b>%s</b>: <code>%s</code>\n"
+		}
+
+		builder.WriteString(fmt.Sprintf(format, attr.label, html.EscapeString(value)))
+	}
+
+	writeFiles(&builder, event.Attributes)
+
+	if event.Body != "" {
+		builder.WriteString(
+			fmt.Sprintf("<blockquote>%s</blockquote>\n", html.EscapeString(truncate(event.Body))),
+		)
+	}
+
+	return builder.String()
+}
+
+func header(event *domain.Event) string {
+	format, ok := headers[event.Source+"/"+event.Kind]
+	if !ok {
+		format, ok = headers[event.Kind]
+	}
+
+	if !ok {
+		format = "Обновление на %s!"
+	}
+
+	if !strings.Contains(format, "%s") {
+		return format
+	}
+
+	name, ok := sourceNames[event.Source]
+	if !ok {
+		name = event.Source
+	}
+
+	return fmt.Sprintf(format, name)
+}
+
+func writeFiles(builder *strings.Builder, attrs map[string]string) {
+	names := make([]string, 0)
+
+	for key := range attrs {
+		if name, ok := strings.CutPrefix(key, domain.AttrFilePrefix); ok {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	slices.Sort(names)
+
+	builder.WriteString("<b>Файлы</b>:\n")
+
+	for _, name := range names {
+		builder.WriteString(
+			fmt.Sprintf(
+				"- <a href=%q>%s</a>\n",
+				attrs[domain.AttrFilePrefix+name],
+				html.EscapeString(name),
+			),
+		)
+	}
+}
+
+func yesNo(value string) string {
+	if value == "true" {
+		return "да"
+	}
+
+	return "нет"
+}
+
+func truncate(text string) string {
+	rns := []rune(text)
+	if len(rns) > maxBodySize {
+		return string(rns[:maxBodySize]) + "..."
+	}
+
+	return text
+}
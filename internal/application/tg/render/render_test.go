@@ -0,0 +1,90 @@
+package render_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/tg/render"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Release(t *testing.T) {
+	event := domain.Event{
+		Source:    domain.SourceGitHub,
+		Kind:      domain.KindRelease,
+		Author:    "maintainer",
+		Title:     "v1.2.0-rc.1",
+		URL:       "https://github.com/example/repo/releases/tag/v1.2.0-rc.1",
+		Body:      "Bug fixes",
+		CreatedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrTag:        "v1.2.0-rc.1",
+			domain.AttrPrerelease: "true",
+			domain.AttrFilePrefix + "repo.tar.gz": "https://github.com/example/repo/releases/download/" +
+				"v1.2.0-rc.1/repo.tar.gz",
+		},
+	}
+
+	exp := "<b>Новый релиз на Github!</b>\n" +
+		"<b>Название</b>: <a href=\"https://github.com/example/repo/releases/tag/v1.2.0-rc.1\">v1.2.0-rc.1</a>\n" +
+		"<b>Автор</b>: <i>maintainer</i>\n" +
+		"<b>Время создания</b>: <i>09:00 02.04.2025</i>\n" +
+		"<b>Тег</b>: <code>v1.2.0-rc.1</code>\n" +
+		"<b>Пре-релиз</b>: <i>да</i>\n" +
+		"<b>Файлы</b>:\n" +
+		"- <a href=\"https://github.com/example/repo/releases/download/v1.2.0-rc.1/repo.tar.gz\">repo.tar.gz</a>\n" +
+		"<blockquote>Bug fixes</blockquote>\n"
+	assert.Equal(t, exp, render.Event(&event), "invalid message")
+}
+
+func TestEvent_StackOverflow(t *testing.T) {
+	event := domain.Event{
+		Source:     domain.SourceStackOverflow,
+		Kind:       domain.KindClosed,
+		Title:      "How to test my code?",
+		URL:        "https://stackoverflow.com/q/123456",
+		CreatedAt:  time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrVotes: "+3 / -1"},
+	}
+
+	exp := "<b>Вопрос закрыт на StackOverflow!</b>\n" +
+		"<b>Вопрос</b>: <a href=\"https://stackoverflow.com/q/123456\">How to test my code?</a>\n" +
+		"<b>Время создания</b>: <i>15:30 30.03.2025</i>\n" +
+		"<b>Голоса</b>: <i>+3 / -1</i>\n"
+	assert.Equal(t, exp, render.Event(&event), "invalid message")
+}
+
+func TestEvent_Escaping(t *testing.T) {
+	event := domain.Event{
+		Source:     domain.SourcePage,
+		Kind:       domain.KindChange,
+		Title:      "News & updates",
+		URL:        "https://example.com/news",
+		Body:       "- <old>\n+ new",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrSelector: "div.news>ul"},
+	}
+
+	exp := "<b>Изменения на странице!</b>\n" +
+		"<b>Название</b>: <a href=\"https://example.com/news\">News &amp; updates</a>\n" +
+		"<b>Время создания</b>: <i>10:00 01.04.2025</i>\n" +
+		"<b>Селектор</b>: <code>div.news&gt;ul</code>\n" +
+		"<blockquote>- &lt;old&gt;\n+ new</blockquote>\n"
+	assert.Equal(t, exp, render.Event(&event), "invalid message")
+}
+
+func TestEvent_UnknownKind(t *testing.T) {
+	event := domain.Event{
+		Source:    "bitbucket",
+		Kind:      "fork",
+		Title:     "repo",
+		URL:       "https://bitbucket.org/example/repo",
+		CreatedAt: time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+	}
+
+	exp := "<b>Обновление на bitbucket!</b>\n" +
+		"<b>Название</b>: <a href=\"https://bitbucket.org/example/repo\">repo</a>\n" +
+		"<b>Время создания</b>: <i>10:00 01.04.2025</i>\n"
+	assert.Equal(t, exp, render.Event(&event), "invalid message")
+}
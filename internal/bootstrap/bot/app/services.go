@@ -9,6 +9,7 @@ import (
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/http/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/client/kafka"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
 	botscheduler "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/bot"
 	botsrv "github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/bot"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/health"
@@ -83,7 +84,7 @@ func (a *App) runProcessor(ctx context.Context, stop context.CancelFunc, wg *syn
 	}
 
 	scrap := scrapper.NewClient(ogenClient)
-	proc := processor.New(scrap, a.channels, a.cache, a.prometheus)
+	proc := processor.New(scrap, a.channels, a.cache, a.prometheus, linktype.New(&a.cfg.GitLab))
 
 	if err := proc.Run(ctx); err != nil {
 		slog.Error("failed to run processor", slog.Any("err", err))
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	scrshed "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/metrics"
@@ -21,14 +23,17 @@ type App struct {
 	rdb      *redis.Client
 	repo     repo.Repository
 	channels *domain.Channels
+	types    *linktype.Registry
 
 	prometheus *metrics.Prometheus
+	scheduler  *scrshed.Scheduler
 }
 
 func New(cfg *config.Config) *App {
 	return &App{
 		cfg:      cfg,
 		channels: domain.NewChannels(),
+		types:    linktype.New(&cfg.GitLab),
 	}
 }
 
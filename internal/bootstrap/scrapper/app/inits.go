@@ -4,8 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	scrshed "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/feed"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/gitlab"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/page"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/registry"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/sof"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/metrics"
 	repo "github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/client"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 )
@@ -18,6 +26,7 @@ func (a *App) inits() []InitFunc {
 		a.initRepo,
 		a.initRedis,
 		a.initPrometheus,
+		a.initScheduler,
 	}
 }
 
@@ -90,3 +99,47 @@ func (a *App) initPrometheus(_ context.Context) error {
 
 	return nil
 }
+
+func (a *App) initScheduler(_ context.Context) error {
+	upd, err := a.getBotClient()
+	if err != nil {
+		return fmt.Errorf("failed to create bot client: %w", err)
+	}
+
+	// every source has its own client, so a failing source doesn't open the
+	// circuit breaker of the others. Feeds and pages are fetched from any host
+	// given by users.
+	ghClient := github.New(&a.cfg.GitHub, client.New(&a.cfg.Client))
+	ghChecker := a.githubChecker(ghClient)
+	ghBranchClient := github.NewBranch(ghClient)
+	ghWorkflowClient := github.NewWorkflow(ghClient)
+	glClient := gitlab.New(&a.cfg.GitLab, client.New(&a.cfg.Client))
+	sofClient := sof.New(&a.cfg.SOF, client.New(&a.cfg.Client))
+	feedClient := feed.New(client.NewPublic(&a.cfg.Client))
+	goProxyClient := registry.NewGoProxy(&a.cfg.Registry, client.New(&a.cfg.Client))
+	npmClient := registry.NewNPM(&a.cfg.Registry, client.New(&a.cfg.Client))
+	pypiClient := registry.NewPyPI(&a.cfg.Registry, client.New(&a.cfg.Client))
+	cratesClient := registry.NewCrates(&a.cfg.Registry, client.New(&a.cfg.Client))
+	pageClient := page.New(client.NewPublic(&a.cfg.Client))
+
+	a.scheduler = scrshed.NewScheduler(
+		&a.cfg.Scrapper.Scheduler,
+		a.repo,
+		upd,
+		a.prometheus,
+		a.types,
+		ghChecker,
+		ghBranchClient,
+		ghWorkflowClient,
+		glClient,
+		sofClient,
+		feedClient,
+		goProxyClient,
+		npmClient,
+		pypiClient,
+		cratesClient,
+		pageClient,
+	)
+
+	return nil
+}
@@ -14,9 +14,9 @@ import (
 	scrshed "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/health"
 	scrapsrv "github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/server/http/webhook"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application/updater"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
-	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/sof"
 	botapi "github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/bot"
 	scrapperapi "github.com/es-debug/backend-academy-2024-go-template/pkg/api/http/v1/scrapper"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/client"
@@ -45,7 +45,7 @@ func (a *App) runServer(ctx context.Context, stop context.CancelFunc, wg *sync.W
 	defer stop()
 	defer slog.Info("service stopped")
 
-	scrapperServer := scrapsrv.NewServer(a.repo)
+	scrapperServer := scrapsrv.NewServer(a.repo, a.types, a.scheduler, &a.cfg.GitHub)
 
 	srv, err := scrapperapi.NewServer(scrapperServer)
 	if err != nil {
@@ -58,11 +58,16 @@ func (a *App) runServer(ctx context.Context, stop context.CancelFunc, wg *sync.W
 	rateLimiter := ratelimiter.NewSlidingWindow(repo, &a.cfg.Scrapper.RateLimiter)
 
 	metricsMW := metricsmw.New(a.prometheus)
-	activeLinksMW := mws.NewLinksCounter(a.repo, a.prometheus)
+	activeLinksMW := mws.NewLinksCounter(a.repo, a.prometheus, a.types)
+
+	// webhooks are signed by GitHub, so they bypass the rate limiter of the API
+	mux := http.NewServeMux()
+	webhook.New(&a.cfg.GitHub, github.NewWebhook(), a.scheduler).RegisterRoutes(mux)
+	mux.Handle("/", middleware.Wrap(srv, metricsMW, rateLimiter, activeLinksMW))
 
 	httpServer := &http.Server{
 		Addr:              a.cfg.Scrapper.URL,
-		Handler:           middleware.Wrap(srv, metricsMW, rateLimiter, activeLinksMW),
+		Handler:           mux,
 		ReadTimeout:       a.cfg.Server.ReadTimeout,
 		ReadHeaderTimeout: a.cfg.Server.ReadHeaderTimeout,
 	}
@@ -90,27 +95,7 @@ func (a *App) runScheduler(ctx context.Context, stop context.CancelFunc, wg *syn
 	defer stop()
 	defer slog.Info("scheduler stopped")
 
-	upd, err := a.getBotClient()
-	if err != nil {
-		slog.Error("failed to create bot client", slog.Any("error", err))
-
-		return
-	}
-
-	httpClient := client.New(&a.cfg.Client)
-	ghClient := github.New(&a.cfg.GitHub, httpClient)
-	sofClient := sof.New(&a.cfg.SOF, httpClient)
-
-	schedule := scrshed.NewScheduler(
-		&a.cfg.Scrapper.Scheduler,
-		a.repo,
-		upd,
-		a.prometheus,
-		ghClient,
-		sofClient,
-	)
-
-	if err := schedule.Run(ctx); err != nil {
+	if err := a.scheduler.Run(ctx); err != nil {
 		slog.Error("failed to run scheduler", slog.Any("error", err))
 	}
 }
@@ -199,3 +184,15 @@ func (a *App) getBotClient() (*updater.Updater, error) {
 
 	return updater.New(handlers...), nil
 }
+
+// githubChecker selects the API of repository links, both report the same
+// updates.
+func (a *App) githubChecker(ghClient *github.GitHub) scrshed.Checher {
+	switch a.cfg.GitHub.API {
+	case "graphql":
+		return github.NewGraphQL(ghClient)
+
+	default:
+		return ghClient
+	}
+}
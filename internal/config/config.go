@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -10,6 +13,8 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/middleware/ratelimiter"
 )
 
+const gitLabCom = "https://gitlab.com"
+
 type Config struct {
 	App      App              `envPrefix:"APP_"`
 	Bot      Bot              `envPrefix:"BOT_"`
@@ -17,7 +22,9 @@ type Config struct {
 	Client   clientcfg.Config `envPrefix:"CLIENT_"`
 	Server   Server           `envPrefix:"SERVER_"`
 	GitHub   GitHub           `envPrefix:"GITHUB_"`
+	GitLab   GitLab           `envPrefix:"GITLAB_"`
 	SOF      SOF              `envPrefix:"SOF_"`
+	Registry Registry         `envPrefix:"REGISTRY_"`
 	Kafka    Kafka            `envPrefix:"KAFKA_"`
 }
 
@@ -56,9 +63,53 @@ type Server struct {
 type GitHub struct {
 	Token    string `env:"TOKEN,required"`
 	PageSize string `env:"PAGE_SIZE"      envDefault:"100"`
+	// API is rest or graphql: the latter batches repository links.
+	API string `env:"API" envDefault:"rest"`
+	// WebhookSecret enables the webhook endpoint of the scrapper.
+	WebhookSecret string `env:"WEBHOOK_SECRET"`
+}
+
+// GitLab configures the main instance at BaseURL. Instances adds other
+// instances by their base URLs with tokens, e.g.
+// "https://gitlab.gnome.org=token,https://salsa.debian.org=". gitlab.com is
+// always tracked, without a token unless it is configured.
+type GitLab struct {
+	BaseURL   string            `env:"BASE_URL"  envDefault:"https://gitlab.com"`
+	Token     string            `env:"TOKEN"`
+	Instances map[string]string `env:"INSTANCES" envKeyValSeparator:"="`
+	PageSize  string            `env:"PAGE_SIZE" envDefault:"100"`
+}
+
+type GitLabInstance struct {
+	BaseURL string
+	Token   string
+}
+
+// AllInstances returns the main instance first, other instances sorted by
+// their base URLs and gitlab.com last if it is not configured.
+func (g *GitLab) AllInstances() []GitLabInstance {
+	instances := []GitLabInstance{{BaseURL: strings.TrimSuffix(g.BaseURL, "/"), Token: g.Token}}
+	seen := map[string]bool{instances[0].BaseURL: true}
+
+	for _, baseURL := range slices.Sorted(maps.Keys(g.Instances)) {
+		trimmed := strings.TrimSuffix(baseURL, "/")
+		if seen[trimmed] {
+			continue
+		}
+
+		seen[trimmed] = true
+		instances = append(instances, GitLabInstance{BaseURL: trimmed, Token: g.Instances[baseURL]})
+	}
+
+	if !seen[gitLabCom] {
+		instances = append(instances, GitLabInstance{BaseURL: gitLabCom})
+	}
+
+	return instances
 }
 
 type SOF struct {
+	Key      string `env:"KEY"`
 	PageSize string `env:"PAGE_SIZE" envDefault:"100"`
 }
 
@@ -69,9 +120,36 @@ type BotScheduler struct {
 }
 
 type ScrapperScheduler struct {
-	Interval   time.Duration `env:"INTERVAL"   envDefault:"1h"`
-	PageSize   uint          `env:"PAGE_SIZE"  envDefault:"100"`
-	Transports []string      `env:"TRANSPORTS" envDefault:"http"`
+	// Interval is the tick of the scheduler. Links are checked on the first
+	// tick after their next check, which adapts to the activity of the link
+	// between MinInterval and MaxInterval.
+	Interval    time.Duration `env:"INTERVAL"       envDefault:"1m"`
+	MinInterval time.Duration `env:"MIN_INTERVAL"   envDefault:"5m"`
+	MaxInterval time.Duration `env:"MAX_INTERVAL"   envDefault:"24h"`
+	// Lookback overlaps the time windows of checks. Every check fetches one
+	// more lookback before its window: events found there for the first time
+	// are sent and counted as late. Events appearing upstream later than that
+	// are never fetched and are not detected.
+	Lookback      time.Duration `env:"LOOKBACK"       envDefault:"10m"`
+	ScrapeTimeout time.Duration `env:"SCRAPE_TIMEOUT" envDefault:"1m"`
+	PageSize      uint          `env:"PAGE_SIZE"      envDefault:"100"`
+	Transports    []string      `env:"TRANSPORTS"     envDefault:"http"`
+	// Scrapes of a source slow down when its quota is below RateLimitSlowdown
+	// and pause until the reset when RateLimitReserve requests are left.
+	RateLimitSlowdown int `env:"RATE_LIMIT_SLOWDOWN" envDefault:"1000"`
+	RateLimitReserve  int `env:"RATE_LIMIT_RESERVE"  envDefault:"100"`
+	// Replicas split links by leases, a lease of a crashed replica expires in
+	// LeaseTimeout. It must be longer than a tick.
+	ReplicaID    string        `env:"REPLICA_ID"`
+	LeaseTimeout time.Duration `env:"LEASE_TIMEOUT" envDefault:"30m"`
+	// Every source is checked by its own pool of Workers with a queue of
+	// QueueSize links. SourceWorkers overrides the number of workers and
+	// SourceRates limits scrapes per second of a source, e.g.
+	// "stackoverflow:2,github:10".
+	Workers       int                `env:"WORKERS"        envDefault:"5"`
+	QueueSize     int                `env:"QUEUE_SIZE"     envDefault:"1000"`
+	SourceWorkers map[string]int     `env:"SOURCE_WORKERS"`
+	SourceRates   map[string]float64 `env:"SOURCE_RATES"`
 }
 
 type Database struct {
@@ -84,6 +162,13 @@ type Database struct {
 	Type     string `env:"TYPE,required"`
 }
 
+type Registry struct {
+	GoProxyURL string `env:"GOPROXY_URL" envDefault:"https://proxy.golang.org"`
+	NPMURL     string `env:"NPM_URL"     envDefault:"https://registry.npmjs.org"`
+	PyPIURL    string `env:"PYPI_URL"    envDefault:"https://pypi.org"`
+	CratesURL  string `env:"CRATES_URL"  envDefault:"https://crates.io"`
+}
+
 type Kafka struct {
 	Core           kafkacfg.Kafka
 	CircuitBreaker CircuitBreaker `envPrefix:"CIRCUIT_BREAKER_"`
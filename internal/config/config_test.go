@@ -14,12 +14,16 @@ func TestLoad_Success(t *testing.T) {
 	assert.NoError(t, os.Setenv("BOT_SCRAPPER_URL", "http://localhost:8080"))
 	assert.NoError(t, os.Setenv("SCRAPPER_BOT_URL", "http://localhost:8081"))
 	assert.NoError(t, os.Setenv("GITHUB_TOKEN", "github_test_token"))
+	assert.NoError(t, os.Setenv("GITLAB_BASE_URL", "https://git.example.com/"))
+	assert.NoError(t, os.Setenv("GITLAB_INSTANCES", "https://gitlab.gnome.org=gnome_token,https://salsa.debian.org="))
 
 	assert.NoError(t, os.Setenv("APP_TERMINATE_TIMEOUT", "7s"))
 	assert.NoError(t, os.Setenv("APP_SHUTDOWN_TIMEOUT", "3s"))
 	assert.NoError(t, os.Setenv("CLIENT_HTTP_DIAL_TIMEOUT", "6s"))
 	assert.NoError(t, os.Setenv("SERVER_READ_TIMEOUT", "12s"))
 	assert.NoError(t, os.Setenv("SCRAPPER_SCHEDULER_INTERVAL", "2h"))
+	assert.NoError(t, os.Setenv("SCRAPPER_SCHEDULER_SOURCE_WORKERS", "stackoverflow:2,github:10"))
+	assert.NoError(t, os.Setenv("SCRAPPER_SCHEDULER_SOURCE_RATES", "stackoverflow:0.5"))
 
 	assert.NoError(t, os.Setenv("BOT_DATABASE_HOST", "localhost"))
 	assert.NoError(t, os.Setenv("BOT_DATABASE_PORT", "5432"))
@@ -77,6 +81,17 @@ func TestLoad_Success(t *testing.T) {
 	)
 
 	assert.Equal(t, "github_test_token", cfg.GitHub.Token, "unexpected GitHub.Token")
+	assert.Equal(
+		t,
+		[]config.GitLabInstance{
+			{BaseURL: "https://git.example.com"},
+			{BaseURL: "https://gitlab.gnome.org", Token: "gnome_token"},
+			{BaseURL: "https://salsa.debian.org"},
+			{BaseURL: "https://gitlab.com"},
+		},
+		cfg.GitLab.AllInstances(),
+		"unexpected GitLab.AllInstances",
+	)
 
 	assert.Equal(
 		t,
@@ -84,6 +99,19 @@ func TestLoad_Success(t *testing.T) {
 		cfg.Scrapper.Scheduler.Interval,
 		"unexpected Scheduler.Interval",
 	)
+	assert.Equal(
+		t,
+		map[string]int{"stackoverflow": 2, "github": 10},
+		cfg.Scrapper.Scheduler.SourceWorkers,
+		"unexpected Scheduler.SourceWorkers",
+	)
+	assert.Equal(
+		t,
+		map[string]float64{"stackoverflow": 0.5},
+		cfg.Scrapper.Scheduler.SourceRates,
+		"unexpected Scheduler.SourceRates",
+	)
+	assert.Equal(t, 5, cfg.Scrapper.Scheduler.Workers, "unexpected default Scheduler.Workers")
 	assert.Equal(
 		t,
 		uint(10),
@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+const (
+	SourceGitHub        = "github"
+	SourceGitLab        = "gitlab"
+	SourceStackOverflow = "stackoverflow"
+	SourceFeed          = "feed"
+	SourceGo            = "go"
+	SourceNPM           = "npm"
+	SourcePyPI          = "pypi"
+	SourceCrates        = "crates"
+	SourcePage          = "page"
+)
+
+const (
+	KindIssue        = "issue"
+	KindPullRequest  = "pull_request"
+	KindMergeRequest = "merge_request"
+	KindComment      = "comment"
+	KindCodeComment  = "code_comment"
+	KindReview       = "review"
+	KindLabeled      = "labeled"
+	KindUnlabeled    = "unlabeled"
+	KindClosed       = "closed"
+	KindReopened     = "reopened"
+	KindMerged       = "merged"
+	KindCommit       = "commit"
+	KindCommits      = "commits"
+	KindRelease      = "release"
+	KindWorkflowRun  = "workflow_run"
+	KindAnswer       = "answer"
+	KindEdited       = "edited"
+	KindAccepted     = "accepted"
+	KindUnaccepted   = "unaccepted"
+	KindDuplicate    = "duplicate"
+	KindBounty       = "bounty"
+	KindVotes        = "votes"
+	KindEntry        = "entry"
+	KindVersion      = "version"
+	KindChange       = "change"
+)
+
+const (
+	AttrStatus     = "status"
+	AttrBranch     = "branch"
+	AttrCommit     = "commit"
+	AttrCommits    = "commits"
+	AttrTag        = "tag"
+	AttrPrerelease = "prerelease"
+	AttrLabel      = "label"
+	AttrVotes      = "votes"
+	AttrScore      = "score"
+	AttrBounty     = "bounty"
+	AttrVersion    = "version"
+	AttrFeed       = "feed"
+	AttrSelector   = "selector"
+
+	// AttrLabels holds comma separated labels of issues and pull requests.
+	AttrLabels = "labels"
+
+	// AttrFilePrefix starts attributes of attached files: the rest of the key
+	// is the file name and the value is its URL.
+	AttrFilePrefix = "file:"
+)
+
+// Event is a single update of a tracked resource. Checkers return events and
+// the bot renders them, so filters work with fields instead of message text.
+type Event struct {
+	Source     string            `json:"source"`
+	Kind       string            `json:"kind"`
+	ExternalID string            `json:"external_id"`
+	Author     string            `json:"author"`
+	Title      string            `json:"title"`
+	URL        string            `json:"url"`
+	Body       string            `json:"body"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Parts are external IDs of items grouped in the event, such as commits.
+	// Each of them is delivered once, whatever group it was sent in.
+	Parts []string `json:"-"`
+}
@@ -16,6 +16,11 @@ type CheckLink struct {
 	URL       string     `json:"url"        db:"url"`
 	Chats     []LinkChat `json:"chats"      db:"chats"`
 	CheckedAt time.Time  `json:"checked_at" db:"checked_at"`
+	// Interval is the adaptive interval of the last check, zero before the
+	// first one.
+	Interval time.Duration `json:"interval" db:"check_interval"`
+	// Webhook links get updates from webhooks and are not scheduled.
+	Webhook bool `json:"webhook" db:"webhook"`
 }
 
 type LinkChat struct {
@@ -23,4 +28,6 @@ type LinkChat struct {
 	SendImmediately bool     `json:"send_immediately" db:"send_immediately"`
 	Filters         []string `json:"filters"          db:"filters"`
 	Tags            []string `json:"tags"             db:"tags"`
+	// Interval is pinned by the chat, zero keeps the adaptive interval.
+	Interval time.Duration `json:"interval" db:"check_interval"`
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// RateLimit is the request quota of a source. Remaining requests are
+// restored at Reset.
+type RateLimit struct {
+	Source    string
+	Remaining int
+	Reset     time.Time
+}
@@ -244,12 +244,9 @@ func TestCache_Updates(t *testing.T) {
 	cache := botcache.New(rdb, time.Hour)
 	chatID := int64(123)
 	update := &domain.Update{
-		ChatID: chatID,
-		URL:    "https://example.com",
-		Message: `{
-			"message": "test message",
-			"tags": ["tag1", "tag2"]
-		}`,
+		ChatID:          chatID,
+		URL:             "https://example.com",
+		Event:           domain.Event{Kind: domain.KindIssue, Title: "test message"},
 		Tags:            []string{"tag1", "tag2"},
 		SendImmediately: domain.NewNull(false),
 	}
@@ -266,7 +263,7 @@ func TestCache_Updates(t *testing.T) {
 	assert.Len(t, updates, 1, "expected 1 update")
 	assert.Equal(t, chatID, updates[0].ChatID, "chat ID does not match")
 	assert.Equal(t, update.URL, updates[0].URL, "URL does not match")
-	assert.Equal(t, update.Message, updates[0].Message, "message does not match")
+	assert.Equal(t, update.Event, updates[0].Event, "event does not match")
 	assert.Equal(t, update.Tags, updates[0].Tags, "tags do not match")
 	assert.Equal(
 		t,
@@ -304,22 +301,16 @@ func TestCache_MultipleChatsWithUpdates(t *testing.T) {
 			chatID: 123,
 			updates: []*domain.Update{
 				{
-					ChatID: 123,
-					URL:    "https://example.com/1",
-					Message: `{
-						"message": "test message 1",
-						"tags": ["tag1"]
-					}`,
+					ChatID:          123,
+					URL:             "https://example.com/1",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 1"},
 					Tags:            []string{"tag1"},
 					SendImmediately: domain.NewNull(true),
 				},
 				{
-					ChatID: 123,
-					URL:    "https://example.com/2",
-					Message: `{
-						"message": "test message 2",
-						"tags": ["tag2"]
-					}`,
+					ChatID:          123,
+					URL:             "https://example.com/2",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 2"},
 					Tags:            []string{"tag2"},
 					SendImmediately: domain.NewNull(false),
 				},
@@ -329,12 +320,9 @@ func TestCache_MultipleChatsWithUpdates(t *testing.T) {
 			chatID: 456,
 			updates: []*domain.Update{
 				{
-					ChatID: 456,
-					URL:    "https://example.com/3",
-					Message: `{
-						"message": "test message 3",
-						"tags": ["tag3"]
-					}`,
+					ChatID:          456,
+					URL:             "https://example.com/3",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 3"},
 					Tags:            []string{"tag3"},
 					SendImmediately: domain.NewNull(true),
 				},
@@ -344,32 +332,23 @@ func TestCache_MultipleChatsWithUpdates(t *testing.T) {
 			chatID: 789,
 			updates: []*domain.Update{
 				{
-					ChatID: 789,
-					URL:    "https://example.com/4",
-					Message: `{
-						"message": "test message 4",
-						"tags": ["tag4"]
-					}`,
+					ChatID:          789,
+					URL:             "https://example.com/4",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 4"},
 					Tags:            []string{"tag4"},
 					SendImmediately: domain.NewNull(false),
 				},
 				{
-					ChatID: 789,
-					URL:    "https://example.com/5",
-					Message: `{
-						"message": "test message 5",
-						"tags": ["tag5"]
-					}`,
+					ChatID:          789,
+					URL:             "https://example.com/5",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 5"},
 					Tags:            []string{"tag5"},
 					SendImmediately: domain.NewNull(true),
 				},
 				{
-					ChatID: 789,
-					URL:    "https://example.com/6",
-					Message: `{
-						"message": "test message 6",
-						"tags": ["tag6"]
-					}`,
+					ChatID:          789,
+					URL:             "https://example.com/6",
+					Event:           domain.Event{Kind: domain.KindIssue, Title: "test message 6"},
 					Tags:            []string{"tag6"},
 					SendImmediately: domain.NewNull(false),
 				},
@@ -423,9 +402,9 @@ func TestCache_MultipleChatsWithUpdates(t *testing.T) {
 			)
 			assert.Equal(
 				t,
-				expected.Message,
-				update.Message,
-				"message mismatch for update %d in chat %d",
+				expected.Event,
+				update.Event,
+				"event mismatch for update %d in chat %d",
 				i,
 				chat.chatID,
 			)
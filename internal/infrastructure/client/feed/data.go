@@ -0,0 +1,47 @@
+package feed
+
+import "encoding/xml"
+
+// Document covers both RSS 2.0 and Atom documents. RSS keeps items inside
+// the channel element, Atom keeps entries in the root element.
+type Document struct {
+	XMLName xml.Name    `xml:""`
+	Channel Channel     `xml:"channel"`
+	Title   string      `xml:"title"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+type Channel struct {
+	Title string    `xml:"title"`
+	Items []RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	GUID        string `xml:"guid"`
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Author      string `xml:"author"`
+	Creator     string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+}
+
+type AtomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []AtomLink `xml:"link"`
+	Authors   []Author   `xml:"author"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type Author struct {
+	Name string `xml:"name"`
+}
@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+var tagRegex = regexp.MustCompile(`<[^>]+>`)
+
+// EntryToEvent identifies entries by the guid or id element and falls back to
+// the link for feeds without them.
+func EntryToEvent(entry *Entry, feedTitle string) domain.Event {
+	id := entry.ID
+	if id == "" {
+		id = entry.Link
+	}
+
+	event := domain.Event{
+		Source:     domain.SourceFeed,
+		Kind:       domain.KindEntry,
+		ExternalID: id,
+		Author:     clearHTML(entry.Author),
+		Title:      clearHTML(entry.Title),
+		URL:        entry.Link,
+		Body:       preview(clearHTML(entry.Summary)),
+		CreatedAt:  entry.Published,
+	}
+
+	if feedTitle != "" {
+		event.Attributes = map[string]string{
+			domain.AttrFeed: clearHTML(feedTitle),
+		}
+	}
+
+	return event
+}
+
+func preview(text string) string {
+	rns := []rune(text)
+	if len(rns) > 200 {
+		rns = rns[:200]
+		rns = append(rns, []rune("...")...)
+
+		return string(rns)
+	}
+
+	return text
+}
+
+// clearHTML removes tags from the text. Feeds often keep escaped HTML in
+// summaries, so the text is unescaped before that.
+func clearHTML(text string) string {
+	text = tagRegex.ReplaceAllString(html.UnescapeString(text), "")
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,33 @@
+package feed
+
+import "fmt"
+
+type ErrUnexpectedStatus struct {
+	URL    string
+	Status int
+}
+
+func NewErrUnexpectedStatus(url string, status int) error {
+	return ErrUnexpectedStatus{
+		URL:    url,
+		Status: status,
+	}
+}
+
+func (e ErrUnexpectedStatus) Error() string {
+	return fmt.Sprintf("unexpected status %d for url=%q", e.Status, e.URL)
+}
+
+type ErrUnknownFormat struct {
+	Root string
+}
+
+func NewErrUnknownFormat(root string) error {
+	return ErrUnknownFormat{
+		Root: root,
+	}
+}
+
+func (e ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("unknown feed format with root element %q", e.Root)
+}
@@ -0,0 +1,223 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"golang.org/x/net/html/charset"
+)
+
+// maxFeedSize limits the size of a downloaded feed.
+const maxFeedSize = 5 << 20
+
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+}
+
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Feed reports new entries of RSS 2.0 and Atom feeds. Links are recognized by
+// the path: it must end with .xml, .rss, .atom, /feed, /rss or /atom.
+type Feed struct {
+	client    Client
+	linkRegex *regexp.Regexp
+}
+
+type Entry struct {
+	ID        string
+	Title     string
+	Link      string
+	Author    string
+	Summary   string
+	Published time.Time
+}
+
+func New(client Client) *Feed {
+	return &Feed{
+		client: client,
+		linkRegex: regexp.MustCompile(
+			`(?i)^https?://[^/?#]+(?:/[^?#]*)?(?:\.(?:xml|rss|atom)|/(?:feed|rss|atom)/?)(?:\?[^#]*)?$`,
+		),
+	}
+}
+
+func (f *Feed) GetType() string {
+	return "feed"
+}
+
+// GetUpdates skips entries without publication time, as it is impossible to
+// say whether they are new.
+func (f *Feed) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	if !f.linkRegex.MatchString(link) {
+		return []domain.Event{}, nil
+	}
+
+	doc, err := f.getDocument(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	title, entries, err := doc.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0)
+
+	for _, entry := range entries {
+		if entry.Published.IsZero() || from.After(entry.Published) || to.Before(entry.Published) {
+			continue
+		}
+
+		events = append(events, EntryToEvent(&entry, title))
+	}
+
+	return events, nil
+}
+
+func (f *Feed) getDocument(ctx context.Context, link string) (*Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request with url=%q: %w", link, err)
+	}
+
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response with url=%q: %w", link, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error(
+				"failed to close response body",
+				slog.Any("error", err),
+				slog.Any("service", "feed client"),
+				slog.Any("url", link),
+			)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewErrUnexpectedStatus(link, resp.StatusCode)
+	}
+
+	dec := xml.NewDecoder(io.LimitReader(resp.Body, maxFeedSize))
+	dec.CharsetReader = charset.NewReaderLabel
+
+	var doc Document
+
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode response with url=%q: %w", link, err)
+	}
+
+	return &doc, nil
+}
+
+func (d *Document) entries() (string, []Entry, error) {
+	switch d.XMLName.Local {
+	case "rss":
+		entries := make([]Entry, 0, len(d.Channel.Items))
+
+		for _, item := range d.Channel.Items {
+			author := item.Creator
+			if author == "" {
+				author = item.Author
+			}
+
+			entries = append(entries, Entry{
+				ID:        strings.TrimSpace(item.GUID),
+				Title:     strings.TrimSpace(item.Title),
+				Link:      strings.TrimSpace(item.Link),
+				Author:    strings.TrimSpace(author),
+				Summary:   item.Description,
+				Published: parseDate(item.PubDate),
+			})
+		}
+
+		return strings.TrimSpace(d.Channel.Title), entries, nil
+
+	case "feed":
+		entries := make([]Entry, 0, len(d.Entries))
+
+		for _, entry := range d.Entries {
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+
+			summary := entry.Summary
+			if summary == "" {
+				summary = entry.Content
+			}
+
+			entries = append(entries, Entry{
+				ID:        strings.TrimSpace(entry.ID),
+				Title:     strings.TrimSpace(entry.Title),
+				Link:      entry.link(),
+				Author:    entry.author(),
+				Summary:   summary,
+				Published: parseDate(published),
+			})
+		}
+
+		return strings.TrimSpace(d.Title), entries, nil
+
+	default:
+		return "", nil, NewErrUnknownFormat(d.XMLName.Local)
+	}
+}
+
+func (e *AtomEntry) link() string {
+	for _, link := range e.Links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+
+	if len(e.Links) != 0 {
+		return e.Links[0].Href
+	}
+
+	return ""
+}
+
+func (e *AtomEntry) author() string {
+	names := make([]string, 0, len(e.Authors))
+
+	for _, author := range e.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range dateLayouts {
+		if tm, err := time.Parse(layout, value); err == nil {
+			return tm
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,96 @@
+package feed_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/feed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/blog/feed.xml", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/rss.xml")
+	})
+	mux.HandleFunc("/example/repo/releases.atom", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/atom.xml")
+	})
+	mux.HandleFunc("/missing/feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetUpdates_RSS(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	f := feed.New(server.Client())
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := f.GetUpdates(context.Background(), server.URL+"/blog/feed.xml", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	exp := domain.Event{
+		Source:     domain.SourceFeed,
+		Kind:       domain.KindEntry,
+		ExternalID: "https://go.dev/blog/go1.24",
+		Author:     "Go Team",
+		Title:      "Go 1.24 is released",
+		URL:        "https://go.dev/blog/go1.24",
+		Body:       "Today the Go team is happy to release Go 1.24.",
+		CreatedAt:  events[0].CreatedAt,
+		Attributes: map[string]string{domain.AttrFeed: "Go Blog"},
+	}
+	assert.Equal(t, exp, events[0], "invalid event")
+	assert.True(t, exp.CreatedAt.Equal(time.Date(2025, 4, 1, 11, 0, 0, 0, time.UTC)), "invalid time")
+}
+
+func TestGetUpdates_Atom(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	f := feed.New(server.Client())
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := f.GetUpdates(context.Background(), server.URL+"/example/repo/releases.atom", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1, "entries are matched by publication time")
+
+	assert.Equal(t, "Release notes from repo", events[0].Attributes[domain.AttrFeed])
+	assert.Equal(t, "https://github.com/example/repo/releases/tag/v1.1.0", events[0].URL)
+	assert.Equal(t, "v1.1.0", events[0].Title)
+	assert.Equal(t, "maintainer", events[0].Author)
+	assert.Equal(t, "ChangesFix bug", events[0].Body)
+}
+
+func TestGetUpdates_NotFeed(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t)
+	f := feed.New(server.Client())
+
+	events, err := f.GetUpdates(context.Background(), "https://github.com/example/repo", time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = f.GetUpdates(context.Background(), server.URL+"/missing/feed", time.Time{}, time.Now())
+	assert.ErrorAs(t, err, &feed.ErrUnexpectedStatus{})
+}
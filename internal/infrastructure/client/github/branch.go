@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const (
+	repoCommitsURL = "https://api.github.com/repos/%s/%s/commits"
+	repoCommitURL  = "https://api.github.com/repos/%s/%s/commits/%s"
+	repoCompareURL = "https://api.github.com/repos/%s/%s/compare/%s...%s"
+
+	compareURL = "%s/compare/%s...%s"
+)
+
+// Branch reports commits pushed to a branch. New commits are grouped by
+// author, so a large push produces a few messages only.
+type Branch struct {
+	github      *GitHub
+	branchRegex *regexp.Regexp
+}
+
+// NewBranch shares the client and the rate limit of gh.
+func NewBranch(gh *GitHub) *Branch {
+	return &Branch{
+		github:      gh,
+		branchRegex: regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/tree/([\w./-]+)$`),
+	}
+}
+
+func (b *Branch) GetType() string {
+	return "github_branch"
+}
+
+func (b *Branch) RateLimits() []domain.RateLimit {
+	return b.github.RateLimits()
+}
+
+func (b *Branch) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := b.branchRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	owner, repo, branch := matches[1], matches[2], matches[3]
+
+	params := url.Values{}
+	params.Add("sha", branch)
+	params.Add("since", from.UTC().Format(time.RFC3339))
+	params.Add("until", to.UTC().Format(time.RFC3339))
+
+	commits, err := getAll[Commit](ctx, b.github, fmt.Sprintf(repoCommitsURL, owner, repo), params)
+	if err != nil {
+		return nil, err
+	}
+
+	title := fmt.Sprintf("%s/%s:%s", owner, repo, branch)
+	events := make([]domain.Event, 0, len(commits))
+
+	for _, commit := range commits {
+		if inRange(commit.Commit.Committer.Date, from, to) {
+			events = append(events, BranchCommitToEvent(&commit, title))
+		}
+	}
+
+	return events, nil
+}
+
+// GetCursorUpdates lists commits added to the branch after the head of the
+// previous check, whatever their dates: a rebased or long-lived local branch
+// pushes commits dated before the window. The first check, or a head lost to a
+// force push, falls back to commits by dates. It returns the current head.
+func (b *Branch) GetCursorUpdates(
+	ctx context.Context,
+	link string,
+	cursor string,
+	from, to time.Time,
+) ([]domain.Event, string, error) {
+	matches := b.branchRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, cursor, nil
+	}
+
+	owner, repo, branch := matches[1], matches[2], matches[3]
+
+	if cursor != "" {
+		commits, err := b.compare(ctx, owner, repo, cursor, branch)
+		if err == nil {
+			return b.commitsToEvents(owner, repo, branch, commits), headOf(commits, cursor), nil
+		}
+
+		var statusErr ErrUnexpectedStatus
+		if !errors.As(err, &statusErr) || statusErr.Status != http.StatusNotFound {
+			return nil, "", err
+		}
+	}
+
+	// the head is taken first, commits pushed after it are found again by the
+	// next check and dropped as delivered
+	var head Commit
+
+	err := b.github.getAndDecodeResponse(ctx, fmt.Sprintf(repoCommitURL, owner, repo, branch), nil, &head)
+	if err != nil {
+		return nil, "", err
+	}
+
+	events, err := b.GetUpdates(ctx, link, from, to)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return events, head.SHA, nil
+}
+
+// compare lists commits from base to head, the oldest first.
+func (b *Branch) compare(ctx context.Context, owner, repo, base, head string) ([]Commit, error) {
+	link := fmt.Sprintf(repoCompareURL, owner, repo, base, head)
+	commits := make([]Commit, 0)
+
+	for page := 1; ; page++ {
+		var comparison Comparison
+
+		if err := b.github.getAndDecodePage(ctx, link, nil, page, &comparison); err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, comparison.Commits...)
+
+		if len(comparison.Commits) == 0 || len(commits) >= comparison.TotalCommits {
+			return commits, nil
+		}
+	}
+}
+
+// commitsToEvents orders compared commits from the newest, as the commits list
+// does.
+func (b *Branch) commitsToEvents(owner, repo, branch string, commits []Commit) []domain.Event {
+	title := fmt.Sprintf("%s/%s:%s", owner, repo, branch)
+	events := make([]domain.Event, 0, len(commits))
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		events = append(events, BranchCommitToEvent(&commits[i], title))
+	}
+
+	return events
+}
+
+func headOf(commits []Commit, cursor string) string {
+	if len(commits) == 0 {
+		return cursor
+	}
+
+	return commits[len(commits)-1].SHA
+}
+
+// GroupEvents groups commits by author. Delivered commits are dropped by the
+// scheduler before, so groups and the compare range cover new commits only.
+func (b *Branch) GroupEvents(events []domain.Event) []domain.Event {
+	if len(events) == 0 {
+		return events
+	}
+
+	compare := batchCompareURL(events)
+	grouped := make([]domain.Event, 0)
+
+	for _, group := range groupByAuthor(events) {
+		grouped = append(grouped, BranchCommitsToEvent(group, compare))
+	}
+
+	return grouped
+}
+
+// batchCompareURL links the changes from the parent of the oldest commit to the
+// newest one. Commits are ordered from the newest to the oldest.
+func batchCompareURL(commits []domain.Event) string {
+	newest := commits[0]
+	oldest := commits[len(commits)-1]
+
+	repoURL, _, ok := strings.Cut(newest.URL, "/commit/")
+	parent := oldest.Attributes[attrParent]
+
+	if !ok || parent == "" {
+		return newest.URL
+	}
+
+	return fmt.Sprintf(compareURL, repoURL, shortSHA(parent), shortSHA(newest.ExternalID))
+}
+
+func groupByAuthor(commits []domain.Event) [][]domain.Event {
+	groups := make([][]domain.Event, 0)
+	indexes := make(map[string]int)
+
+	for _, commit := range commits {
+		idx, ok := indexes[commit.Author]
+		if !ok {
+			idx = len(groups)
+			indexes[commit.Author] = idx
+
+			groups = append(groups, make([]domain.Event, 0))
+		}
+
+		groups[idx] = append(groups[idx], commit)
+	}
+
+	return groups
+}
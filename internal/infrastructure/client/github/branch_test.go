@@ -0,0 +1,186 @@
+package github_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchGetUpdates(t *testing.T) {
+	t.Parallel()
+
+	commits := make([]string, 0)
+
+	for i := range 12 {
+		commits = append(commits, fmt.Sprintf(`{
+			"sha": "%07d000",
+			"html_url": "https://github.com/example/repo/commit/%07d000",
+			"author": {"login": "alice"},
+			"commit": {"message": "commit %d", "committer": {"date": "2025-04-01T11:%02d:00Z"}},
+			"parents": [{"sha": "%07d000"}]
+		}`, 20-i, 20-i, 20-i, 30-i, 19-i))
+	}
+
+	commits = append(commits, `{
+		"sha": "bbbbbbb000",
+		"html_url": "https://github.com/example/repo/commit/bbbbbbb000",
+		"author": {"login": "bob"},
+		"commit": {"message": "fix typo\n\nlong description", "committer": {"date": "2025-04-01T10:30:00Z"}},
+		"parents": [{"sha": "aaaaaaa000"}]
+	}`)
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/commits": "[" + strings.Join(commits, ",") + "]",
+		},
+	}
+
+	branch := github.NewBranch(github.New(&config.GitHub{PageSize: "100"}, client))
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	commitEvents, err := branch.GetUpdates(context.Background(), "https://github.com/example/repo/tree/feature/x", from, to)
+	require.NoError(t, err)
+	require.Len(t, commitEvents, 13, "commits should be grouped after delivered ones are dropped")
+
+	events := branch.GroupEvents(commitEvents)
+	require.Len(t, events, 2)
+
+	compare := "https://github.com/example/repo/compare/aaaaaaa...0000020"
+
+	assert.Equal(t, "alice", events[0].Author)
+	assert.Equal(t, "12", events[0].Attributes[domain.AttrCommits])
+	assert.Contains(t, events[0].Body, "... и ещё 2")
+	assert.Equal(t, compare, events[0].URL)
+	assert.Equal(t, "example/repo:feature/x", events[0].Title)
+	assert.Len(t, events[0].Parts, 12, "group should hold all commits")
+
+	assert.Equal(t, "bob", events[1].Author)
+	assert.Equal(t, "bbbbbbb fix typo", events[1].Body)
+
+	events, err = branch.GetUpdates(context.Background(), "https://github.com/example/repo", from, to)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestBranchGroupEvents_Undelivered(t *testing.T) {
+	t.Parallel()
+
+	branch := github.NewBranch(github.New(&config.GitHub{PageSize: "100"}, &fakeClient{}))
+
+	newCommit := func(sha, parent string) domain.Event {
+		return github.BranchCommitToEvent(&github.Commit{
+			SHA:     sha,
+			URL:     "https://github.com/example/repo/commit/" + sha,
+			Author:  github.User{Login: "alice"},
+			Commit:  github.CommitData{Message: "commit " + sha},
+			Parents: []github.Parent{{SHA: parent}},
+		}, "example/repo:main")
+	}
+
+	// the previous window had commits 1 and 2, only commit 3 is new
+	events := branch.GroupEvents([]domain.Event{newCommit("3333333aaa", "2222222aaa")})
+	require.Len(t, events, 1)
+
+	assert.Equal(t, "https://github.com/example/repo/compare/2222222...3333333", events[0].URL)
+	assert.Equal(t, "3333333 commit 3333333aaa", events[0].Body)
+	assert.Equal(t, []string{"3333333aaa"}, events[0].Parts)
+	assert.Equal(t, "1", events[0].Attributes[domain.AttrCommits])
+}
+
+func TestBranchGetCursorUpdates(t *testing.T) {
+	t.Parallel()
+
+	// the rebased commit is dated before the window but pushed after the head
+	// of the previous check
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/compare/aaaaaaa000...main": `{
+				"total_commits": 2,
+				"commits": [
+					{
+						"sha": "bbbbbbb000",
+						"html_url": "https://github.com/example/repo/commit/bbbbbbb000",
+						"author": {"login": "alice"},
+						"commit": {"message": "rebased", "committer": {"date": "2025-03-01T10:00:00Z"}},
+						"parents": [{"sha": "aaaaaaa000"}]
+					},
+					{
+						"sha": "ccccccc000",
+						"html_url": "https://github.com/example/repo/commit/ccccccc000",
+						"author": {"login": "alice"},
+						"commit": {"message": "new", "committer": {"date": "2025-04-01T11:00:00Z"}},
+						"parents": [{"sha": "bbbbbbb000"}]
+					}
+				]
+			}`,
+		},
+	}
+
+	branch := github.NewBranch(github.New(&config.GitHub{PageSize: "100"}, client))
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, cursor, err := branch.GetCursorUpdates(
+		context.Background(),
+		"https://github.com/example/repo/tree/main",
+		"aaaaaaa000",
+		from,
+		to,
+	)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "ccccccc000", cursor)
+	assert.Equal(t, "ccccccc000", events[0].ExternalID)
+	assert.Equal(t, "bbbbbbb000", events[1].ExternalID)
+}
+
+func TestBranchGetCursorUpdates_LostCursor(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/commits/main": `{"sha": "ccccccc000"}`,
+			"/repos/example/repo/commits": `[{
+				"sha": "ccccccc000",
+				"html_url": "https://github.com/example/repo/commit/ccccccc000",
+				"author": {"login": "alice"},
+				"commit": {"message": "new", "committer": {"date": "2025-04-01T11:00:00Z"}},
+				"parents": [{"sha": "bbbbbbb000"}]
+			}]`,
+		},
+		statuses: map[string]int{
+			"/repos/example/repo/compare/aaaaaaa000...main": http.StatusNotFound,
+		},
+	}
+
+	branch := github.NewBranch(github.New(&config.GitHub{PageSize: "100"}, client))
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, cursor, err := branch.GetCursorUpdates(
+		context.Background(),
+		"https://github.com/example/repo/tree/main",
+		"aaaaaaa000",
+		from,
+		to,
+	)
+	require.NoError(t, err, "a head lost to a force push should fall back to dates")
+	require.Len(t, events, 1)
+
+	assert.Equal(t, "ccccccc000", cursor)
+	assert.Equal(t, "ccccccc000", events[0].ExternalID)
+}
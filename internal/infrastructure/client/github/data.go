@@ -3,13 +3,18 @@ package github
 import "time"
 
 type Data struct {
+	ID        int64       `json:"id"`
 	Title     string      `json:"title"`
 	Body      string      `json:"body"`
 	Number    int         `json:"number"`
 	URL       string      `json:"html_url"`
 	User      User        `json:"user"`
 	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	ClosedAt  time.Time   `json:"closed_at"`
 	PR        PullRequest `json:"pull_request"`
+	Labels    []Label     `json:"labels"`
+	Reactions Reactions   `json:"reactions"`
 }
 
 type User struct {
@@ -19,3 +24,205 @@ type User struct {
 type PullRequest struct {
 	URL string `json:"url"`
 }
+
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	URL       string    `json:"html_url"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+	Reactions Reactions `json:"reactions"`
+}
+
+type Reactions struct {
+	PlusOne  int `json:"+1"`
+	MinusOne int `json:"-1"`
+}
+
+type Review struct {
+	ID          int64     `json:"id"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	URL         string    `json:"html_url"`
+	User        User      `json:"user"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+type Event struct {
+	ID        int64     `json:"id"`
+	Event     string    `json:"event"`
+	Actor     User      `json:"actor"`
+	Label     Label     `json:"label"`
+	CommitID  string    `json:"commit_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+type Commit struct {
+	SHA     string     `json:"sha"`
+	URL     string     `json:"html_url"`
+	Author  User       `json:"author"`
+	Commit  CommitData `json:"commit"`
+	Parents []Parent   `json:"parents"`
+}
+
+// Comparison lists commits reachable from the head but not from the base, the
+// oldest first.
+type Comparison struct {
+	TotalCommits int      `json:"total_commits"`
+	Commits      []Commit `json:"commits"`
+}
+
+type Parent struct {
+	SHA string `json:"sha"`
+}
+
+type CommitData struct {
+	Message   string       `json:"message"`
+	Author    CommitAuthor `json:"author"`
+	Committer CommitAuthor `json:"committer"`
+}
+
+type CommitAuthor struct {
+	Name string    `json:"name"`
+	Date time.Time `json:"date"`
+}
+
+type Release struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	TagName     string    `json:"tag_name"`
+	Body        string    `json:"body"`
+	URL         string    `json:"html_url"`
+	Prerelease  bool      `json:"prerelease"`
+	Author      User      `json:"author"`
+	Assets      []Asset   `json:"assets"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+type WorkflowRuns struct {
+	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+}
+
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	DisplayTitle string    `json:"display_title"`
+	HeadBranch   string    `json:"head_branch"`
+	HeadSHA      string    `json:"head_sha"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	URL          string    `json:"html_url"`
+	RunNumber    int       `json:"run_number"`
+	RunAttempt   int       `json:"run_attempt"`
+	Actor        User      `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type GraphQLRepository struct {
+	Issues       GraphQLItems    `json:"issues"`
+	PullRequests GraphQLItems    `json:"pullRequests"`
+	Releases     GraphQLReleases `json:"releases"`
+}
+
+type GraphQLItems struct {
+	Nodes    []GraphQLItem `json:"nodes"`
+	PageInfo PageInfo      `json:"pageInfo"`
+}
+
+type GraphQLItem struct {
+	DatabaseID int64         `json:"databaseId"`
+	Title      string        `json:"title"`
+	Body       string        `json:"body"`
+	Number     int           `json:"number"`
+	URL        string        `json:"url"`
+	Author     User          `json:"author"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	Labels     GraphQLLabels `json:"labels"`
+	ThumbsUp   TotalCount    `json:"thumbsUp"`
+	ThumbsDown TotalCount    `json:"thumbsDown"`
+}
+
+type GraphQLLabels struct {
+	Nodes []Label `json:"nodes"`
+}
+
+type TotalCount struct {
+	TotalCount int `json:"totalCount"`
+}
+
+type GraphQLReleases struct {
+	Nodes    []GraphQLRelease `json:"nodes"`
+	PageInfo PageInfo         `json:"pageInfo"`
+}
+
+type GraphQLRelease struct {
+	DatabaseID    int64         `json:"databaseId"`
+	Name          string        `json:"name"`
+	TagName       string        `json:"tagName"`
+	Description   string        `json:"description"`
+	URL           string        `json:"url"`
+	IsPrerelease  bool          `json:"isPrerelease"`
+	Author        User          `json:"author"`
+	ReleaseAssets GraphQLAssets `json:"releaseAssets"`
+	PublishedAt   time.Time     `json:"publishedAt"`
+}
+
+type GraphQLAssets struct {
+	Nodes []GraphQLAsset `json:"nodes"`
+}
+
+type GraphQLAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"downloadUrl"`
+}
+
+type PageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+type WebhookPayload struct {
+	Action      string            `json:"action"`
+	Repository  WebhookRepository `json:"repository"`
+	Sender      User              `json:"sender"`
+	Issue       *Data             `json:"issue"`
+	PullRequest *WebhookPull      `json:"pull_request"`
+	Comment     *Comment          `json:"comment"`
+	Release     *Release          `json:"release"`
+	Label       *Label            `json:"label"`
+	Ref         string            `json:"ref"`
+	Before      string            `json:"before"`
+	Commits     []WebhookCommit   `json:"commits"`
+}
+
+type WebhookRepository struct {
+	FullName string `json:"full_name"`
+}
+
+type WebhookPull struct {
+	Data
+	Merged   bool      `json:"merged"`
+	MergedAt time.Time `json:"merged_at"`
+}
+
+type WebhookCommit struct {
+	ID        string        `json:"id"`
+	Message   string        `json:"message"`
+	URL       string        `json:"url"`
+	Author    WebhookAuthor `json:"author"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+type WebhookAuthor struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
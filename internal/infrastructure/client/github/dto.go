@@ -2,30 +2,360 @@ package github
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 )
 
-func DataToMessage(data *Data) string {
-	builder := strings.Builder{}
+const maxBranchCommits = 10
+
+// attrParent holds the parent of a commit event until commits are grouped.
+const attrParent = "parent"
+
+var eventKinds = map[string]string{
+	"labeled":   domain.KindLabeled,
+	"unlabeled": domain.KindUnlabeled,
+	"closed":    domain.KindClosed,
+	"reopened":  domain.KindReopened,
+	"merged":    domain.KindMerged,
+}
+
+func DataToEvent(data *Data) domain.Event {
+	kind := domain.KindIssue
 	if data.PR.URL != "" {
-		builder.WriteString("<b>Новый Pull Request на Github!</b>\n")
-	} else {
-		builder.WriteString("<b>Новое Issue на Github!</b>\n")
+		kind = domain.KindPullRequest
+	}
+
+	event := newEvent(
+		kind,
+		strconv.FormatInt(data.ID, 10),
+		data.User.Login,
+		data.Title,
+		data.URL,
+		preview(data.Body),
+		data.CreatedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrScore: score(data.Reactions),
+	}
+
+	if len(data.Labels) != 0 {
+		names := make([]string, 0, len(data.Labels))
+		for _, label := range data.Labels {
+			names = append(names, label.Name)
+		}
+
+		event.Attributes[domain.AttrLabels] = strings.Join(names, ",")
+	}
+
+	return event
+}
+
+func CommentToEvent(comment *Comment, title string) domain.Event {
+	event := newEvent(
+		domain.KindComment,
+		strconv.FormatInt(comment.ID, 10),
+		comment.User.Login,
+		title,
+		comment.URL,
+		preview(comment.Body),
+		comment.CreatedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrScore: score(comment.Reactions),
+	}
+
+	return event
+}
+
+func ReviewCommentToEvent(comment *Comment, title string) domain.Event {
+	return newEvent(
+		domain.KindCodeComment,
+		strconv.FormatInt(comment.ID, 10),
+		comment.User.Login,
+		title,
+		comment.URL,
+		preview(comment.Body),
+		comment.CreatedAt,
+	)
+}
+
+func ReviewToEvent(review *Review, title string) domain.Event {
+	event := newEvent(
+		domain.KindReview,
+		strconv.FormatInt(review.ID, 10),
+		review.User.Login,
+		title,
+		review.URL,
+		preview(review.Body),
+		review.SubmittedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrStatus: strings.ToLower(review.State),
+	}
+
+	return event
+}
+
+// IssueEventToEvent returns false for events that are not reported to users.
+func IssueEventToEvent(event *Event, number int, title, url string) (domain.Event, bool) {
+	kind, ok := eventKinds[event.Event]
+	if !ok {
+		return domain.Event{}, false
 	}
 
-	builder.WriteString(
-		fmt.Sprintf("<b>Название</b>: <a href=%q>%s</a>\n", data.URL, data.Title),
+	res := newEvent(
+		kind,
+		issueEventID(event, number),
+		event.Actor.Login,
+		title,
+		url,
+		"",
+		event.CreatedAt,
 	)
-	builder.WriteString(fmt.Sprintf("<b>Автор</b>: <i>%s</i>\n", data.User.Login))
-	builder.WriteString(
-		fmt.Sprintf(
-			"<b>Время создания</b>: <i>%s</i>\n",
-			data.CreatedAt.Local().Format("15:04 02.01.2006"),
-		),
+
+	if event.Label.Name != "" {
+		res.Attributes = map[string]string{
+			domain.AttrLabel: event.Label.Name,
+		}
+	}
+
+	return res, true
+}
+
+// issueEventID is built from fields known to both the REST API and webhooks,
+// the webhook payload has no ID of the issue event. Labels are added by
+// separate events of the same second.
+func issueEventID(event *Event, number int) string {
+	id := fmt.Sprintf("%d/%s/%d", number, event.Event, event.CreatedAt.Unix())
+	if event.Label.Name != "" {
+		id += "/" + event.Label.Name
+	}
+
+	return id
+}
+
+func CommitToEvent(commit *Commit, title string) domain.Event {
+	event := newEvent(
+		domain.KindCommit,
+		commit.SHA,
+		commitAuthor(commit),
+		title,
+		commit.URL,
+		preview(firstLine(commit.Commit.Message)),
+		commit.Commit.Committer.Date,
 	)
-	builder.WriteString(fmt.Sprintf("<blockquote>%s</blockquote>\n", preview(data.Body)))
+	event.Attributes = map[string]string{
+		domain.AttrCommit: shortSHA(commit.SHA),
+	}
+
+	return event
+}
+
+func ReleaseToEvent(release *Release) domain.Event {
+	title := release.Name
+	if title == "" {
+		title = release.TagName
+	}
+
+	event := newEvent(
+		domain.KindRelease,
+		strconv.FormatInt(release.ID, 10),
+		release.Author.Login,
+		title,
+		release.URL,
+		preview(release.Body),
+		release.PublishedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrTag:        release.TagName,
+		domain.AttrPrerelease: strconv.FormatBool(release.Prerelease),
+	}
+
+	for _, asset := range release.Assets {
+		event.Attributes[domain.AttrFilePrefix+asset.Name] = asset.URL
+	}
+
+	return event
+}
+
+// GraphQLItemToData converts an issue or a pull request of the GraphQL API
+// to the REST one, so both APIs produce the same events.
+func GraphQLItemToData(item *GraphQLItem, isPull bool) Data {
+	data := Data{
+		ID:        item.DatabaseID,
+		Title:     item.Title,
+		Body:      item.Body,
+		Number:    item.Number,
+		URL:       item.URL,
+		User:      item.Author,
+		CreatedAt: item.CreatedAt,
+		Labels:    item.Labels.Nodes,
+		Reactions: Reactions{
+			PlusOne:  item.ThumbsUp.TotalCount,
+			MinusOne: item.ThumbsDown.TotalCount,
+		},
+	}
+
+	if isPull {
+		data.PR.URL = item.URL
+	}
+
+	return data
+}
+
+func GraphQLReleaseToRelease(release *GraphQLRelease) Release {
+	assets := make([]Asset, 0, len(release.ReleaseAssets.Nodes))
+	for _, asset := range release.ReleaseAssets.Nodes {
+		assets = append(assets, Asset{Name: asset.Name, URL: asset.DownloadURL})
+	}
+
+	return Release{
+		ID:          release.DatabaseID,
+		Name:        release.Name,
+		TagName:     release.TagName,
+		Body:        release.Description,
+		URL:         release.URL,
+		Prerelease:  release.IsPrerelease,
+		Author:      release.Author,
+		Assets:      assets,
+		PublishedAt: release.PublishedAt,
+	}
+}
+
+func WebhookCommitToCommit(commit *WebhookCommit) Commit {
+	return Commit{
+		SHA:    commit.ID,
+		URL:    commit.URL,
+		Author: User{Login: commit.Author.Username},
+		Commit: CommitData{
+			Message:   commit.Message,
+			Author:    CommitAuthor{Name: commit.Author.Name, Date: commit.Timestamp},
+			Committer: CommitAuthor{Name: commit.Author.Name, Date: commit.Timestamp},
+		},
+	}
+}
+
+// BranchCommitToEvent is a commit of a branch, commits are sent grouped by
+// BranchCommitsToEvent. The parent links the compare range of the group.
+func BranchCommitToEvent(commit *Commit, title string) domain.Event {
+	event := newEvent(
+		domain.KindCommits,
+		commit.SHA,
+		commitAuthor(commit),
+		title,
+		commit.URL,
+		fmt.Sprintf("%s %s", shortSHA(commit.SHA), preview(firstLine(commit.Commit.Message))),
+		commit.Commit.Committer.Date,
+	)
+
+	if len(commit.Parents) > 0 {
+		event.Attributes = map[string]string{
+			attrParent: commit.Parents[0].SHA,
+		}
+	}
+
+	return event
+}
+
+// BranchCommitsToEvent groups commits of one author into a single event.
+// Only the first maxBranchCommits commits are listed.
+func BranchCommitsToEvent(commits []domain.Event, compareURL string) domain.Event {
+	lines := make([]string, 0, maxBranchCommits+1)
+	parts := make([]string, 0, len(commits))
+
+	for i, commit := range commits {
+		parts = append(parts, commit.ExternalID)
+
+		if i == maxBranchCommits {
+			lines = append(lines, fmt.Sprintf("... и ещё %d", len(commits)-maxBranchCommits))
+
+			continue
+		}
+
+		if i < maxBranchCommits {
+			lines = append(lines, commit.Body)
+		}
+	}
+
+	event := newEvent(
+		domain.KindCommits,
+		commits[0].ExternalID,
+		commits[0].Author,
+		commits[0].Title,
+		compareURL,
+		strings.Join(lines, "\n"),
+		commits[0].CreatedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrCommits: strconv.Itoa(len(commits)),
+	}
+	event.Parts = parts
+
+	return event
+}
+
+// WorkflowRunToEvent keys the run by the attempt: a re-run keeps the ID of the
+// run, so its result is a new event.
+func WorkflowRunToEvent(run *WorkflowRun) domain.Event {
+	event := newEvent(
+		domain.KindWorkflowRun,
+		fmt.Sprintf("%d/%d", run.ID, run.RunAttempt),
+		run.Actor.Login,
+		fmt.Sprintf("%s #%d", run.Name, run.RunNumber),
+		run.URL,
+		preview(run.DisplayTitle),
+		run.UpdatedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrStatus: run.Conclusion,
+		domain.AttrBranch: run.HeadBranch,
+		domain.AttrCommit: shortSHA(run.HeadSHA),
+	}
+
+	return event
+}
+
+func newEvent(kind, id, author, title, url, body string, createdAt time.Time) domain.Event {
+	return domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       kind,
+		ExternalID: id,
+		Author:     author,
+		Title:      title,
+		URL:        url,
+		Body:       body,
+		CreatedAt:  createdAt,
+	}
+}
+
+// score is the difference of thumbs up and thumbs down reactions.
+func score(reactions Reactions) string {
+	return strconv.Itoa(reactions.PlusOne - reactions.MinusOne)
+}
+
+func commitAuthor(commit *Commit) string {
+	if commit.Author.Login != "" {
+		return commit.Author.Login
+	}
+
+	return commit.Commit.Author.Name
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
 
-	return builder.String()
+	return strings.TrimSpace(line)
 }
 
 func preview(text string) string {
@@ -6,12 +6,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestDataToMessage_PR(t *testing.T) {
+func TestDataToEvent_PR(t *testing.T) {
 	data := github.Data{
+		ID: 10,
 		PR: github.PullRequest{
 			URL: "https://github.com/example/repo/pull/1",
 		},
@@ -20,20 +22,32 @@ func TestDataToMessage_PR(t *testing.T) {
 		User:      github.User{Login: "devUser"},
 		CreatedAt: time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
 		Body:      "This is the body of the pull request. It contains details about the changes.",
+		Labels:    []github.Label{{Name: "enhancement"}, {Name: "api"}},
+		Reactions: github.Reactions{PlusOne: 5, MinusOne: 1},
 	}
 
-	msg := github.DataToMessage(&data)
+	event := github.DataToEvent(&data)
 
-	exp := "<b>Новый Pull Request на Github!</b>\n" +
-		"<b>Название</b>: <a href=\"https://github.com/example/repo/pull/1\">Add new feature</a>\n" +
-		"<b>Автор</b>: <i>devUser</i>\n" +
-		"<b>Время создания</b>: <i>15:30 30.03.2025</i>\n" +
-		"<blockquote>This is the body of the pull request. It contains details about the changes.</blockquote>\n"
-	assert.Equal(t, exp, msg, "invalid message")
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindPullRequest,
+		ExternalID: "10",
+		Author:     "devUser",
+		Title:      "Add new feature",
+		URL:        "https://github.com/example/repo/pull/1",
+		Body:       "This is the body of the pull request. It contains details about the changes.",
+		CreatedAt:  time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrScore:  "4",
+			domain.AttrLabels: "enhancement,api",
+		},
+	}
+	assert.Equal(t, exp, event, "invalid event")
 }
 
-func TestDataToMessage_Issue(t *testing.T) {
+func TestDataToEvent_Issue(t *testing.T) {
 	data := github.Data{
+		ID:        42,
 		PR:        github.PullRequest{URL: ""},
 		URL:       "https://github.com/example/repo/issues/42",
 		Title:     "Bug in feature",
@@ -42,12 +56,166 @@ func TestDataToMessage_Issue(t *testing.T) {
 		Body:      strings.Repeat("a", 250),
 	}
 
-	msg := github.DataToMessage(&data)
+	event := github.DataToEvent(&data)
+
+	assert.Equal(t, domain.KindIssue, event.Kind, "invalid kind")
+	assert.Equal(t, strings.Repeat("a", 200)+"...", event.Body, "body must be shortened")
+}
+
+func TestReviewToEvent(t *testing.T) {
+	review := github.Review{
+		ID:          1,
+		URL:         "https://github.com/example/repo/pull/1#pullrequestreview-1",
+		State:       "CHANGES_REQUESTED",
+		User:        github.User{Login: "reviewer"},
+		SubmittedAt: time.Date(2025, 4, 1, 12, 0, 0, 0, time.Local),
+	}
+
+	event := github.ReviewToEvent(&review, "Add new feature")
+
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindReview,
+		ExternalID: "1",
+		Author:     "reviewer",
+		Title:      "Add new feature",
+		URL:        "https://github.com/example/repo/pull/1#pullrequestreview-1",
+		CreatedAt:  time.Date(2025, 4, 1, 12, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrStatus: "changes_requested"},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
+
+func TestIssueEventToEvent(t *testing.T) {
+	event := github.Event{
+		ID:        3,
+		Event:     "labeled",
+		Actor:     github.User{Login: "maintainer"},
+		Label:     github.Label{Name: "bug"},
+		CreatedAt: time.Date(2025, 4, 1, 13, 0, 0, 0, time.Local),
+	}
+
+	res, ok := github.IssueEventToEvent(&event, 42, "Bug in feature", "https://github.com/example/repo/issues/42")
+	assert.True(t, ok, "event must be reported")
+
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindLabeled,
+		ExternalID: fmt.Sprintf("42/labeled/%d/bug", event.CreatedAt.Unix()),
+		Author:     "maintainer",
+		Title:      "Bug in feature",
+		URL:        "https://github.com/example/repo/issues/42",
+		CreatedAt:  time.Date(2025, 4, 1, 13, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrLabel: "bug"},
+	}
+	assert.Equal(t, exp, res, "invalid event")
+
+	event.Event = "subscribed"
+
+	_, ok = github.IssueEventToEvent(&event, 42, "Bug in feature", "https://github.com/example/repo/issues/42")
+	assert.False(t, ok, "event must be skipped")
+}
+
+func TestCommitToEvent(t *testing.T) {
+	commit := github.Commit{
+		SHA: "0123456789abcdef",
+		URL: "https://github.com/example/repo/commit/0123456789abcdef",
+		Commit: github.CommitData{
+			Message: "Fix nil pointer\n\nDetailed description",
+			Author:  github.CommitAuthor{Name: "Dev User"},
+			Committer: github.CommitAuthor{
+				Name: "Dev User",
+				Date: time.Date(2025, 4, 1, 14, 0, 0, 0, time.Local),
+			},
+		},
+	}
+
+	event := github.CommitToEvent(&commit, "Add new feature")
+
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindCommit,
+		ExternalID: "0123456789abcdef",
+		Author:     "Dev User",
+		Title:      "Add new feature",
+		URL:        "https://github.com/example/repo/commit/0123456789abcdef",
+		Body:       "Fix nil pointer",
+		CreatedAt:  time.Date(2025, 4, 1, 14, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrCommit: "0123456"},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
+
+func TestReleaseToEvent(t *testing.T) {
+	release := github.Release{
+		ID:         5,
+		TagName:    "v1.2.0-rc.1",
+		URL:        "https://github.com/example/repo/releases/tag/v1.2.0-rc.1",
+		Prerelease: true,
+		Author:     github.User{Login: "maintainer"},
+		Assets: []github.Asset{
+			{Name: "repo_linux_amd64.tar.gz", URL: "https://github.com/example/repo/releases/download/v1.2.0-rc.1/repo_linux_amd64.tar.gz"},
+		},
+		PublishedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local),
+		Body:        "Bug fixes",
+	}
+
+	event := github.ReleaseToEvent(&release)
+
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindRelease,
+		ExternalID: "5",
+		Author:     "maintainer",
+		Title:      "v1.2.0-rc.1",
+		URL:        "https://github.com/example/repo/releases/tag/v1.2.0-rc.1",
+		Body:       "Bug fixes",
+		CreatedAt:  time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrTag:        "v1.2.0-rc.1",
+			domain.AttrPrerelease: "true",
+			domain.AttrFilePrefix + "repo_linux_amd64.tar.gz": "https://github.com/example/repo/releases/download/" +
+				"v1.2.0-rc.1/repo_linux_amd64.tar.gz",
+		},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
+
+func TestWorkflowRunToEvent(t *testing.T) {
+	run := github.WorkflowRun{
+		ID:           7,
+		Name:         "CI",
+		DisplayTitle: "Fix flaky test",
+		HeadBranch:   "main",
+		HeadSHA:      "0123456789abcdef",
+		Conclusion:   "failure",
+		URL:          "https://github.com/example/repo/actions/runs/1",
+		RunNumber:    42,
+		RunAttempt:   1,
+		Actor:        github.User{Login: "devUser"},
+		UpdatedAt:    time.Date(2025, 4, 1, 15, 0, 0, 0, time.Local),
+	}
+
+	event := github.WorkflowRunToEvent(&run)
+
+	exp := domain.Event{
+		Source:     domain.SourceGitHub,
+		Kind:       domain.KindWorkflowRun,
+		ExternalID: "7/1",
+		Author:     "devUser",
+		Title:      "CI #42",
+		URL:        "https://github.com/example/repo/actions/runs/1",
+		Body:       "Fix flaky test",
+		CreatedAt:  time.Date(2025, 4, 1, 15, 0, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrStatus: "failure",
+			domain.AttrBranch: "main",
+			domain.AttrCommit: "0123456",
+		},
+	}
+	assert.Equal(t, exp, event, "invalid event")
 
-	exp := "<b>Новое Issue на Github!</b>\n" +
-		"<b>Название</b>: <a href=\"https://github.com/example/repo/issues/42\">Bug in feature</a>\n" +
-		"<b>Автор</b>: <i>bugHunter</i>\n" +
-		"<b>Время создания</b>: <i>10:00 01.04.2025</i>\n" +
-		fmt.Sprintf("<blockquote>%s</blockquote>\n", strings.Repeat("a", 200)+"...")
-	assert.Equal(t, exp, msg, "invalid message")
+	run.RunAttempt = 2
+	rerun := github.WorkflowRunToEvent(&run)
+	assert.Equal(t, "7/2", rerun.ExternalID, "re-run should be a new event")
 }
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"fmt"
+	"time"
+)
+
+type ErrUnexpectedStatus struct {
+	URL    string
+	Status int
+}
+
+func NewErrUnexpectedStatus(url string, status int) error {
+	return ErrUnexpectedStatus{
+		URL:    url,
+		Status: status,
+	}
+}
+
+func (e ErrUnexpectedStatus) Error() string {
+	return fmt.Sprintf("unexpected status %d for url=%q", e.Status, e.URL)
+}
+
+type ErrRateLimited struct {
+	URL   string
+	Reset time.Time
+}
+
+func NewErrRateLimited(url string, reset time.Time) error {
+	return ErrRateLimited{
+		URL:   url,
+		Reset: reset,
+	}
+}
+
+func (e ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limit exceeded for url=%q until %s", e.URL, e.Reset.Format(time.RFC3339))
+}
+
+type ErrGraphQL struct {
+	Message string
+}
+
+func NewErrGraphQL(message string) error {
+	return ErrGraphQL{
+		Message: message,
+	}
+}
+
+func (e ErrGraphQL) Error() string {
+	return fmt.Sprintf("graphql error: %s", e.Message)
+}
+
+type ErrRepositoryNotFound struct {
+	Name string
+}
+
+func NewErrRepositoryNotFound(name string) error {
+	return ErrRepositoryNotFound{
+		Name: name,
+	}
+}
+
+func (e ErrRepositoryNotFound) Error() string {
+	return fmt.Sprintf("repository %q not found", e.Name)
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -11,16 +12,22 @@ import (
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 )
 
 const (
 	// repoActivityURL = "https://api.github.com/repos/%s/%s/activity"
-	repoIssuesURL = "https://api.github.com/repos/%s/%s/issues"
+	repoIssuesURL   = "https://api.github.com/repos/%s/%s/issues"
+	repoReleasesURL = "https://api.github.com/repos/%s/%s/releases"
 	// repoPullsURL  = "https://api.github.com/repos/%s/%s/pulls"
 	// repoBranchesURL = "https://api.github.com/repos/%s/%s/branches"
 
-	// issueURL = "https://api.github.com/repos/%s/%s/issues/%s"
-	// pullURL  = "https://api.github.com/repos/%s/%s/pulls/%s"
+	issueURL              = "https://api.github.com/repos/%s/%s/issues/%s"
+	issueCommentsURL      = "https://api.github.com/repos/%s/%s/issues/%s/comments"
+	issueEventsURL        = "https://api.github.com/repos/%s/%s/issues/%s/events"
+	pullReviewsURL        = "https://api.github.com/repos/%s/%s/pulls/%s/reviews"
+	pullReviewCommentsURL = "https://api.github.com/repos/%s/%s/pulls/%s/comments"
+	pullCommitsURL        = "https://api.github.com/repos/%s/%s/pulls/%s/commits"
 )
 
 type Client interface {
@@ -28,22 +35,48 @@ type Client interface {
 }
 
 type GitHub struct {
-	client    Client
-	repoRegex *regexp.Regexp
-	// issueRegex *regexp.Regexp
-	// pullRegex  *regexp.Regexp
-	token    string
-	pageSize string
+	client        Client
+	repoRegex     *regexp.Regexp
+	issuesRegex   *regexp.Regexp
+	releasesRegex *regexp.Regexp
+	issueRegex    *regexp.Regexp
+	pullRegex     *regexp.Regexp
+	token         string
+	pageSize      string
+	rateLimit     *rateLimit
+}
+
+// etags holds ETags of first pages of lists by the list URL. ETags of the
+// previous check are sent, ETags of this check are collected for the next one.
+type etags struct {
+	prev map[string]string
+	next map[string]string
+}
+
+func newETags(prev map[string]string) *etags {
+	return &etags{
+		prev: prev,
+		next: make(map[string]string),
+	}
+}
+
+// drop forgets the ETag of the list: an item newer than the checked window is
+// skipped and must be found on the next check.
+func (t *etags) drop(link string) {
+	delete(t.next, link)
 }
 
 func New(cfg *config.GitHub, client Client) *GitHub {
 	return &GitHub{
-		client:    client,
-		repoRegex: regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)$`),
-		// issueRegex: regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)$`),
-		// pullRegex:  regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/pull/(\d+)$`),
-		token:    cfg.Token,
-		pageSize: cfg.PageSize,
+		client:        client,
+		repoRegex:     regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)$`),
+		issuesRegex:   regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/issues$`),
+		releasesRegex: regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/releases$`),
+		issueRegex:    regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)$`),
+		pullRegex:     regexp.MustCompile(`^https://github\.com/([\w.-]+)/([\w.-]+)/pull/(\d+)$`),
+		token:         cfg.Token,
+		pageSize:      cfg.PageSize,
+		rateLimit:     newRateLimit("github"),
 	}
 }
 
@@ -51,76 +84,389 @@ func (g *GitHub) GetType() string {
 	return "github"
 }
 
-func (g *GitHub) GetUpdates(link string, from, to time.Time) ([]string, error) {
-	if !g.repoRegex.MatchString(link) {
-		return []string{}, nil
-	}
+// RateLimits returns the quota reported by the last response.
+func (g *GitHub) RateLimits() []domain.RateLimit {
+	return g.rateLimit.limits()
+}
+
+// GetUpdates reports issues, pull requests and releases for repository links.
+// Links ending with /issues or /releases narrow updates to one kind.
+func (g *GitHub) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	return g.getUpdates(ctx, link, newETags(nil), from, to)
+}
 
-	matches := g.repoRegex.FindStringSubmatch(link)
-	baseURL := fmt.Sprintf(repoIssuesURL, matches[1], matches[2])
+// GetConditionalUpdates sends ETags of the previous check with first pages of
+// issues and releases. Lists not modified since then have no updates. It
+// returns ETags of this check.
+func (g *GitHub) GetConditionalUpdates(
+	ctx context.Context,
+	link string,
+	prev map[string]string,
+	from, to time.Time,
+) ([]domain.Event, map[string]string, error) {
+	tags := newETags(prev)
 
-	msgs, err := g.getMessages(baseURL, from, to)
+	events, err := g.getUpdates(ctx, link, tags, from, to)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return msgs, nil
+	return events, tags.next, nil
+}
+
+func (g *GitHub) getUpdates(ctx context.Context, link string, tags *etags, from, to time.Time) ([]domain.Event, error) {
+	switch {
+	case g.repoRegex.MatchString(link):
+		matches := g.repoRegex.FindStringSubmatch(link)
+
+		events, err := g.getEvents(ctx, fmt.Sprintf(repoIssuesURL, matches[1], matches[2]), tags, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		releaseEvents, err := g.getReleaseEvents(
+			ctx,
+			fmt.Sprintf(repoReleasesURL, matches[1], matches[2]),
+			tags,
+			from,
+			to,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(events, releaseEvents...), nil
+
+	case g.issuesRegex.MatchString(link):
+		matches := g.issuesRegex.FindStringSubmatch(link)
+
+		return g.getEvents(ctx, fmt.Sprintf(repoIssuesURL, matches[1], matches[2]), tags, from, to)
+
+	case g.releasesRegex.MatchString(link):
+		matches := g.releasesRegex.FindStringSubmatch(link)
+
+		return g.getReleaseEvents(ctx, fmt.Sprintf(repoReleasesURL, matches[1], matches[2]), tags, from, to)
+
+	case g.issueRegex.MatchString(link):
+		matches := g.issueRegex.FindStringSubmatch(link)
+
+		return g.getIssueEvents(ctx, matches[1], matches[2], matches[3], false, from, to)
+
+	case g.pullRegex.MatchString(link):
+		matches := g.pullRegex.FindStringSubmatch(link)
+
+		return g.getIssueEvents(ctx, matches[1], matches[2], matches[3], true, from, to)
+
+	default:
+		return []domain.Event{}, nil
+	}
 }
 
-func (g *GitHub) getMessages(baseURL string, from, to time.Time) ([]string, error) {
-	msgs := make([]string, 0)
+func (g *GitHub) getEvents(
+	ctx context.Context,
+	baseURL string,
+	tags *etags,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	events := make([]domain.Event, 0)
+
+	params := url.Values{}
+	params.Add("sort", "created")
+	params.Add("direction", "desc")
 
 	page := 1
 
 	for {
 		data := make([]Data, 0)
 
-		err := g.getAndDecodeResponse(baseURL, page, &data)
+		modified, err := g.getListPage(ctx, baseURL, params, page, tags, &data)
 		if err != nil {
 			return nil, err
 		}
 
+		if !modified {
+			return events, nil
+		}
+
+		if page == 1 && len(data) > 0 && to.Before(data[0].CreatedAt) {
+			tags.drop(baseURL)
+		}
+
 		if len(data) == 0 || from.After(data[0].CreatedAt) {
-			return msgs, nil
+			return events, nil
 		}
 
 		for _, d := range data {
 			if from.After(d.CreatedAt) {
-				return msgs, nil
+				return events, nil
 			}
 
 			if to.Before(d.CreatedAt) {
 				continue
 			}
 
-			msgs = append(msgs, DataToMessage(&d))
+			events = append(events, DataToEvent(&d))
 		}
 
 		page++
 	}
 }
 
-func (g *GitHub) getAndDecodeResponse(link string, page int, data any) error {
-	params := url.Values{}
-	params.Add("sort", "created")
-	params.Add("direction", "desc")
-	params.Add("per_page", g.pageSize)
-	params.Add("page", strconv.Itoa(page))
+// getReleaseEvents walks releases from the newest one. Releases are ordered
+// by creation time, so pages are read until the oldest release is out of range.
+func (g *GitHub) getReleaseEvents(
+	ctx context.Context,
+	baseURL string,
+	tags *etags,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	events := make([]domain.Event, 0)
+
+	for page := 1; ; page++ {
+		data := make([]Release, 0)
+
+		modified, err := g.getListPage(ctx, baseURL, nil, page, tags, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		if !modified || len(data) == 0 {
+			return events, nil
+		}
+
+		if page == 1 && to.Before(data[0].PublishedAt) {
+			tags.drop(baseURL)
+		}
+
+		for _, release := range data {
+			if inRange(release.PublishedAt, from, to) {
+				events = append(events, ReleaseToEvent(&release))
+			}
+		}
+
+		// drafts have no publication time and must not stop the walk
+		last := data[len(data)-1].PublishedAt
+		if !last.IsZero() && from.After(last) {
+			return events, nil
+		}
+	}
+}
 
-	reqURL := fmt.Sprintf("%s?%s", link, params.Encode())
+// getIssueEvents collects updates of a single issue or pull request.
+// Pull requests additionally report review comments, reviews and commits.
+func (g *GitHub) getIssueEvents(
+	ctx context.Context,
+	owner, repo, number string,
+	isPull bool,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	var issue Data
 
-	req, err := http.NewRequest(http.MethodGet, reqURL, http.NoBody)
+	err := g.getAndDecodeResponse(ctx, fmt.Sprintf(issueURL, owner, repo, number), nil, &issue)
 	if err != nil {
-		return fmt.Errorf("failed to create request with url=%q: %w", reqURL, err)
+		return nil, err
+	}
+
+	since := url.Values{}
+	since.Add("since", from.UTC().Format(time.RFC3339))
+
+	comments, err := getAll[Comment](ctx, g, fmt.Sprintf(issueCommentsURL, owner, repo, number), since)
+	if err != nil {
+		return nil, err
+	}
+
+	issueEvents, err := getAll[Event](ctx, g, fmt.Sprintf(issueEventsURL, owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0)
+
+	for _, comment := range comments {
+		if inRange(comment.CreatedAt, from, to) {
+			events = append(events, CommentToEvent(&comment, issue.Title))
+		}
+	}
+
+	for _, event := range issueEvents {
+		if !inRange(event.CreatedAt, from, to) {
+			continue
+		}
+
+		if res, ok := IssueEventToEvent(&event, issue.Number, issue.Title, issue.URL); ok {
+			events = append(events, res)
+		}
+	}
+
+	if !isPull {
+		return events, nil
+	}
+
+	pullEvents, err := g.getPullEvents(ctx, owner, repo, number, issue.Title, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(events, pullEvents...), nil
+}
+
+func (g *GitHub) getPullEvents(
+	ctx context.Context,
+	owner, repo, number, title string,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	since := url.Values{}
+	since.Add("since", from.UTC().Format(time.RFC3339))
+
+	reviewComments, err := getAll[Comment](
+		ctx,
+		g,
+		fmt.Sprintf(pullReviewCommentsURL, owner, repo, number),
+		since,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := getAll[Review](ctx, g, fmt.Sprintf(pullReviewsURL, owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := getAll[Commit](ctx, g, fmt.Sprintf(pullCommitsURL, owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0)
+
+	for _, comment := range reviewComments {
+		if inRange(comment.CreatedAt, from, to) {
+			events = append(events, ReviewCommentToEvent(&comment, title))
+		}
+	}
+
+	for _, review := range reviews {
+		if inRange(review.SubmittedAt, from, to) {
+			events = append(events, ReviewToEvent(&review, title))
+		}
+	}
+
+	for _, commit := range commits {
+		if inRange(commit.Commit.Committer.Date, from, to) {
+			events = append(events, CommitToEvent(&commit, title))
+		}
+	}
+
+	return events, nil
+}
+
+// getAll walks through all pages of the list endpoint.
+func getAll[T any](ctx context.Context, g *GitHub, link string, params url.Values) ([]T, error) {
+	items := make([]T, 0)
+
+	for page := 1; ; page++ {
+		data := make([]T, 0)
+
+		if err := g.getAndDecodePage(ctx, link, params, page, &data); err != nil {
+			return nil, err
+		}
+
+		if len(data) == 0 {
+			return items, nil
+		}
+
+		items = append(items, data...)
+	}
+}
+
+// getListPage sends the ETag of the previous check with the first page of the
+// list. It returns false if the list is not modified since then. Lists are
+// ordered from the newest item, so new items are always on the first page.
+func (g *GitHub) getListPage(
+	ctx context.Context,
+	link string,
+	params url.Values,
+	page int,
+	tags *etags,
+	data any,
+) (bool, error) {
+	if page != 1 {
+		return true, g.getAndDecodePage(ctx, link, params, page, data)
+	}
+
+	etag, modified, err := g.getAndDecodeConditional(ctx, link, g.pageParams(params, page), tags.prev[link], data)
+	if err != nil {
+		return false, err
+	}
+
+	if etag != "" {
+		tags.next[link] = etag
+	}
+
+	return modified, nil
+}
+
+func (g *GitHub) getAndDecodePage(
+	ctx context.Context,
+	link string,
+	params url.Values,
+	page int,
+	data any,
+) error {
+	return g.getAndDecodeResponse(ctx, link, g.pageParams(params, page), data)
+}
+
+func (g *GitHub) pageParams(params url.Values, page int) url.Values {
+	pageParams := url.Values{}
+
+	for key, values := range params {
+		pageParams[key] = values
+	}
+
+	pageParams.Set("per_page", g.pageSize)
+	pageParams.Set("page", strconv.Itoa(page))
+
+	return pageParams
+}
+
+func (g *GitHub) getAndDecodeResponse(ctx context.Context, link string, params url.Values, data any) error {
+	_, _, err := g.getAndDecodeConditional(ctx, link, params, "", data)
+
+	return err
+}
+
+// getAndDecodeConditional sends the ETag of the previous response if it is not
+// empty. It returns the ETag of the response and false if the resource is not
+// modified, data is not decoded then.
+func (g *GitHub) getAndDecodeConditional(
+	ctx context.Context,
+	link string,
+	params url.Values,
+	etag string,
+	data any,
+) (string, bool, error) {
+	reqURL := link
+	if len(params) != 0 {
+		reqURL = fmt.Sprintf("%s?%s", link, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create request with url=%q: %w", reqURL, err)
 	}
 
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("Authorization", g.token)
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to get response with url=%q: %w", reqURL, err)
+		return "", false, fmt.Errorf("failed to get response with url=%q: %w", reqURL, err)
 	}
 
 	defer func() {
@@ -128,18 +474,36 @@ func (g *GitHub) getAndDecodeResponse(link string, page int, data any) error {
 			slog.Error(
 				"failed to close response body",
 				slog.Any("error", err),
-				slog.Any("service", "stackoverflow client"),
-				slog.Any("method", "hasSourceUpdates"),
+				slog.Any("service", "github client"),
 				slog.Any("url", reqURL),
 			)
 		}
 	}()
 
+	g.rateLimit.update(resp.Header)
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return etag, false, nil
+
+	case isRateLimited(resp):
+		limit, _ := g.rateLimit.get()
+
+		return "", false, NewErrRateLimited(reqURL, limit.Reset)
+
+	case resp.StatusCode != http.StatusOK:
+		return "", false, NewErrUnexpectedStatus(reqURL, resp.StatusCode)
+	}
+
 	dec := json.NewDecoder(resp.Body)
 
 	if err := dec.Decode(data); err != nil {
-		return fmt.Errorf("failed to decode response with url=%q: %w", reqURL, err)
+		return "", false, fmt.Errorf("failed to decode response with url=%q: %w", reqURL, err)
 	}
 
-	return nil
+	return resp.Header.Get("ETag"), true, nil
+}
+
+func inRange(tm, from, to time.Time) bool {
+	return !from.After(tm) && !to.Before(tm)
 }
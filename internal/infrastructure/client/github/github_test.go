@@ -0,0 +1,278 @@
+package github_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	responses map[string]string
+	statuses  map[string]int
+	empty     string
+}
+
+func (c *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	body := "[]"
+	if c.empty != "" {
+		body = c.empty
+	}
+
+	if req.URL.Query().Get("page") == "" || req.URL.Query().Get("page") == "1" {
+		if resp, ok := c.responses[req.URL.Path]; ok {
+			body = resp
+		}
+	}
+
+	status := http.StatusOK
+	if code, ok := c.statuses[req.URL.Path]; ok {
+		status = code
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestGetUpdates_Pull(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/issues/1": `{
+				"title": "Add new feature",
+				"html_url": "https://github.com/example/repo/pull/1"
+			}`,
+			"/repos/example/repo/issues/1/comments": `[
+				{"body": "old", "user": {"login": "a"}, "created_at": "2025-04-01T09:00:00Z"},
+				{"body": "new", "user": {"login": "b"}, "created_at": "2025-04-01T11:00:00Z"}
+			]`,
+			"/repos/example/repo/issues/1/events": `[
+				{"event": "subscribed", "actor": {"login": "c"}, "created_at": "2025-04-01T11:00:00Z"},
+				{"event": "merged", "actor": {"login": "c"}, "created_at": "2025-04-01T11:30:00Z"}
+			]`,
+			"/repos/example/repo/pulls/1/comments": `[
+				{"body": "nit", "user": {"login": "d"}, "created_at": "2025-04-01T10:30:00Z"}
+			]`,
+			"/repos/example/repo/pulls/1/reviews": `[
+				{"state": "APPROVED", "user": {"login": "d"}, "submitted_at": "2025-04-01T10:45:00Z"}
+			]`,
+			"/repos/example/repo/pulls/1/commits": `[
+				{"sha": "abcdef0123", "commit": {"message": "fix", "committer": {"date": "2025-04-01T10:15:00Z"}}}
+			]`,
+		},
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gh.GetUpdates(context.Background(), "https://github.com/example/repo/pull/1", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+
+	assert.Equal(t, domain.KindComment, events[0].Kind)
+	assert.Equal(t, domain.KindMerged, events[1].Kind)
+	assert.Equal(t, domain.KindCodeComment, events[2].Kind)
+	assert.Equal(t, domain.KindReview, events[3].Kind)
+	assert.Equal(t, domain.KindCommit, events[4].Kind)
+}
+
+func TestGetUpdates_Issue(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/issues/42": `{
+				"title": "Bug in feature",
+				"html_url": "https://github.com/example/repo/issues/42"
+			}`,
+			"/repos/example/repo/issues/42/events": `[
+				{"event": "closed", "actor": {"login": "c"}, "created_at": "2025-04-01T11:00:00Z"}
+			]`,
+			"/repos/example/repo/pulls/42/reviews": `[
+				{"state": "APPROVED", "user": {"login": "d"}, "submitted_at": "2025-04-01T10:45:00Z"}
+			]`,
+		},
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gh.GetUpdates(context.Background(), "https://github.com/example/repo/issues/42", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	assert.Equal(t, domain.KindClosed, events[0].Kind)
+	assert.Equal(t, "c", events[0].Author)
+}
+
+func TestGetUpdates_Releases(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/issues": `[
+				{"title": "Bug", "user": {"login": "a"}, "created_at": "2025-04-01T11:00:00Z"}
+			]`,
+			"/repos/example/repo/releases": `[
+				{"tag_name": "v1.1.0", "author": {"login": "m"}, "published_at": "2025-04-01T11:00:00Z"},
+				{"tag_name": "v1.0.0", "author": {"login": "m"}, "published_at": "2025-03-01T11:00:00Z"}
+			]`,
+		},
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gh.GetUpdates(context.Background(), "https://github.com/example/repo/releases", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "v1.1.0", events[0].Attributes[domain.AttrTag])
+
+	events, err = gh.GetUpdates(context.Background(), "https://github.com/example/repo/issues", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.KindIssue, events[0].Kind)
+
+	events, err = gh.GetUpdates(context.Background(), "https://github.com/example/repo", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+}
+
+// conditionalClient answers 304 to requests with the known ETag.
+type conditionalClient struct {
+	etag string
+	body string
+}
+
+func (c *conditionalClient) Do(req *http.Request) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("ETag", c.etag)
+	header.Set("X-RateLimit-Remaining", "4999")
+	header.Set("X-RateLimit-Reset", "1760000000")
+
+	if req.Header.Get("If-None-Match") == c.etag {
+		return &http.Response{
+			StatusCode: http.StatusNotModified,
+			Header:     header,
+			Body:       http.NoBody,
+		}, nil
+	}
+
+	body := "[]"
+	if req.URL.Query().Get("page") == "1" {
+		body = c.body
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestGetConditionalUpdates(t *testing.T) {
+	t.Parallel()
+
+	client := &conditionalClient{
+		etag: `W/"abc"`,
+		body: `[{"title": "Bug", "html_url": "https://github.com/example/repo/issues/1",
+			"user": {"login": "a"}, "created_at": "2025-04-01T11:00:00Z"}]`,
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	link := "https://github.com/example/repo/issues"
+
+	events, etags, err := gh.GetConditionalUpdates(context.Background(), link, nil, from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]string{"https://api.github.com/repos/example/repo/issues": `W/"abc"`}, etags)
+
+	events, next, err := gh.GetConditionalUpdates(context.Background(), link, etags, from, to)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, etags, next)
+
+	limits := gh.RateLimits()
+	require.Len(t, limits, 1)
+	assert.Equal(t, 4999, limits[0].Remaining)
+	assert.Equal(t, time.Unix(1760000000, 0), limits[0].Reset)
+}
+
+func TestGetConditionalUpdates_KeepsNewerItems(t *testing.T) {
+	t.Parallel()
+
+	client := &conditionalClient{
+		etag: `W/"abc"`,
+		body: `[{"title": "Bug", "html_url": "https://github.com/example/repo/issues/1",
+			"user": {"login": "a"}, "created_at": "2025-04-01T13:00:00Z"}]`,
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	// The issue is created after the window, so it must be downloaded again.
+	events, etags, err := gh.GetConditionalUpdates(context.Background(), "https://github.com/example/repo/issues", nil, from, to)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Empty(t, etags)
+}
+
+func TestGetUpdates_RateLimited(t *testing.T) {
+	t.Parallel()
+
+	client := &statusClient{
+		status: http.StatusForbidden,
+		header: http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{"1760000000"},
+		},
+	}
+
+	gh := github.New(&config.GitHub{PageSize: "100"}, client)
+
+	_, err := gh.GetUpdates(context.Background(), "https://github.com/example/repo/issues", time.Time{}, time.Now())
+
+	var rateLimited github.ErrRateLimited
+
+	require.ErrorAs(t, err, &rateLimited)
+	assert.Equal(t, time.Unix(1760000000, 0), rateLimited.Reset)
+
+	limits := gh.RateLimits()
+	require.Len(t, limits, 1)
+	assert.Zero(t, limits[0].Remaining)
+}
+
+type statusClient struct {
+	status int
+	header http.Header
+}
+
+func (c *statusClient) Do(_ *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: c.status,
+		Header:     c.header,
+		Body:       io.NopCloser(strings.NewReader(`{"message": "API rate limit exceeded"}`)),
+	}, nil
+}
@@ -0,0 +1,389 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const (
+	graphQLURL = "https://api.github.com/graphql"
+
+	graphQLPageSize = 100
+	// graphQLBatchSize keeps a query below the limit of 500 000 nodes: a
+	// repository costs up to 3 lists of 100 items with 100 labels or assets.
+	graphQLBatchSize = 15
+)
+
+// itemFields are the fields of the REST issue list, issues and pull requests
+// share them.
+const itemFields = `databaseId title body number url createdAt
+	author { login }
+	labels(first: 100) { nodes { name } }
+	thumbsUp: reactions(content: THUMBS_UP) { totalCount }
+	thumbsDown: reactions(content: THUMBS_DOWN) { totalCount }`
+
+// repositoryFragments request the first pages of the lists read by the REST
+// client: open issues and pull requests and releases, the newest first.
+var repositoryFragments = fmt.Sprintf(`
+fragment issue on Issue { %[1]s }
+fragment pull on PullRequest { %[1]s }
+fragment repository on Repository {
+	issues(first: %[2]d, states: OPEN, orderBy: {field: CREATED_AT, direction: DESC}) {
+		nodes { ...issue }
+		pageInfo { hasNextPage }
+	}
+	pullRequests(first: %[2]d, states: OPEN, orderBy: {field: CREATED_AT, direction: DESC}) {
+		nodes { ...pull }
+		pageInfo { hasNextPage }
+	}
+	releases(first: %[2]d, orderBy: {field: CREATED_AT, direction: DESC}) {
+		nodes {
+			databaseId name tagName description url isPrerelease publishedAt
+			author { login }
+			releaseAssets(first: 100) { nodes { name downloadUrl } }
+		}
+		pageInfo { hasNextPage }
+	}
+}`, itemFields, graphQLPageSize)
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   map[string]*GraphQLRepository `json:"data"`
+	Errors []graphQLError                `json:"errors"`
+}
+
+// graphQLError of a repository has the alias of the repository first in the
+// path.
+type graphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+// repoLink is a repository link. The repository link itself reports both
+// issues and releases.
+type repoLink struct {
+	owner    string
+	name     string
+	issues   bool
+	releases bool
+}
+
+// GraphQL checks repository links of many repositories with one aliased query
+// of the GraphQL API. Other links and repositories with more new items than a
+// page holds are checked by the REST client, so updates are the same as of
+// the REST client and the two can be swapped.
+type GraphQL struct {
+	rest      *GitHub
+	rateLimit *rateLimit
+}
+
+// NewGraphQL shares the client and the token of rest.
+func NewGraphQL(rest *GitHub) *GraphQL {
+	return &GraphQL{
+		rest:      rest,
+		rateLimit: newRateLimit("github_graphql"),
+	}
+}
+
+func (q *GraphQL) GetType() string {
+	return q.rest.GetType()
+}
+
+func (q *GraphQL) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	return q.rest.GetUpdates(ctx, link, from, to)
+}
+
+func (q *GraphQL) GetConditionalUpdates(
+	ctx context.Context,
+	link string,
+	prev map[string]string,
+	from, to time.Time,
+) ([]domain.Event, map[string]string, error) {
+	return q.rest.GetConditionalUpdates(ctx, link, prev, from, to)
+}
+
+// RateLimits returns quotas of both APIs: they are counted separately.
+func (q *GraphQL) RateLimits() []domain.RateLimit {
+	return append(q.rest.RateLimits(), q.rateLimit.limits()...)
+}
+
+// IsBatched returns true for repository links. Issues and pull requests are
+// checked by the REST client: their timeline events have no REST IDs in the
+// GraphQL API.
+func (q *GraphQL) IsBatched(link string) bool {
+	_, ok := q.rest.parseRepoLink(link)
+
+	return ok
+}
+
+// GetBatchUpdates requests up to graphQLBatchSize repositories at once. Every
+// link is checked from its own time. Links of repositories missing or failed
+// in the response are returned with errors.
+func (q *GraphQL) GetBatchUpdates(
+	ctx context.Context,
+	links map[string]time.Time,
+	to time.Time,
+) (map[string][]domain.Event, map[string]error, error) {
+	parsed := make(map[string]repoLink, len(links))
+	repos := make(map[string][]string)
+
+	for link := range links {
+		repo, ok := q.rest.parseRepoLink(link)
+		if !ok {
+			continue
+		}
+
+		name := repo.owner + "/" + repo.name
+		parsed[link] = repo
+		repos[name] = append(repos[name], link)
+	}
+
+	updates := make(map[string][]domain.Event, len(links))
+	linkErrs := make(map[string]error)
+
+	for chunk := range slices.Chunk(slices.Sorted(maps.Keys(repos)), graphQLBatchSize) {
+		data, repoErrs, err := q.getRepositories(ctx, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, name := range chunk {
+			repoErr := repoErrs[name]
+			if repoErr == nil && data[name] == nil {
+				repoErr = NewErrRepositoryNotFound(name)
+			}
+
+			for _, link := range repos[name] {
+				if repoErr != nil {
+					linkErrs[link] = repoErr
+
+					continue
+				}
+
+				events, err := q.getLinkUpdates(ctx, link, parsed[link], data[name], links[link], to)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				updates[link] = events
+			}
+		}
+	}
+
+	return updates, linkErrs, nil
+}
+
+// getLinkUpdates walks the rest of the lists with the REST client when the
+// window doesn't fit in the first page.
+func (q *GraphQL) getLinkUpdates(
+	ctx context.Context,
+	link string,
+	repo repoLink,
+	data *GraphQLRepository,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	if repo.issues && (hasMoreItems(&data.Issues, from) || hasMoreItems(&data.PullRequests, from)) ||
+		repo.releases && hasMoreReleases(&data.Releases, from) {
+		return q.rest.GetUpdates(ctx, link, from, to)
+	}
+
+	events := make([]domain.Event, 0)
+
+	if repo.issues {
+		items := make([]Data, 0, len(data.Issues.Nodes)+len(data.PullRequests.Nodes))
+
+		for _, item := range data.Issues.Nodes {
+			items = append(items, GraphQLItemToData(&item, false))
+		}
+
+		for _, item := range data.PullRequests.Nodes {
+			items = append(items, GraphQLItemToData(&item, true))
+		}
+
+		// the REST issue list mixes issues and pull requests, the newest first
+		slices.SortStableFunc(items, func(a, b Data) int {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		})
+
+		for _, item := range items {
+			if inRange(item.CreatedAt, from, to) {
+				events = append(events, DataToEvent(&item))
+			}
+		}
+	}
+
+	if repo.releases {
+		for _, node := range data.Releases.Nodes {
+			release := GraphQLReleaseToRelease(&node)
+
+			if inRange(release.PublishedAt, from, to) {
+				events = append(events, ReleaseToEvent(&release))
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func hasMoreItems(items *GraphQLItems, from time.Time) bool {
+	if !items.PageInfo.HasNextPage || len(items.Nodes) == 0 {
+		return false
+	}
+
+	return !from.After(items.Nodes[len(items.Nodes)-1].CreatedAt)
+}
+
+// hasMoreReleases treats drafts as recent: they have no publication time.
+func hasMoreReleases(releases *GraphQLReleases, from time.Time) bool {
+	if !releases.PageInfo.HasNextPage || len(releases.Nodes) == 0 {
+		return false
+	}
+
+	last := releases.Nodes[len(releases.Nodes)-1].PublishedAt
+
+	return last.IsZero() || !from.After(last)
+}
+
+// getRepositories returns repositories by names, missing repositories are
+// nil. Errors of single repositories are returned by names, they may come
+// along with partial data of the repository.
+func (q *GraphQL) getRepositories(
+	ctx context.Context,
+	names []string,
+) (map[string]*GraphQLRepository, map[string]error, error) {
+	params := make([]string, 0, len(names))
+	fields := make([]string, 0, len(names))
+	variables := make(map[string]any, 2*len(names))
+
+	for i, name := range names {
+		owner, repo, _ := strings.Cut(name, "/")
+
+		variables[fmt.Sprintf("owner%d", i)] = owner
+		variables[fmt.Sprintf("name%d", i)] = repo
+		params = append(params, fmt.Sprintf("$owner%d: String!, $name%d: String!", i, i))
+		fields = append(fields, fmt.Sprintf("r%d: repository(owner: $owner%d, name: $name%d) { ...repository }", i, i, i))
+	}
+
+	query := fmt.Sprintf(
+		"query(%s) {\n%s\n}\n%s",
+		strings.Join(params, ", "),
+		strings.Join(fields, "\n"),
+		repositoryFragments,
+	)
+
+	var resp graphQLResponse
+
+	if err := q.post(ctx, &graphQLRequest{Query: query, Variables: variables}, &resp); err != nil {
+		return nil, nil, err
+	}
+
+	repos := make(map[string]*GraphQLRepository, len(names))
+	aliases := make(map[string]string, len(names))
+
+	for i, name := range names {
+		alias := fmt.Sprintf("r%d", i)
+		repos[name] = resp.Data[alias]
+		aliases[alias] = name
+	}
+
+	// missing repositories are reported as errors along with the data of
+	// the rest, an error of no repository fails the whole query
+	repoErrs := make(map[string]error)
+
+	for _, gqlErr := range resp.Errors {
+		alias := ""
+		if len(gqlErr.Path) != 0 {
+			alias, _ = gqlErr.Path[0].(string)
+		}
+
+		name, ok := aliases[alias]
+		if !ok {
+			return nil, nil, NewErrGraphQL(gqlErr.Message)
+		}
+
+		if repoErrs[name] == nil {
+			repoErrs[name] = NewErrGraphQL(gqlErr.Message)
+		}
+	}
+
+	return repos, repoErrs, nil
+}
+
+func (q *GraphQL) post(ctx context.Context, request *graphQLRequest, data any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal graphql request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request with url=%q: %w", graphQLURL, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", q.rest.token)
+
+	resp, err := q.rest.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get response with url=%q: %w", graphQLURL, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error(
+				"failed to close response body",
+				slog.Any("error", err),
+				slog.Any("service", "github graphql client"),
+			)
+		}
+	}()
+
+	q.rateLimit.update(resp.Header)
+
+	switch {
+	case isRateLimited(resp):
+		limit, _ := q.rateLimit.get()
+
+		return NewErrRateLimited(graphQLURL, limit.Reset)
+
+	case resp.StatusCode != http.StatusOK:
+		return NewErrUnexpectedStatus(graphQLURL, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		return fmt.Errorf("failed to decode response with url=%q: %w", graphQLURL, err)
+	}
+
+	return nil
+}
+
+// parseRepoLink returns false for links to issues and pull requests.
+func (g *GitHub) parseRepoLink(link string) (repoLink, bool) {
+	if matches := g.repoRegex.FindStringSubmatch(link); matches != nil {
+		return repoLink{owner: matches[1], name: matches[2], issues: true, releases: true}, true
+	}
+
+	if matches := g.issuesRegex.FindStringSubmatch(link); matches != nil {
+		return repoLink{owner: matches[1], name: matches[2], issues: true}, true
+	}
+
+	if matches := g.releasesRegex.FindStringSubmatch(link); matches != nil {
+		return repoLink{owner: matches[1], name: matches[2], releases: true}, true
+	}
+
+	return repoLink{}, false
+}
@@ -0,0 +1,219 @@
+package github_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	restIssues = `[
+		{"id": 2, "title": "Add feature", "number": 2, "html_url": "https://github.com/example/repo/pull/2",
+			"user": {"login": "b"}, "created_at": "2025-04-01T11:30:00Z",
+			"pull_request": {"url": "https://api.github.com/repos/example/repo/pulls/2"}},
+		{"id": 1, "title": "Bug", "body": "crash", "number": 1, "html_url": "https://github.com/example/repo/issues/1",
+			"user": {"login": "a"}, "created_at": "2025-04-01T11:00:00Z",
+			"labels": [{"name": "bug"}], "reactions": {"+1": 2, "-1": 1}},
+		{"id": 3, "title": "Old", "number": 3, "html_url": "https://github.com/example/repo/issues/3",
+			"user": {"login": "a"}, "created_at": "2025-04-01T09:00:00Z"}
+	]`
+	restReleases = `[
+		{"id": 10, "name": "", "tag_name": "v1.0.0", "body": "notes", "html_url": "https://github.com/example/repo/releases/tag/v1.0.0",
+			"author": {"login": "c"}, "published_at": "2025-04-01T11:15:00Z",
+			"assets": [{"name": "app.zip", "browser_download_url": "https://github.com/example/repo/releases/download/v1.0.0/app.zip"}]}
+	]`
+	graphQLRepository = `{
+		"issues": {"nodes": [
+			{"databaseId": 1, "title": "Bug", "body": "crash", "number": 1, "url": "https://github.com/example/repo/issues/1",
+				"author": {"login": "a"}, "createdAt": "2025-04-01T11:00:00Z", "labels": {"nodes": [{"name": "bug"}]},
+				"thumbsUp": {"totalCount": 2}, "thumbsDown": {"totalCount": 1}},
+			{"databaseId": 3, "title": "Old", "number": 3, "url": "https://github.com/example/repo/issues/3",
+				"author": {"login": "a"}, "createdAt": "2025-04-01T09:00:00Z", "labels": {"nodes": []}}
+		], "pageInfo": {"hasNextPage": %s}},
+		"pullRequests": {"nodes": [
+			{"databaseId": 2, "title": "Add feature", "number": 2, "url": "https://github.com/example/repo/pull/2",
+				"author": {"login": "b"}, "createdAt": "2025-04-01T11:30:00Z", "labels": {"nodes": []}}
+		], "pageInfo": {"hasNextPage": false}},
+		"releases": {"nodes": [
+			{"databaseId": 10, "name": "", "tagName": "v1.0.0", "description": "notes",
+				"url": "https://github.com/example/repo/releases/tag/v1.0.0", "isPrerelease": false,
+				"author": {"login": "c"}, "publishedAt": "2025-04-01T11:15:00Z",
+				"releaseAssets": {"nodes": [
+					{"name": "app.zip", "downloadUrl": "https://github.com/example/repo/releases/download/v1.0.0/app.zip"}
+				]}}
+		], "pageInfo": {"hasNextPage": false}}
+	}`
+)
+
+// graphQLClient answers GraphQL queries with the repository data for every
+// alias and REST requests with the fake client.
+type graphQLClient struct {
+	rest       *fakeClient
+	repository string
+	// errors are reported instead of the data of repositories by names
+	errors   map[string]string
+	queries  int
+	requests int
+}
+
+func (c *graphQLClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost {
+		c.requests++
+
+		return c.rest.Do(req)
+	}
+
+	c.queries++
+
+	var request struct {
+		Variables map[string]string `json:"variables"`
+	}
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+
+	aliases := make([]string, 0)
+	errors := make([]string, 0)
+
+	for name, owner := range request.Variables {
+		index, ok := strings.CutPrefix(name, "owner")
+		if !ok {
+			continue
+		}
+
+		message, failed := c.errors[owner+"/"+request.Variables["name"+index]]
+		if !failed {
+			aliases = append(aliases, `"r`+index+`": `+c.repository)
+
+			continue
+		}
+
+		aliases = append(aliases, `"r`+index+`": null`)
+		errors = append(errors, `{"message": "`+message+`", "path": ["r`+index+`"]}`)
+	}
+
+	body := `{"data": {` + strings.Join(aliases, ",") + `}, "errors": [` + strings.Join(errors, ",") + `]}`
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func newGraphQLClient(hasNextPage string) *graphQLClient {
+	return &graphQLClient{
+		rest: &fakeClient{
+			responses: map[string]string{
+				"/repos/example/repo/issues":   restIssues,
+				"/repos/example/repo/releases": restReleases,
+			},
+		},
+		repository: strings.Replace(graphQLRepository, "%s", hasNextPage, 1),
+	}
+}
+
+func TestGraphQL_GetBatchUpdates_SameAsREST(t *testing.T) {
+	t.Parallel()
+
+	client := newGraphQLClient("false")
+	rest := github.New(&config.GitHub{PageSize: "100"}, client)
+	gql := github.NewGraphQL(rest)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	links := map[string]time.Time{
+		"https://github.com/example/repo":          from,
+		"https://github.com/example/repo/releases": from,
+	}
+
+	updates, linkErrs, err := gql.GetBatchUpdates(context.Background(), links, to)
+	require.NoError(t, err)
+	assert.Empty(t, linkErrs)
+	assert.Equal(t, 1, client.queries)
+	assert.Zero(t, client.requests)
+
+	for link := range links {
+		want, err := rest.GetUpdates(context.Background(), link, from, to)
+		require.NoError(t, err)
+
+		assert.Equal(t, want, updates[link], link)
+	}
+
+	assert.Len(t, updates["https://github.com/example/repo"], 3)
+}
+
+func TestGraphQL_GetBatchUpdates_FallsBackToREST(t *testing.T) {
+	t.Parallel()
+
+	client := newGraphQLClient("true")
+	rest := github.New(&config.GitHub{PageSize: "100"}, client)
+	gql := github.NewGraphQL(rest)
+
+	// The oldest issue of the first page is in the window, so the rest of
+	// issues is read by the REST client.
+	from := time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	link := "https://github.com/example/repo/issues"
+
+	updates, linkErrs, err := gql.GetBatchUpdates(context.Background(), map[string]time.Time{link: from}, to)
+	require.NoError(t, err)
+	assert.Empty(t, linkErrs)
+	assert.NotZero(t, client.requests)
+
+	want, err := rest.GetUpdates(context.Background(), link, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, want, updates[link])
+	assert.Len(t, updates[link], 3)
+}
+
+func TestGraphQL_IsBatched(t *testing.T) {
+	t.Parallel()
+
+	gql := github.NewGraphQL(github.New(&config.GitHub{PageSize: "100"}, &fakeClient{}))
+
+	assert.True(t, gql.IsBatched("https://github.com/example/repo"))
+	assert.True(t, gql.IsBatched("https://github.com/example/repo/issues"))
+	assert.True(t, gql.IsBatched("https://github.com/example/repo/releases"))
+	assert.False(t, gql.IsBatched("https://github.com/example/repo/issues/1"))
+	assert.False(t, gql.IsBatched("https://github.com/example/repo/pull/1"))
+}
+
+func TestGraphQL_GetBatchUpdates_RepositoryErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newGraphQLClient("false")
+	client.errors = map[string]string{
+		"example/gone":    "Could not resolve to a Repository with the name 'example/gone'.",
+		"example/timeout": "Something went wrong while executing your query.",
+	}
+	gql := github.NewGraphQL(github.New(&config.GitHub{PageSize: "100"}, client))
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	updates, linkErrs, err := gql.GetBatchUpdates(context.Background(), map[string]time.Time{
+		"https://github.com/example/repo":             from,
+		"https://github.com/example/gone":             from,
+		"https://github.com/example/timeout/issues":   from,
+		"https://github.com/example/timeout/releases": from,
+	}, to)
+	require.NoError(t, err, "errors of single repositories must not fail the batch")
+
+	assert.Len(t, updates["https://github.com/example/repo"], 3)
+	assert.Len(t, updates, 1, "failed links must not pass for links without updates")
+
+	require.Len(t, linkErrs, 3)
+	assert.ErrorAs(t, linkErrs["https://github.com/example/gone"], &github.ErrGraphQL{})
+	assert.ErrorAs(t, linkErrs["https://github.com/example/timeout/issues"], &github.ErrGraphQL{})
+	assert.ErrorAs(t, linkErrs["https://github.com/example/timeout/releases"], &github.ErrGraphQL{})
+}
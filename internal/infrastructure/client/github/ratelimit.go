@@ -0,0 +1,71 @@
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// rateLimit keeps the quota reported by the last response. The quota belongs
+// to the token, so checkers built on one client share it.
+type rateLimit struct {
+	mu     sync.Mutex
+	source string
+	limit  domain.RateLimit
+	known  bool
+}
+
+func newRateLimit(source string) *rateLimit {
+	return &rateLimit{
+		source: source,
+	}
+}
+
+// update ignores responses without rate limit headers.
+func (r *rateLimit) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = domain.RateLimit{
+		Source:    r.source,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+	r.known = true
+}
+
+// limits returns nothing until the first response.
+func (r *rateLimit) limits() []domain.RateLimit {
+	limit, ok := r.get()
+	if !ok {
+		return nil
+	}
+
+	return []domain.RateLimit{limit}
+}
+
+func (r *rateLimit) get() (domain.RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.limit, r.known
+}
+
+// isRateLimited tells an exhausted quota from other forbidden responses.
+func isRateLimited(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
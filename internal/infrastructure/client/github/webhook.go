@@ -0,0 +1,143 @@
+package github
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const githubURL = "https://github.com/"
+
+// Webhook turns webhook payloads to events of tracked links. Events have the
+// same IDs as found by checks, so a link checked by both sends them once.
+type Webhook struct{}
+
+func NewWebhook() *Webhook {
+	return &Webhook{}
+}
+
+// ParseEvent returns events of the payload by canonical links they are
+// reported to. Other events and actions have no events.
+func (w *Webhook) ParseEvent(event string, payload []byte) (map[string][]domain.Event, error) {
+	var data WebhookPayload
+
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s webhook: %w", event, err)
+	}
+
+	repo := githubURL + strings.ToLower(data.Repository.FullName)
+	updates := make(map[string][]domain.Event)
+
+	switch {
+	case event == "issues" && data.Issue != nil:
+		itemUpdates(updates, repo, &data, data.Issue, false, time.Time{})
+
+	case event == "pull_request" && data.PullRequest != nil:
+		pull := data.PullRequest.Data
+		pull.PR.URL = pull.URL
+
+		itemUpdates(updates, repo, &data, &pull, data.PullRequest.Merged, data.PullRequest.MergedAt)
+
+	case event == "issue_comment" && data.Action == "created" && data.Issue != nil && data.Comment != nil:
+		addEvent(updates, CommentToEvent(data.Comment, data.Issue.Title), itemLink(repo, data.Issue))
+
+	case event == "release" && data.Action == "published" && data.Release != nil:
+		addEvent(updates, ReleaseToEvent(data.Release), repo, repo+"/releases")
+
+	case event == "push":
+		pushUpdates(updates, repo, &data)
+	}
+
+	return updates, nil
+}
+
+// itemUpdates reports new issues and pull requests to repository links and
+// changes of them to their own links. The time of the change is the time of
+// the issue event found by checks.
+func itemUpdates(
+	updates map[string][]domain.Event,
+	repo string,
+	data *WebhookPayload,
+	item *Data,
+	merged bool,
+	mergedAt time.Time,
+) {
+	if data.Action == "opened" {
+		addEvent(updates, DataToEvent(item), repo, repo+"/issues")
+
+		return
+	}
+
+	issueEvent := Event{
+		Event:     data.Action,
+		Actor:     data.Sender,
+		CreatedAt: item.UpdatedAt,
+	}
+
+	switch {
+	case data.Action == "closed" && merged:
+		issueEvent.Event = "merged"
+		issueEvent.CreatedAt = cmp.Or(mergedAt, item.UpdatedAt)
+
+	case data.Action == "closed":
+		issueEvent.CreatedAt = cmp.Or(item.ClosedAt, item.UpdatedAt)
+	}
+
+	if data.Label != nil {
+		issueEvent.Label = *data.Label
+	}
+
+	event, ok := IssueEventToEvent(&issueEvent, item.Number, item.Title, item.URL)
+	if !ok {
+		return
+	}
+
+	addEvent(updates, event, itemLink(repo, item))
+}
+
+// pushUpdates reports pushed commits as checks of branch links do, they are
+// grouped by the scheduler. The parent of a pushed commit is the previous one.
+func pushUpdates(updates map[string][]domain.Event, repo string, data *WebhookPayload) {
+	branch, ok := strings.CutPrefix(data.Ref, "refs/heads/")
+	if !ok || len(data.Commits) == 0 {
+		return
+	}
+
+	title := strings.TrimPrefix(repo, githubURL) + ":" + branch
+	parent := data.Before
+
+	// pushed commits are ordered from the oldest, checks return the newest first
+	events := make([]domain.Event, 0, len(data.Commits))
+
+	for _, pushed := range data.Commits {
+		commit := WebhookCommitToCommit(&pushed)
+		commit.Parents = []Parent{{SHA: parent}}
+		parent = commit.SHA
+
+		events = append(events, BranchCommitToEvent(&commit, title))
+	}
+
+	slices.Reverse(events)
+
+	link := repo + "/tree/" + branch
+	updates[link] = append(updates[link], events...)
+}
+
+func itemLink(repo string, item *Data) string {
+	if item.PR.URL != "" {
+		return fmt.Sprintf("%s/pull/%d", repo, item.Number)
+	}
+
+	return fmt.Sprintf("%s/issues/%d", repo, item.Number)
+}
+
+func addEvent(updates map[string][]domain.Event, event domain.Event, links ...string) {
+	for _, link := range links {
+		updates[link] = append(updates[link], event)
+	}
+}
@@ -0,0 +1,227 @@
+package github_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_ParseEvent(t *testing.T) {
+	t.Parallel()
+
+	const repo = "https://github.com/example/repo"
+
+	tests := []struct {
+		name    string
+		event   string
+		payload string
+		want    map[string]string
+	}{
+		{
+			name:  "opened issue",
+			event: "issues",
+			payload: `{
+				"action": "opened",
+				"repository": {"full_name": "Example/Repo"},
+				"issue": {"id": 1, "number": 3, "title": "bug", "html_url": "https://github.com/Example/Repo/issues/3"}
+			}`,
+			want: map[string]string{
+				repo:             domain.KindIssue,
+				repo + "/issues": domain.KindIssue,
+			},
+		},
+		{
+			name:  "merged pull request",
+			event: "pull_request",
+			payload: `{
+				"action": "closed",
+				"repository": {"full_name": "example/repo"},
+				"sender": {"login": "alice"},
+				"pull_request": {
+					"id": 2, "number": 7, "title": "feature", "merged": true,
+					"html_url": "https://github.com/example/repo/pull/7"
+				}
+			}`,
+			want: map[string]string{
+				repo + "/pull/7": domain.KindMerged,
+			},
+		},
+		{
+			name:  "comment of pull request",
+			event: "issue_comment",
+			payload: `{
+				"action": "created",
+				"repository": {"full_name": "example/repo"},
+				"issue": {"number": 7, "title": "feature", "pull_request": {"url": "https://api.github.com/pulls/7"}},
+				"comment": {"id": 5, "body": "lgtm", "user": {"login": "bob"}}
+			}`,
+			want: map[string]string{
+				repo + "/pull/7": domain.KindComment,
+			},
+		},
+		{
+			name:  "published release",
+			event: "release",
+			payload: `{
+				"action": "published",
+				"repository": {"full_name": "example/repo"},
+				"release": {"id": 4, "tag_name": "v1.0.0"}
+			}`,
+			want: map[string]string{
+				repo:               domain.KindRelease,
+				repo + "/releases": domain.KindRelease,
+			},
+		},
+		{
+			name:  "push",
+			event: "push",
+			payload: `{
+				"ref": "refs/heads/feature/x",
+				"repository": {"full_name": "example/repo"},
+				"before": "0123456789",
+				"commits": [{"id": "abcdef0123", "message": "fix", "author": {"username": "alice"}}]
+			}`,
+			want: map[string]string{
+				repo + "/tree/feature/x": domain.KindCommits,
+			},
+		},
+		{
+			name:  "tag push",
+			event: "push",
+			payload: `{
+				"ref": "refs/tags/v1.0.0",
+				"repository": {"full_name": "example/repo"},
+				"commits": [{"id": "abcdef0123", "message": "fix", "author": {"username": "alice"}}]
+			}`,
+			want: map[string]string{},
+		},
+		{
+			name:    "unsupported event",
+			event:   "star",
+			payload: `{"action": "created", "repository": {"full_name": "example/repo"}}`,
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			updates, err := github.NewWebhook().ParseEvent(tt.event, []byte(tt.payload))
+			require.NoError(t, err)
+
+			kinds := make(map[string]string, len(updates))
+
+			for link, events := range updates {
+				require.Len(t, events, 1)
+
+				kinds[link] = events[0].Kind
+			}
+
+			assert.Equal(t, tt.want, kinds)
+		})
+	}
+}
+
+func TestWebhook_ParseEvent_Push(t *testing.T) {
+	t.Parallel()
+
+	payload := `{
+		"ref": "refs/heads/main",
+		"before": "1111111aaa",
+		"repository": {"full_name": "example/repo"},
+		"commits": [
+			{"id": "2222222aaa", "message": "first", "url": "https://github.com/example/repo/commit/2222222aaa",
+				"author": {"username": "alice"}},
+			{"id": "3333333aaa", "message": "second", "url": "https://github.com/example/repo/commit/3333333aaa",
+				"author": {"username": "alice"}}
+		]
+	}`
+
+	updates, err := github.NewWebhook().ParseEvent("push", []byte(payload))
+	require.NoError(t, err)
+
+	commits := updates["https://github.com/example/repo/tree/main"]
+	require.Len(t, commits, 2, "commits should be grouped by the scheduler")
+	assert.Equal(t, "3333333aaa", commits[0].ExternalID, "the newest commit should be first as in checks")
+
+	branch := github.NewBranch(github.New(&config.GitHub{PageSize: "100"}, &fakeClient{}))
+
+	events := branch.GroupEvents(commits)
+	require.Len(t, events, 1)
+	assert.Equal(t, "https://github.com/example/repo/compare/1111111...3333333", events[0].URL)
+	assert.Equal(t, []string{"3333333aaa", "2222222aaa"}, events[0].Parts)
+}
+
+func TestWebhook_ParseEvent_InvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	_, err := github.NewWebhook().ParseEvent("issues", []byte("{"))
+	assert.Error(t, err)
+}
+
+func TestWebhook_ParseEvent_SameAsPolled(t *testing.T) {
+	t.Parallel()
+
+	const link = "https://github.com/example/repo/issues/42"
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/issues/42": `{
+				"number": 42,
+				"title": "Bug in feature",
+				"html_url": "https://github.com/example/repo/issues/42"
+			}`,
+			"/repos/example/repo/issues/42/events": `[
+				{"id": 99, "event": "closed", "actor": {"login": "c"}, "created_at": "2025-04-01T11:00:00Z"},
+				{"id": 100, "event": "labeled", "actor": {"login": "c"}, "label": {"name": "bug"},
+				 "created_at": "2025-04-01T11:00:00Z"}
+			]`,
+		},
+	}
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	polled, err := github.New(&config.GitHub{PageSize: "100"}, client).GetUpdates(context.Background(), link, from, to)
+	require.NoError(t, err)
+	require.Len(t, polled, 2)
+
+	payloads := []string{
+		`{
+			"action": "closed",
+			"repository": {"full_name": "example/repo"},
+			"sender": {"login": "c"},
+			"issue": {
+				"number": 42, "title": "Bug in feature", "html_url": "https://github.com/example/repo/issues/42",
+				"closed_at": "2025-04-01T11:00:00Z", "updated_at": "2025-04-01T11:00:01Z"
+			}
+		}`,
+		`{
+			"action": "labeled",
+			"repository": {"full_name": "example/repo"},
+			"sender": {"login": "c"},
+			"label": {"name": "bug"},
+			"issue": {
+				"number": 42, "title": "Bug in feature", "html_url": "https://github.com/example/repo/issues/42",
+				"updated_at": "2025-04-01T11:00:00Z"
+			}
+		}`,
+	}
+
+	for i, payload := range payloads {
+		updates, err := github.NewWebhook().ParseEvent("issues", []byte(payload))
+		require.NoError(t, err)
+		require.Len(t, updates[link], 1)
+
+		pushed := updates[link][0]
+		assert.Equal(t, polled[i].Kind, pushed.Kind, "webhook event should be of the polled kind")
+		assert.Equal(t, polled[i].ExternalID, pushed.ExternalID, "webhook event should be seen as the polled one")
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const workflowRunsURL = "https://api.github.com/repos/%s/%s/actions/workflows/%s/runs"
+
+// Workflow reports completed runs of a GitHub Actions workflow. A run is
+// reported when it finishes, so its update time is matched against the window.
+type Workflow struct {
+	github        *GitHub
+	workflowRegex *regexp.Regexp
+}
+
+// NewWorkflow shares the client and the rate limit of gh.
+func NewWorkflow(gh *GitHub) *Workflow {
+	return &Workflow{
+		github: gh,
+		workflowRegex: regexp.MustCompile(
+			`^https://github\.com/([\w.-]+)/([\w.-]+)/actions/workflows/([\w.-]+)$`,
+		),
+	}
+}
+
+func (w *Workflow) GetType() string {
+	return "github_workflow"
+}
+
+func (w *Workflow) RateLimits() []domain.RateLimit {
+	return w.github.RateLimits()
+}
+
+func (w *Workflow) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := w.workflowRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	baseURL := fmt.Sprintf(workflowRunsURL, matches[1], matches[2], matches[3])
+
+	params := url.Values{}
+	params.Add("status", "completed")
+
+	events := make([]domain.Event, 0)
+
+	for page := 1; ; page++ {
+		var data WorkflowRuns
+
+		if err := w.github.getAndDecodePage(ctx, baseURL, params, page, &data); err != nil {
+			return nil, err
+		}
+
+		// runs are ordered by creation time, so the walk stops at the first page
+		// without runs finished in the window
+		hasRecent := false
+
+		for _, run := range data.WorkflowRuns {
+			if !from.After(run.UpdatedAt) {
+				hasRecent = true
+			}
+
+			if inRange(run.UpdatedAt, from, to) {
+				events = append(events, WorkflowRunToEvent(&run))
+			}
+		}
+
+		if !hasRecent {
+			return events, nil
+		}
+	}
+}
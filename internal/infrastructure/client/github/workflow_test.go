@@ -0,0 +1,56 @@
+package github_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowGetUpdates(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/repos/example/repo/actions/workflows/ci.yml/runs": `{"workflow_runs": [
+				{
+					"name": "CI", "run_number": 3, "conclusion": "failure", "head_branch": "main",
+					"created_at": "2025-04-01T11:00:00Z", "updated_at": "2025-04-01T11:10:00Z"
+				},
+				{
+					"name": "CI", "run_number": 2, "conclusion": "success", "head_branch": "dev",
+					"created_at": "2025-04-01T09:50:00Z", "updated_at": "2025-04-01T10:05:00Z"
+				},
+				{
+					"name": "CI", "run_number": 1, "conclusion": "success", "head_branch": "main",
+					"created_at": "2025-04-01T09:00:00Z", "updated_at": "2025-04-01T09:10:00Z"
+				}
+			]}`,
+		},
+		empty: `{"workflow_runs": []}`,
+	}
+
+	workflow := github.NewWorkflow(github.New(&config.GitHub{PageSize: "100"}, client))
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := workflow.GetUpdates(
+		context.Background(),
+		"https://github.com/example/repo/actions/workflows/ci.yml",
+		from,
+		to,
+	)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "CI #3", events[0].Title)
+	assert.Equal(t, "failure", events[0].Attributes[domain.AttrStatus])
+	assert.Equal(t, "CI #2", events[1].Title)
+	assert.Equal(t, "dev", events[1].Attributes[domain.AttrBranch])
+}
@@ -0,0 +1,81 @@
+package gitlab
+
+import "time"
+
+type Issue struct {
+	ID          int64     `json:"id"`
+	IID         int       `json:"iid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	URL         string    `json:"web_url"`
+	Author      User      `json:"author"`
+	Labels      []string  `json:"labels"`
+	Upvotes     int       `json:"upvotes"`
+	Downvotes   int       `json:"downvotes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type User struct {
+	Username string `json:"username"`
+}
+
+type Note struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Body      string    `json:"body"`
+	System    bool      `json:"system"`
+	Author    User      `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LabelEvent struct {
+	ID        int64     `json:"id"`
+	Action    string    `json:"action"`
+	Label     Label     `json:"label"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+type StateEvent struct {
+	ID        int64     `json:"id"`
+	State     string    `json:"state"`
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Commit struct {
+	ID            string    `json:"id"`
+	ShortID       string    `json:"short_id"`
+	Title         string    `json:"title"`
+	AuthorName    string    `json:"author_name"`
+	URL           string    `json:"web_url"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+type Release struct {
+	Name        string    `json:"name"`
+	TagName     string    `json:"tag_name"`
+	Description string    `json:"description"`
+	Upcoming    bool      `json:"upcoming_release"`
+	Author      User      `json:"author"`
+	Assets      Assets    `json:"assets"`
+	Links       Links     `json:"_links"`
+	ReleasedAt  time.Time `json:"released_at"`
+}
+
+type Assets struct {
+	Links []AssetLink `json:"links"`
+}
+
+type AssetLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type Links struct {
+	Self string `json:"self"`
+}
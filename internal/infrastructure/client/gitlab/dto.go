@@ -0,0 +1,178 @@
+package gitlab
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+var stateKinds = map[string]string{
+	"closed":   domain.KindClosed,
+	"reopened": domain.KindReopened,
+	"merged":   domain.KindMerged,
+}
+
+var labelKinds = map[string]string{
+	"add":    domain.KindLabeled,
+	"remove": domain.KindUnlabeled,
+}
+
+func IssueToEvent(issue *Issue) domain.Event {
+	return itemToEvent(domain.KindIssue, issue)
+}
+
+func MergeRequestToEvent(mr *Issue) domain.Event {
+	return itemToEvent(domain.KindMergeRequest, mr)
+}
+
+func itemToEvent(kind string, item *Issue) domain.Event {
+	event := newEvent(
+		kind,
+		strconv.FormatInt(item.ID, 10),
+		item.Author.Username,
+		item.Title,
+		item.URL,
+		preview(item.Description),
+		item.CreatedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrScore: strconv.Itoa(item.Upvotes - item.Downvotes),
+	}
+
+	if len(item.Labels) != 0 {
+		event.Attributes[domain.AttrLabels] = strings.Join(item.Labels, ",")
+	}
+
+	return event
+}
+
+// NoteToEvent reports comments. Notes of the DiffNote type are left on code.
+func NoteToEvent(note *Note, issue *Issue) domain.Event {
+	kind := domain.KindComment
+	if note.Type == "DiffNote" {
+		kind = domain.KindCodeComment
+	}
+
+	return newEvent(
+		kind,
+		strconv.FormatInt(note.ID, 10),
+		note.Author.Username,
+		issue.Title,
+		fmt.Sprintf("%s#note_%d", issue.URL, note.ID),
+		preview(note.Body),
+		note.CreatedAt,
+	)
+}
+
+// LabelEventToEvent returns false for unknown actions.
+func LabelEventToEvent(event *LabelEvent, issue *Issue) (domain.Event, bool) {
+	kind, ok := labelKinds[event.Action]
+	if !ok {
+		return domain.Event{}, false
+	}
+
+	res := newEvent(
+		kind,
+		strconv.FormatInt(event.ID, 10),
+		event.User.Username,
+		issue.Title,
+		issue.URL,
+		"",
+		event.CreatedAt,
+	)
+	res.Attributes = map[string]string{
+		domain.AttrLabel: event.Label.Name,
+	}
+
+	return res, true
+}
+
+// StateEventToEvent returns false for states that are not reported to users.
+func StateEventToEvent(event *StateEvent, issue *Issue) (domain.Event, bool) {
+	kind, ok := stateKinds[event.State]
+	if !ok {
+		return domain.Event{}, false
+	}
+
+	return newEvent(
+		kind,
+		strconv.FormatInt(event.ID, 10),
+		event.User.Username,
+		issue.Title,
+		issue.URL,
+		"",
+		event.CreatedAt,
+	), true
+}
+
+func CommitToEvent(commit *Commit, title string) domain.Event {
+	event := newEvent(
+		domain.KindCommit,
+		commit.ID,
+		commit.AuthorName,
+		title,
+		commit.URL,
+		preview(commit.Title),
+		commit.CommittedDate,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrCommit: commit.ShortID,
+	}
+
+	return event
+}
+
+func ReleaseToEvent(release *Release) domain.Event {
+	title := release.Name
+	if title == "" {
+		title = release.TagName
+	}
+
+	event := newEvent(
+		domain.KindRelease,
+		release.TagName,
+		release.Author.Username,
+		title,
+		release.Links.Self,
+		preview(release.Description),
+		release.ReleasedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrTag:        release.TagName,
+		domain.AttrPrerelease: strconv.FormatBool(release.Upcoming),
+	}
+
+	for _, asset := range release.Assets.Links {
+		event.Attributes[domain.AttrFilePrefix+asset.Name] = asset.URL
+	}
+
+	return event
+}
+
+func newEvent(kind, id, author, title, url, body string, createdAt time.Time) domain.Event {
+	return domain.Event{
+		Source:     domain.SourceGitLab,
+		Kind:       kind,
+		ExternalID: id,
+		Author:     author,
+		Title:      title,
+		URL:        url,
+		Body:       body,
+		CreatedAt:  createdAt,
+	}
+}
+
+func preview(text string) string {
+	rns := []rune(text)
+	if len(rns) > 200 {
+		rns = rns[:200]
+		rns = append(rns, []rune("...")...)
+
+		return string(rns)
+	}
+
+	return text
+}
@@ -0,0 +1,132 @@
+package gitlab_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRequestToEvent(t *testing.T) {
+	mr := gitlab.Issue{
+		ID:          100,
+		Title:       "Add new feature",
+		Description: "Adds the feature",
+		URL:         "https://gitlab.com/group/project/-/merge_requests/1",
+		Author:      gitlab.User{Username: "devUser"},
+		Labels:      []string{"backend", "feature"},
+		Upvotes:     3,
+		CreatedAt:   time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+	}
+
+	event := gitlab.MergeRequestToEvent(&mr)
+
+	exp := domain.Event{
+		Source:     domain.SourceGitLab,
+		Kind:       domain.KindMergeRequest,
+		ExternalID: "100",
+		Author:     "devUser",
+		Title:      "Add new feature",
+		URL:        "https://gitlab.com/group/project/-/merge_requests/1",
+		Body:       "Adds the feature",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrScore:  "3",
+			domain.AttrLabels: "backend,feature",
+		},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
+
+func TestNoteToEvent(t *testing.T) {
+	issue := gitlab.Issue{
+		Title: "Bug in feature",
+		URL:   "https://gitlab.com/group/project/-/issues/42",
+	}
+
+	note := gitlab.Note{
+		ID:        7,
+		Type:      "DiffNote",
+		Body:      "nit",
+		Author:    gitlab.User{Username: "reviewer"},
+		CreatedAt: time.Date(2025, 4, 1, 11, 0, 0, 0, time.Local),
+	}
+
+	event := gitlab.NoteToEvent(&note, &issue)
+
+	exp := domain.Event{
+		Source:     domain.SourceGitLab,
+		Kind:       domain.KindCodeComment,
+		ExternalID: "7",
+		Author:     "reviewer",
+		Title:      "Bug in feature",
+		URL:        "https://gitlab.com/group/project/-/issues/42#note_7",
+		Body:       "nit",
+		CreatedAt:  time.Date(2025, 4, 1, 11, 0, 0, 0, time.Local),
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
+
+func TestStateEventToEvent(t *testing.T) {
+	issue := gitlab.Issue{
+		Title: "Bug in feature",
+		URL:   "https://gitlab.com/group/project/-/issues/42",
+	}
+
+	event := gitlab.StateEvent{
+		ID:        3,
+		State:     "closed",
+		User:      gitlab.User{Username: "maintainer"},
+		CreatedAt: time.Date(2025, 4, 1, 13, 0, 0, 0, time.Local),
+	}
+
+	res, ok := gitlab.StateEventToEvent(&event, &issue)
+	assert.True(t, ok, "event must be reported")
+
+	exp := domain.Event{
+		Source:     domain.SourceGitLab,
+		Kind:       domain.KindClosed,
+		ExternalID: "3",
+		Author:     "maintainer",
+		Title:      "Bug in feature",
+		URL:        "https://gitlab.com/group/project/-/issues/42",
+		CreatedAt:  time.Date(2025, 4, 1, 13, 0, 0, 0, time.Local),
+	}
+	assert.Equal(t, exp, res, "invalid event")
+
+	event.State = "locked"
+
+	_, ok = gitlab.StateEventToEvent(&event, &issue)
+	assert.False(t, ok, "event must be skipped")
+}
+
+func TestReleaseToEvent(t *testing.T) {
+	release := gitlab.Release{
+		TagName:  "v1.2.0",
+		Upcoming: true,
+		Author:   gitlab.User{Username: "maintainer"},
+		Links: gitlab.Links{
+			Self: "https://gitlab.com/group/project/-/releases/v1.2.0",
+		},
+		ReleasedAt: time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local),
+	}
+
+	event := gitlab.ReleaseToEvent(&release)
+
+	exp := domain.Event{
+		Source:     domain.SourceGitLab,
+		Kind:       domain.KindRelease,
+		ExternalID: "v1.2.0",
+		Author:     "maintainer",
+		Title:      "v1.2.0",
+		URL:        "https://gitlab.com/group/project/-/releases/v1.2.0",
+		CreatedAt:  time.Date(2025, 4, 2, 9, 0, 0, 0, time.Local),
+		Attributes: map[string]string{
+			domain.AttrTag:        "v1.2.0",
+			domain.AttrPrerelease: "true",
+		},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
@@ -0,0 +1,346 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const (
+	projectURL = "%s/api/v4/projects/%s"
+
+	issues        = "issues"
+	mergeRequests = "merge_requests"
+)
+
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GitLab reports updates of projects, issues and merge requests of the
+// configured GitLab instances. Links are matched to instances by base URLs.
+type GitLab struct {
+	instances []*instance
+}
+
+// instance requests one GitLab instance with its own token.
+type instance struct {
+	client       Client
+	baseURL      string
+	projectRegex *regexp.Regexp
+	itemRegex    *regexp.Regexp
+	token        string
+	pageSize     string
+}
+
+func New(cfg *config.GitLab, client Client) *GitLab {
+	project := `(\w[\w.-]*(?:/\w[\w.-]*)+)`
+
+	instances := make([]*instance, 0)
+
+	for _, inst := range cfg.AllInstances() {
+		base := regexp.QuoteMeta(inst.BaseURL)
+
+		instances = append(instances, &instance{
+			client:       client,
+			baseURL:      inst.BaseURL,
+			projectRegex: regexp.MustCompile(`^` + base + `/` + project + `$`),
+			itemRegex: regexp.MustCompile(
+				`^` + base + `/` + project + `/-/(issues|merge_requests)/(\d+)$`,
+			),
+			token:    inst.Token,
+			pageSize: cfg.PageSize,
+		})
+	}
+
+	return &GitLab{
+		instances: instances,
+	}
+}
+
+func (g *GitLab) GetType() string {
+	return "gitlab"
+}
+
+func (g *GitLab) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	for _, inst := range g.instances {
+		if inst.match(link) {
+			return inst.getUpdates(ctx, link, from, to)
+		}
+	}
+
+	return []domain.Event{}, nil
+}
+
+func (i *instance) match(link string) bool {
+	return i.itemRegex.MatchString(link) || i.projectRegex.MatchString(link)
+}
+
+func (i *instance) getUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	if matches := i.itemRegex.FindStringSubmatch(link); matches != nil {
+		baseURL := fmt.Sprintf(
+			"%s/%s/%s",
+			i.projectAPIURL(matches[1]),
+			matches[2],
+			matches[3],
+		)
+
+		return i.getItemEvents(ctx, baseURL, matches[2] == mergeRequests, from, to)
+	}
+
+	matches := i.projectRegex.FindStringSubmatch(link)
+
+	return i.getProjectEvents(ctx, i.projectAPIURL(matches[1]), from, to)
+}
+
+func (i *instance) projectAPIURL(project string) string {
+	return fmt.Sprintf(projectURL, i.baseURL, url.PathEscape(project))
+}
+
+func (i *instance) getProjectEvents(
+	ctx context.Context,
+	baseURL string,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	params := url.Values{}
+	params.Add("created_after", from.UTC().Format(time.RFC3339))
+	params.Add("created_before", to.UTC().Format(time.RFC3339))
+	params.Add("order_by", "created_at")
+	params.Add("sort", "desc")
+
+	projectIssues, err := getAll[Issue](ctx, i, baseURL+"/"+issues, params)
+	if err != nil {
+		return nil, err
+	}
+
+	projectMergeRequests, err := getAll[Issue](ctx, i, baseURL+"/"+mergeRequests, params)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := i.getReleases(ctx, baseURL, from)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0)
+
+	for _, issue := range projectIssues {
+		if inRange(issue.CreatedAt, from, to) {
+			events = append(events, IssueToEvent(&issue))
+		}
+	}
+
+	for _, mr := range projectMergeRequests {
+		if inRange(mr.CreatedAt, from, to) {
+			events = append(events, MergeRequestToEvent(&mr))
+		}
+	}
+
+	for _, release := range releases {
+		if inRange(release.ReleasedAt, from, to) {
+			events = append(events, ReleaseToEvent(&release))
+		}
+	}
+
+	return events, nil
+}
+
+// getReleases walks releases from the newest one until a release older than from.
+func (i *instance) getReleases(ctx context.Context, baseURL string, from time.Time) ([]Release, error) {
+	params := url.Values{}
+	params.Add("order_by", "released_at")
+	params.Add("sort", "desc")
+
+	releases := make([]Release, 0)
+
+	for page := 1; ; page++ {
+		data := make([]Release, 0)
+
+		if err := i.getAndDecodePage(ctx, baseURL+"/releases", params, page, &data); err != nil {
+			return nil, err
+		}
+
+		if len(data) == 0 {
+			return releases, nil
+		}
+
+		releases = append(releases, data...)
+
+		if from.After(data[len(data)-1].ReleasedAt) {
+			return releases, nil
+		}
+	}
+}
+
+// getItemEvents collects updates of an issue or a merge request. Merge
+// requests additionally report commits.
+func (i *instance) getItemEvents(
+	ctx context.Context,
+	baseURL string,
+	isMergeRequest bool,
+	from, to time.Time,
+) ([]domain.Event, error) {
+	var item Issue
+
+	if err := i.getAndDecodeResponse(ctx, baseURL, nil, &item); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("order_by", "created_at")
+	params.Add("sort", "asc")
+
+	notes, err := getAll[Note](ctx, i, baseURL+"/notes", params)
+	if err != nil {
+		return nil, err
+	}
+
+	labelEvents, err := getAll[LabelEvent](ctx, i, baseURL+"/resource_label_events", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stateEvents, err := getAll[StateEvent](ctx, i, baseURL+"/resource_state_events", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.Event, 0)
+
+	for _, note := range notes {
+		if !note.System && inRange(note.CreatedAt, from, to) {
+			events = append(events, NoteToEvent(&note, &item))
+		}
+	}
+
+	for _, event := range labelEvents {
+		if !inRange(event.CreatedAt, from, to) {
+			continue
+		}
+
+		if res, ok := LabelEventToEvent(&event, &item); ok {
+			events = append(events, res)
+		}
+	}
+
+	for _, event := range stateEvents {
+		if !inRange(event.CreatedAt, from, to) {
+			continue
+		}
+
+		if res, ok := StateEventToEvent(&event, &item); ok {
+			events = append(events, res)
+		}
+	}
+
+	if !isMergeRequest {
+		return events, nil
+	}
+
+	commits, err := getAll[Commit](ctx, i, baseURL+"/commits", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, commit := range commits {
+		if inRange(commit.CommittedDate, from, to) {
+			events = append(events, CommitToEvent(&commit, item.Title))
+		}
+	}
+
+	return events, nil
+}
+
+// getAll walks through all pages of the list endpoint.
+func getAll[T any](ctx context.Context, i *instance, link string, params url.Values) ([]T, error) {
+	items := make([]T, 0)
+
+	for page := 1; ; page++ {
+		data := make([]T, 0)
+
+		if err := i.getAndDecodePage(ctx, link, params, page, &data); err != nil {
+			return nil, err
+		}
+
+		if len(data) == 0 {
+			return items, nil
+		}
+
+		items = append(items, data...)
+	}
+}
+
+func (i *instance) getAndDecodePage(
+	ctx context.Context,
+	link string,
+	params url.Values,
+	page int,
+	data any,
+) error {
+	pageParams := url.Values{}
+
+	for key, values := range params {
+		pageParams[key] = values
+	}
+
+	pageParams.Set("per_page", i.pageSize)
+	pageParams.Set("page", strconv.Itoa(page))
+
+	return i.getAndDecodeResponse(ctx, link, pageParams, data)
+}
+
+func (i *instance) getAndDecodeResponse(ctx context.Context, link string, params url.Values, data any) error {
+	reqURL := link
+	if len(params) != 0 {
+		reqURL = fmt.Sprintf("%s?%s", link, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request with url=%q: %w", reqURL, err)
+	}
+
+	if i.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", i.token)
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get response with url=%q: %w", reqURL, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error(
+				"failed to close response body",
+				slog.Any("error", err),
+				slog.Any("service", "gitlab client"),
+				slog.Any("url", reqURL),
+			)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return NewErrUnexpectedStatus(reqURL, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		return fmt.Errorf("failed to decode response with url=%q: %w", reqURL, err)
+	}
+
+	return nil
+}
+
+func inRange(tm, from, to time.Time) bool {
+	return !from.After(tm) && !to.Before(tm)
+}
@@ -0,0 +1,152 @@
+package gitlab_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, responses map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+
+		body := "[]"
+
+		if page := r.URL.Query().Get("page"); page == "" || page == "1" {
+			if resp, ok := responses[r.URL.EscapedPath()]; ok {
+				body = resp
+			}
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetUpdates_MergeRequest(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t, map[string]string{
+		"/api/v4/projects/group%2Fsub%2Fproject/merge_requests/1": `{
+			"title": "Add new feature",
+			"web_url": "https://gitlab.example.com/group/sub/project/-/merge_requests/1"
+		}`,
+		"/api/v4/projects/group%2Fsub%2Fproject/merge_requests/1/notes": `[
+			{"id": 1, "body": "old", "author": {"username": "a"}, "created_at": "2025-04-01T09:00:00Z"},
+			{"id": 2, "body": "approved this merge request", "system": true, "author": {"username": "a"}, "created_at": "2025-04-01T10:10:00Z"},
+			{"id": 3, "body": "new", "author": {"username": "b"}, "created_at": "2025-04-01T11:00:00Z"}
+		]`,
+		"/api/v4/projects/group%2Fsub%2Fproject/merge_requests/1/resource_label_events": `[
+			{"action": "add", "label": {"name": "bug"}, "user": {"username": "c"}, "created_at": "2025-04-01T11:10:00Z"}
+		]`,
+		"/api/v4/projects/group%2Fsub%2Fproject/merge_requests/1/resource_state_events": `[
+			{"state": "merged", "user": {"username": "c"}, "created_at": "2025-04-01T11:30:00Z"}
+		]`,
+		"/api/v4/projects/group%2Fsub%2Fproject/merge_requests/1/commits": `[
+			{"short_id": "abcdef0", "title": "fix", "author_name": "d", "committed_date": "2025-04-01T10:15:00Z"}
+		]`,
+	})
+
+	gl := gitlab.New(&config.GitLab{BaseURL: server.URL, Token: "secret", PageSize: "100"}, server.Client())
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gl.GetUpdates(context.Background(), server.URL+"/group/sub/project/-/merge_requests/1", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, domain.KindComment, events[0].Kind)
+	assert.Equal(t, domain.KindLabeled, events[1].Kind)
+	assert.Equal(t, domain.KindMerged, events[2].Kind)
+	assert.Equal(t, domain.KindCommit, events[3].Kind)
+}
+
+func TestGetUpdates_Project(t *testing.T) {
+	t.Parallel()
+
+	server := newServer(t, map[string]string{
+		"/api/v4/projects/group%2Fproject/issues": `[
+			{"title": "Bug", "author": {"username": "a"}, "created_at": "2025-04-01T11:00:00Z"}
+		]`,
+		"/api/v4/projects/group%2Fproject/merge_requests": `[
+			{"title": "Fix", "author": {"username": "b"}, "created_at": "2025-04-01T11:30:00Z"}
+		]`,
+		"/api/v4/projects/group%2Fproject/releases": `[
+			{"tag_name": "v1.1.0", "author": {"username": "m"}, "released_at": "2025-04-01T11:00:00Z"},
+			{"tag_name": "v1.0.0", "author": {"username": "m"}, "released_at": "2025-03-01T11:00:00Z"}
+		]`,
+	})
+
+	gl := gitlab.New(&config.GitLab{BaseURL: server.URL + "/", Token: "secret", PageSize: "100"}, server.Client())
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gl.GetUpdates(context.Background(), server.URL+"/group/project", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, domain.KindIssue, events[0].Kind)
+	assert.Equal(t, domain.KindMergeRequest, events[1].Kind)
+	assert.Equal(t, "v1.1.0", events[2].Attributes[domain.AttrTag])
+}
+
+func TestGetUpdates_Instances(t *testing.T) {
+	t.Parallel()
+
+	main := newServer(t, map[string]string{
+		"/api/v4/projects/group%2Fproject/issues": `[
+			{"title": "Main", "author": {"username": "a"}, "created_at": "2025-04-01T11:00:00Z"}
+		]`,
+	})
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "other", r.Header.Get("PRIVATE-TOKEN"), "every instance must get its own token")
+
+		body := "[]"
+		if r.URL.EscapedPath() == "/api/v4/projects/group%2Fproject/issues" && r.URL.Query().Get("page") == "1" {
+			body = `[{"title": "Other", "author": {"username": "b"}, "created_at": "2025-04-01T11:00:00Z"}]`
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(other.Close)
+
+	gl := gitlab.New(&config.GitLab{
+		BaseURL:   main.URL,
+		Token:     "secret",
+		Instances: map[string]string{other.URL + "/": "other"},
+		PageSize:  "100",
+	}, http.DefaultClient)
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	events, err := gl.GetUpdates(context.Background(), main.URL+"/group/project", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Main", events[0].Title)
+
+	events, err = gl.GetUpdates(context.Background(), other.URL+"/group/project", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Other", events[0].Title)
+
+	events, err = gl.GetUpdates(context.Background(), "https://gitlab.example.org/group/project", from, to)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
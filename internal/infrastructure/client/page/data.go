@@ -0,0 +1,12 @@
+package page
+
+// State keeps the selected regions of a page between checks. Regions are keyed
+// by CSS selectors, the empty selector stands for the whole page.
+type State struct {
+	Regions map[string]Region `json:"regions"`
+}
+
+type Region struct {
+	Fingerprint string `json:"fingerprint"`
+	Text        string `json:"text"`
+}
@@ -0,0 +1,87 @@
+package page
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const (
+	maxDiffLines = 10
+	maxLineSize  = 100
+)
+
+// ChangeToEvent reports a changed region. The region fingerprint identifies
+// the event, the page has no own identifiers of changes.
+func ChangeToEvent(link, title, selector, fingerprint, changes string, checkedAt time.Time) domain.Event {
+	event := domain.Event{
+		Source:     domain.SourcePage,
+		Kind:       domain.KindChange,
+		ExternalID: fingerprint,
+		Title:      title,
+		URL:        link,
+		Body:       changes,
+		CreatedAt:  checkedAt,
+	}
+
+	if selector != "" {
+		event.Attributes = map[string]string{
+			domain.AttrSelector: selector,
+		}
+	}
+
+	return event
+}
+
+// diff lists removed and added lines, keeping their order. Lines moved inside
+// the text are not reported. At most maxDiffLines lines are listed.
+func diff(oldText, newText string) string {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+
+	lines := make([]string, 0)
+	lines = append(lines, missing(oldLines, newLines, "- ")...)
+	lines = append(lines, missing(newLines, oldLines, "+ ")...)
+
+	if len(lines) > maxDiffLines {
+		rest := len(lines) - maxDiffLines
+		lines = append(lines[:maxDiffLines], fmt.Sprintf("... и ещё %d", rest))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// missing returns lines of a that are absent in b. Every line of b matches once.
+func missing(a, b []string, prefix string) []string {
+	counts := make(map[string]int, len(b))
+	for _, line := range b {
+		counts[line]++
+	}
+
+	lines := make([]string, 0)
+
+	for _, line := range a {
+		if counts[line] > 0 {
+			counts[line]--
+
+			continue
+		}
+
+		if line != "" {
+			lines = append(lines, prefix+truncateLine(line))
+		}
+	}
+
+	return lines
+}
+
+func truncateLine(line string) string {
+	rns := []rune(line)
+	if len(rns) > maxLineSize {
+		return string(rns[:maxLineSize]) + "..."
+	}
+
+	return line
+}
@@ -0,0 +1,33 @@
+package page_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/page"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeToEvent(t *testing.T) {
+	event := page.ChangeToEvent(
+		"https://example.com/news",
+		"News & updates",
+		"div.news>ul",
+		"abc",
+		"- <old>\n+ new",
+		time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+	)
+
+	exp := domain.Event{
+		Source:     domain.SourcePage,
+		Kind:       domain.KindChange,
+		ExternalID: "abc",
+		Title:      "News & updates",
+		URL:        "https://example.com/news",
+		Body:       "- <old>\n+ new",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrSelector: "div.news>ul"},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
@@ -0,0 +1,19 @@
+package page
+
+import "fmt"
+
+type ErrUnexpectedStatus struct {
+	URL    string
+	Status int
+}
+
+func NewErrUnexpectedStatus(url string, status int) error {
+	return ErrUnexpectedStatus{
+		URL:    url,
+		Status: status,
+	}
+}
+
+func (e ErrUnexpectedStatus) Error() string {
+	return fmt.Sprintf("unexpected status %d for url=%q", e.Status, e.URL)
+}
@@ -0,0 +1,188 @@
+package page
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	// maxPageSize limits the size of a downloaded page.
+	maxPageSize = 5 << 20
+
+	// maxStateText limits the text kept in the state for diffs. Fingerprints
+	// always cover the whole text.
+	maxStateText = 20000
+)
+
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Page reports changes of arbitrary web pages. Users may scope the page with
+// CSS selectors.
+type Page struct {
+	client Client
+}
+
+func New(client Client) *Page {
+	return &Page{
+		client: client,
+	}
+}
+
+func (p *Page) GetType() string {
+	return "page"
+}
+
+// GetUpdates returns nothing, as changes can be found only by comparison with
+// the previous state. See GetStatefulUpdates.
+func (p *Page) GetUpdates(_ context.Context, _ string, _, _ time.Time) ([]domain.Event, error) {
+	return []domain.Event{}, nil
+}
+
+// GetStatefulUpdates compares every selected region of the page with the
+// previous state and returns updates grouped by selectors. Regions seen for
+// the first time are saved without updates.
+func (p *Page) GetStatefulUpdates(
+	ctx context.Context,
+	link string,
+	selectors []string,
+	state []byte,
+	to time.Time,
+) (map[string][]domain.Event, []byte, error) {
+	prev := State{}
+
+	if len(state) != 0 {
+		if err := json.Unmarshal(state, &prev); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal page state: %w", err)
+		}
+	}
+
+	doc, err := p.getDocument(ctx, link)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	title := findTitle(doc)
+	if title == "" {
+		title = link
+	}
+
+	next := State{Regions: make(map[string]Region, len(selectors))}
+	updates := make(map[string][]domain.Event)
+
+	for _, selector := range selectors {
+		nodes, err := selectNodes(doc, selector)
+		if err != nil {
+			slog.Warn(
+				"skipped invalid selector",
+				slog.Any("url", link),
+				slog.Any("selector", selector),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		text := normalizedText(nodes)
+		sum := sha256.Sum256([]byte(text))
+		region := Region{
+			Fingerprint: hex.EncodeToString(sum[:]),
+			Text:        truncate(text, maxStateText),
+		}
+
+		next.Regions[selector] = region
+
+		old, ok := prev.Regions[selector]
+		if !ok || old.Fingerprint == region.Fingerprint {
+			continue
+		}
+
+		updates[selector] = []domain.Event{
+			ChangeToEvent(link, title, selector, region.Fingerprint, diff(old.Text, region.Text), to),
+		}
+	}
+
+	data, err := json.Marshal(next)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal page state: %w", err)
+	}
+
+	return updates, data, nil
+}
+
+func selectNodes(doc *html.Node, selector string) ([]*html.Node, error) {
+	if selector == "" {
+		return []*html.Node{doc}, nil
+	}
+
+	sel, err := cascadia.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile selector %q: %w", selector, err)
+	}
+
+	return sel.MatchAll(doc), nil
+}
+
+func (p *Page) getDocument(ctx context.Context, link string) (*html.Node, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request with url=%q: %w", link, err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response with url=%q: %w", link, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error(
+				"failed to close response body",
+				slog.Any("error", err),
+				slog.Any("service", "page client"),
+				slog.Any("url", link),
+			)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewErrUnexpectedStatus(link, resp.StatusCode)
+	}
+
+	reader, err := charset.NewReader(
+		io.LimitReader(resp.Body, maxPageSize),
+		resp.Header.Get("Content-Type"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect charset with url=%q: %w", link, err)
+	}
+
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page with url=%q: %w", link, err)
+	}
+
+	return doc, nil
+}
+
+func truncate(text string, size int) string {
+	rns := []rune(text)
+	if len(rns) > size {
+		return string(rns[:size])
+	}
+
+	return text
+}
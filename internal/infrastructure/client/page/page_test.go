@@ -0,0 +1,84 @@
+package page_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/page"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	firstVersion = `<html><head><title>Releases</title><style>p {}</style></head>
+<body>
+	<nav>Home | Blog</nav>
+	<main>
+		<h1>Releases</h1>
+		<p>go1.24 released</p>
+	</main>
+	<footer>Visits: 1</footer>
+</body></html>`
+
+	secondVersion = `<html><head><title>Releases</title><script>var a = 1;</script></head>
+<body>
+	<nav>Home   |   Blog</nav>
+	<main>
+		<h1>Releases</h1>
+		<p>go1.25 released</p>
+		<p>go1.24 released</p>
+	</main>
+	<footer>Visits: 2</footer>
+</body></html>`
+)
+
+func TestGetStatefulUpdates(t *testing.T) {
+	t.Parallel()
+
+	var version atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if version.Load() == 0 {
+			_, _ = w.Write([]byte(firstVersion))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(secondVersion))
+	}))
+	defer server.Close()
+
+	checker := page.New(server.Client())
+
+	selectors := []string{"", "main", "nav", "div[["}
+	tm := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	updates, state, err := checker.GetStatefulUpdates(context.Background(), server.URL, selectors, nil, tm)
+	require.NoError(t, err)
+	assert.Empty(t, updates, "first check must only save the state")
+
+	updates, state, err = checker.GetStatefulUpdates(context.Background(), server.URL, selectors, state, tm)
+	require.NoError(t, err)
+	assert.Empty(t, updates, "unchanged page must not be reported")
+
+	version.Store(1)
+
+	updates, _, err = checker.GetStatefulUpdates(context.Background(), server.URL, selectors, state, tm)
+	require.NoError(t, err)
+	require.Len(t, updates, 2, "whole page and main must change, nav only changed markup")
+
+	require.Len(t, updates["main"], 1)
+	assert.Equal(t, "main", updates["main"][0].Attributes[domain.AttrSelector])
+	assert.Equal(t, "+ go1.25 released", updates["main"][0].Body)
+
+	require.Len(t, updates[""], 1)
+	assert.Equal(t, "- Visits: 1\n+ go1.25 released\n+ Visits: 2", updates[""][0].Body)
+	assert.Empty(t, updates[""][0].Attributes)
+}
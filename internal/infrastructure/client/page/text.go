@@ -0,0 +1,93 @@
+package page
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Head:     true,
+}
+
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true,
+	atom.Br: true, atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Fieldset: true, atom.Figcaption: true, atom.Figure: true, atom.Footer: true,
+	atom.Form: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true,
+	atom.Main: true, atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Section: true, atom.Table: true, atom.Td: true, atom.Th: true, atom.Tr: true,
+	atom.Ul: true,
+}
+
+// normalizedText returns the visible text of the nodes. Every block element
+// starts a new line, whitespace inside lines is collapsed and empty lines are
+// dropped, so markup changes that do not touch the text are ignored.
+func normalizedText(nodes []*html.Node) string {
+	builder := strings.Builder{}
+
+	for _, node := range nodes {
+		writeText(&builder, node)
+		builder.WriteString("\n")
+	}
+
+	lines := make([]string, 0)
+
+	for _, line := range strings.Split(builder.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func writeText(builder *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		builder.WriteString(node.Data)
+
+		return
+
+	case html.ElementNode:
+		if skippedElements[node.DataAtom] {
+			return
+		}
+
+	case html.CommentNode, html.DoctypeNode:
+		return
+	}
+
+	block := node.Type == html.ElementNode && blockElements[node.DataAtom]
+	if block {
+		builder.WriteString("\n")
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(builder, child)
+	}
+
+	if block {
+		builder.WriteString("\n")
+	}
+}
+
+func findTitle(node *html.Node) string {
+	if node.Type == html.ElementNode && node.DataAtom == atom.Title {
+		return normalizedText([]*html.Node{node})
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if title := findTitle(child); title != "" {
+			return title
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// Crates reports new versions of Rust crates published on crates.io.
+type Crates struct {
+	client    Client
+	baseURL   string
+	linkRegex *regexp.Regexp
+}
+
+func NewCrates(cfg *config.Registry, client Client) *Crates {
+	return &Crates{
+		client:    client,
+		baseURL:   strings.TrimSuffix(cfg.CratesURL, "/"),
+		linkRegex: regexp.MustCompile(`^https://crates\.io/crates/([\w-]+)/?$`),
+	}
+}
+
+func (c *Crates) GetType() string {
+	return "crates"
+}
+
+func (c *Crates) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := c.linkRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	name := matches[1]
+
+	var data CrateVersions
+	if err := getAndDecodeResponse(
+		ctx,
+		c.client,
+		fmt.Sprintf("%s/api/v1/crates/%s/versions", c.baseURL, name),
+		&data,
+	); err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0, len(data.Versions))
+
+	for _, crateVersion := range data.Versions {
+		if crateVersion.Yanked {
+			continue
+		}
+
+		author := ""
+		if crateVersion.PublishedBy != nil {
+			author = crateVersion.PublishedBy.Login
+		}
+
+		versions = append(versions, Version{
+			Registry:    domain.SourceCrates,
+			Package:     name,
+			Number:      crateVersion.Num,
+			URL:         fmt.Sprintf("https://crates.io/crates/%s/%s", name, crateVersion.Num),
+			Author:      author,
+			PublishedAt: crateVersion.CreatedAt,
+		})
+	}
+
+	return versionsToEvents(versions, from, to), nil
+}
@@ -0,0 +1,52 @@
+package registry
+
+import "time"
+
+type GoVersionInfo struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+type NPMPackage struct {
+	Name     string                `json:"name"`
+	Time     map[string]string     `json:"time"`
+	Versions map[string]NPMVersion `json:"versions"`
+}
+
+type NPMVersion struct {
+	User NPMUser `json:"_npmUser"`
+}
+
+type NPMUser struct {
+	Name string `json:"name"`
+}
+
+type PyPIProject struct {
+	Info     PyPIInfo              `json:"info"`
+	Releases map[string][]PyPIFile `json:"releases"`
+}
+
+type PyPIInfo struct {
+	Name   string `json:"name"`
+	Author string `json:"author"`
+}
+
+type PyPIFile struct {
+	UploadTime time.Time `json:"upload_time_iso_8601"`
+	Yanked     bool      `json:"yanked"`
+}
+
+type CrateVersions struct {
+	Versions []CrateVersion `json:"versions"`
+}
+
+type CrateVersion struct {
+	Num         string     `json:"num"`
+	Yanked      bool       `json:"yanked"`
+	PublishedBy *CrateUser `json:"published_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CrateUser struct {
+	Login string `json:"login"`
+}
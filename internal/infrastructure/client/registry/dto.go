@@ -0,0 +1,18 @@
+package registry
+
+import "github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+
+func VersionToEvent(version *Version) domain.Event {
+	return domain.Event{
+		Source:     version.Registry,
+		Kind:       domain.KindVersion,
+		ExternalID: version.Number,
+		Author:     version.Author,
+		Title:      version.Package,
+		URL:        version.URL,
+		CreatedAt:  version.PublishedAt,
+		Attributes: map[string]string{
+			domain.AttrVersion: version.Number,
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package registry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionToEvent(t *testing.T) {
+	version := registry.Version{
+		Registry:    domain.SourceNPM,
+		Package:     "@scope/pkg",
+		Number:      "1.2.0",
+		URL:         "https://www.npmjs.com/package/@scope/pkg/v/1.2.0",
+		Author:      "maintainer",
+		PublishedAt: time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+	}
+
+	event := registry.VersionToEvent(&version)
+
+	exp := domain.Event{
+		Source:     domain.SourceNPM,
+		Kind:       domain.KindVersion,
+		ExternalID: "1.2.0",
+		Author:     "maintainer",
+		Title:      "@scope/pkg",
+		URL:        "https://www.npmjs.com/package/@scope/pkg/v/1.2.0",
+		CreatedAt:  time.Date(2025, 4, 1, 10, 0, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrVersion: "1.2.0"},
+	}
+	assert.Equal(t, exp, event, "invalid event")
+}
@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// maxGoVersions limits the number of the newest versions whose publication time
+// is requested, as the module proxy has no single endpoint with all of them.
+const maxGoVersions = 10
+
+// GoProxy reports new versions of Go modules using the module proxy protocol.
+type GoProxy struct {
+	client    Client
+	baseURL   string
+	linkRegex *regexp.Regexp
+}
+
+func NewGoProxy(cfg *config.Registry, client Client) *GoProxy {
+	return &GoProxy{
+		client:    client,
+		baseURL:   strings.TrimSuffix(cfg.GoProxyURL, "/"),
+		linkRegex: regexp.MustCompile(`^https://pkg\.go\.dev/([\w~-]+\.[\w.~-]+(?:/[\w.~-]+)*)$`),
+	}
+}
+
+func (g *GoProxy) GetType() string {
+	return "goproxy"
+}
+
+func (g *GoProxy) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := g.linkRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	path := matches[1]
+
+	escaped, err := module.EscapePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to escape module path %q: %w", path, err)
+	}
+
+	body, err := getResponse(ctx, g.client, fmt.Sprintf("%s/%s/@v/list", g.baseURL, escaped))
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]string, 0)
+
+	for _, number := range strings.Fields(string(body)) {
+		if semver.IsValid(number) {
+			numbers = append(numbers, number)
+		}
+	}
+
+	slices.SortFunc(numbers, func(a, b string) int {
+		return semver.Compare(b, a)
+	})
+
+	versions := make([]Version, 0, maxGoVersions)
+
+	for _, number := range numbers[:min(len(numbers), maxGoVersions)] {
+		escapedNumber, err := module.EscapeVersion(number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to escape version %q: %w", number, err)
+		}
+
+		var info GoVersionInfo
+
+		infoURL := fmt.Sprintf("%s/%s/@v/%s.info", g.baseURL, escaped, escapedNumber)
+		if err := getAndDecodeResponse(ctx, g.client, infoURL, &info); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, Version{
+			Registry:    domain.SourceGo,
+			Package:     path,
+			Number:      info.Version,
+			URL:         fmt.Sprintf("https://pkg.go.dev/%s@%s", path, info.Version),
+			PublishedAt: info.Time,
+		})
+	}
+
+	return versionsToEvents(versions, from, to), nil
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// NPM reports new versions of npm packages, including scoped ones.
+type NPM struct {
+	client    Client
+	baseURL   string
+	linkRegex *regexp.Regexp
+}
+
+func NewNPM(cfg *config.Registry, client Client) *NPM {
+	return &NPM{
+		client:    client,
+		baseURL:   strings.TrimSuffix(cfg.NPMURL, "/"),
+		linkRegex: regexp.MustCompile(`^https://www\.npmjs\.com/package/((?:@[\w.-]+/)?[\w.-]+)$`),
+	}
+}
+
+func (n *NPM) GetType() string {
+	return "npm"
+}
+
+func (n *NPM) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := n.linkRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	name := matches[1]
+
+	var pkg NPMPackage
+	if err := getAndDecodeResponse(
+		ctx,
+		n.client,
+		fmt.Sprintf("%s/%s", n.baseURL, url.PathEscape(name)),
+		&pkg,
+	); err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0, len(pkg.Versions))
+
+	for number, data := range pkg.Versions {
+		published, err := time.Parse(time.RFC3339, pkg.Time[number])
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, Version{
+			Registry:    domain.SourceNPM,
+			Package:     name,
+			Number:      number,
+			URL:         fmt.Sprintf("https://www.npmjs.com/package/%s/v/%s", name, number),
+			Author:      data.User.Name,
+			PublishedAt: published,
+		})
+	}
+
+	return versionsToEvents(versions, from, to), nil
+}
@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// PyPI reports new releases of Python packages. A release is published when its
+// first file is uploaded.
+type PyPI struct {
+	client    Client
+	baseURL   string
+	linkRegex *regexp.Regexp
+}
+
+func NewPyPI(cfg *config.Registry, client Client) *PyPI {
+	return &PyPI{
+		client:    client,
+		baseURL:   strings.TrimSuffix(cfg.PyPIURL, "/"),
+		linkRegex: regexp.MustCompile(`^https://pypi\.org/project/([\w.-]+)/?$`),
+	}
+}
+
+func (p *PyPI) GetType() string {
+	return "pypi"
+}
+
+func (p *PyPI) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	matches := p.linkRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return []domain.Event{}, nil
+	}
+
+	var project PyPIProject
+	if err := getAndDecodeResponse(
+		ctx,
+		p.client,
+		fmt.Sprintf("%s/pypi/%s/json", p.baseURL, matches[1]),
+		&project,
+	); err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0, len(project.Releases))
+
+	for number, files := range project.Releases {
+		var published time.Time
+
+		for _, file := range files {
+			if file.Yanked {
+				continue
+			}
+
+			if published.IsZero() || published.After(file.UploadTime) {
+				published = file.UploadTime
+			}
+		}
+
+		if published.IsZero() {
+			continue
+		}
+
+		versions = append(versions, Version{
+			Registry:    domain.SourcePyPI,
+			Package:     project.Info.Name,
+			Number:      number,
+			URL:         fmt.Sprintf("https://pypi.org/project/%s/%s/", project.Info.Name, number),
+			Author:      project.Info.Author,
+			PublishedAt: published,
+		})
+	}
+
+	return versionsToEvents(versions, from, to), nil
+}
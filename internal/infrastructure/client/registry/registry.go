@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const userAgent = "link-tracker (https://github.com/es-debug/backend-academy-2024-go-template)"
+
+type Client interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Version is a published version of a package in any of the registries.
+// Version is a published version. Registry holds the event source.
+type Version struct {
+	Registry    string
+	Package     string
+	Number      string
+	URL         string
+	Author      string
+	PublishedAt time.Time
+}
+
+// versionsToEvents reports versions published in the window from the oldest
+// to the newest one.
+func versionsToEvents(versions []Version, from, to time.Time) []domain.Event {
+	slices.SortFunc(versions, func(a, b Version) int {
+		return a.PublishedAt.Compare(b.PublishedAt)
+	})
+
+	events := make([]domain.Event, 0)
+
+	for _, version := range versions {
+		if from.After(version.PublishedAt) || to.Before(version.PublishedAt) {
+			continue
+		}
+
+		events = append(events, VersionToEvent(&version))
+	}
+
+	return events
+}
+
+func getAndDecodeResponse(ctx context.Context, client Client, link string, data any) error {
+	body, err := getResponse(ctx, client, link)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, data); err != nil {
+		return fmt.Errorf("failed to decode response with url=%q: %w", link, err)
+	}
+
+	return nil
+}
+
+func getResponse(ctx context.Context, client Client, link string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request with url=%q: %w", link, err)
+	}
+
+	// crates.io rejects requests without a user agent.
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response with url=%q: %w", link, err)
+	}
+
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error(
+				"failed to close response body",
+				slog.Any("error", err),
+				slog.Any("service", "registry client"),
+				slog.Any("url", link),
+			)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, NewErrUnexpectedStatus(link, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response with url=%q: %w", link, err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,145 @@
+package registry_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	from = time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to   = time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func newConfig(t *testing.T, responses map[string]string) *config.Registry {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(resp))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return &config.Registry{
+		GoProxyURL: server.URL,
+		NPMURL:     server.URL,
+		PyPIURL:    server.URL,
+		CratesURL:  server.URL,
+	}
+}
+
+func TestGoProxy_GetUpdates(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(t, map[string]string{
+		"/github.com/!burnt!sushi/toml/@v/list":        "v1.0.0\nv1.1.0\n",
+		"/github.com/!burnt!sushi/toml/@v/v1.1.0.info": `{"Version": "v1.1.0", "Time": "2025-04-01T11:00:00Z"}`,
+		"/github.com/!burnt!sushi/toml/@v/v1.0.0.info": `{"Version": "v1.0.0", "Time": "2025-03-01T11:00:00Z"}`,
+	})
+
+	checker := registry.NewGoProxy(cfg, http.DefaultClient)
+
+	events, err := checker.GetUpdates(context.Background(), "https://pkg.go.dev/github.com/BurntSushi/toml", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "v1.1.0", events[0].Attributes[domain.AttrVersion])
+	assert.Equal(t, "https://pkg.go.dev/github.com/BurntSushi/toml@v1.1.0", events[0].URL)
+}
+
+func TestNPM_GetUpdates(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(t, map[string]string{
+		"/@scope%2Fpkg": `{
+			"name": "@scope/pkg",
+			"time": {
+				"created": "2025-03-01T11:00:00Z",
+				"modified": "2025-04-01T11:30:00Z",
+				"1.0.0": "2025-03-01T11:00:00Z",
+				"1.1.0": "2025-04-01T11:00:00Z",
+				"1.2.0": "2025-04-01T11:30:00Z"
+			},
+			"versions": {
+				"1.0.0": {"_npmUser": {"name": "a"}},
+				"1.1.0": {"_npmUser": {"name": "a"}},
+				"1.2.0": {"_npmUser": {"name": "b"}}
+			}
+		}`,
+	})
+
+	checker := registry.NewNPM(cfg, http.DefaultClient)
+
+	events, err := checker.GetUpdates(context.Background(), "https://www.npmjs.com/package/@scope/pkg", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "1.1.0", events[0].Attributes[domain.AttrVersion])
+	assert.Equal(t, "b", events[1].Author)
+}
+
+func TestPyPI_GetUpdates(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(t, map[string]string{
+		"/pypi/requests/json": `{
+			"info": {"name": "requests", "author": "psf"},
+			"releases": {
+				"2.0.0": [{"upload_time_iso_8601": "2025-03-01T11:00:00Z"}],
+				"2.1.0": [
+					{"upload_time_iso_8601": "2025-04-01T11:30:00Z"},
+					{"upload_time_iso_8601": "2025-04-01T11:00:00Z"}
+				],
+				"2.2.0": [{"upload_time_iso_8601": "2025-04-01T11:00:00Z", "yanked": true}],
+				"3.0.0": []
+			}
+		}`,
+	})
+
+	checker := registry.NewPyPI(cfg, http.DefaultClient)
+
+	events, err := checker.GetUpdates(context.Background(), "https://pypi.org/project/requests/", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "2.1.0", events[0].Attributes[domain.AttrVersion])
+	assert.True(t, from.Add(time.Hour).Equal(events[0].CreatedAt))
+}
+
+func TestCrates_GetUpdates(t *testing.T) {
+	t.Parallel()
+
+	cfg := newConfig(t, map[string]string{
+		"/api/v1/crates/serde/versions": `{
+			"versions": [
+				{"num": "1.1.0", "yanked": true, "created_at": "2025-04-01T11:30:00Z"},
+				{"num": "1.0.1", "published_by": {"login": "dtolnay"}, "created_at": "2025-04-01T11:00:00Z"},
+				{"num": "1.0.0", "published_by": null, "created_at": "2025-03-01T11:00:00Z"}
+			]
+		}`,
+	})
+
+	checker := registry.NewCrates(cfg, http.DefaultClient)
+
+	events, err := checker.GetUpdates(context.Background(), "https://crates.io/crates/serde", from, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1.0.1", events[0].Attributes[domain.AttrVersion])
+	assert.Equal(t, "dtolnay", events[0].Author)
+
+	events, err = checker.GetUpdates(context.Background(), "https://www.npmjs.com/package/serde", from, to)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
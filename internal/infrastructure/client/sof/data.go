@@ -8,33 +8,49 @@ type Item struct {
 	Owner User `json:"owner"`
 }
 
-type QuestionData struct {
-	Items []Question `json:"items"`
-}
-
 type Question struct {
-	Title string `json:"title"`
+	ID               int64  `json:"question_id"`
+	Title            string `json:"title"`
+	BountyAmount     int    `json:"bounty_amount"`
+	BountyClosesDate int64  `json:"bounty_closes_date"`
 }
 
-type AnswerData struct {
-	Items []Answer `json:"items"`
+type Answer struct {
+	ID         int64  `json:"answer_id"`
+	QuestionID int64  `json:"question_id"`
+	Score      int    `json:"score"`
+	CreatedAt  int64  `json:"creation_date"`
+	Owner      User   `json:"owner"`
+	Body       string `json:"body"`
 }
 
-type Answer struct {
-	ID        int64  `json:"answer_id"`
+type Comment struct {
+	ID        int64  `json:"comment_id"`
+	PostID    int64  `json:"post_id"`
 	CreatedAt int64  `json:"creation_date"`
 	Owner     User   `json:"owner"`
 	Body      string `json:"body"`
 }
 
-type CommentData struct {
-	Items []Comment `json:"items"`
+type TimelineItem struct {
+	QuestionID    int64  `json:"question_id"`
+	Type          string `json:"timeline_type"`
+	PostType      string `json:"post_type"`
+	CreatedAt     int64  `json:"creation_date"`
+	User          User   `json:"user"`
+	Owner         User   `json:"owner"`
+	UpVoteCount   int    `json:"up_vote_count"`
+	DownVoteCount int    `json:"down_vote_count"`
 }
 
-type Comment struct {
+type Revision struct {
+	GUID      string `json:"revision_guid"`
+	PostID    int64  `json:"post_id"`
+	Type      string `json:"revision_type"`
+	Number    int    `json:"revision_number"`
+	Comment   string `json:"comment"`
 	CreatedAt int64  `json:"creation_date"`
-	Owner     User   `json:"owner"`
-	Body      string `json:"body"`
+	User      User   `json:"user"`
 }
 
 type User struct {
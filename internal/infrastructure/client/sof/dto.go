@@ -2,71 +2,183 @@ package sof
 
 import (
 	"fmt"
+	"html"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 )
 
-func AnswersToMessages(answers []Answer, questionTitle, questionLink string) []string {
-	msgs := make([]string, 0, len(answers))
+var tagRegex = regexp.MustCompile(`<[^>]+>`)
+
+func AnswersToEvents(answers []Answer, questionTitle, questionLink string) []domain.Event {
+	events := make([]domain.Event, 0, len(answers))
 
 	for _, answer := range answers {
-		builder := strings.Builder{}
-
-		builder.WriteString("<b>Новый ответ на StackOverflow!</b>\n")
-		builder.WriteString(
-			fmt.Sprintf(
-				"<b>Вопрос</b>: <a href=%q>%s</a>\n",
-				questionLink,
-				clearHTML(questionTitle),
-			),
+		event := newEvent(
+			domain.KindAnswer,
+			strconv.FormatInt(answer.ID, 10),
+			questionTitle,
+			questionLink,
+			answer.Owner.DisplayName,
+			answer.CreatedAt,
 		)
-		builder.WriteString(fmt.Sprintf("<b>Автор</b>: <i>%s</i>\n", answer.Owner.DisplayName))
-		builder.WriteString(
-			fmt.Sprintf(
-				"<b>Время создания</b>: <i>%s</i>\n",
-				time.Unix(answer.CreatedAt, 0).Format("15:04 02.01.2006"),
-			),
-		)
-		builder.WriteString(
-			fmt.Sprintf("<blockquote>%s</blockquote>\n", preview(clearHTML(answer.Body))),
+		event.Body = preview(clearHTML(answer.Body))
+		event.Attributes = map[string]string{
+			domain.AttrScore: strconv.Itoa(answer.Score),
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func CommentsToEvents(comments []Comment, questionTitle, questionLink string) []domain.Event {
+	events := make([]domain.Event, 0, len(comments))
+
+	for _, comment := range comments {
+		event := newEvent(
+			domain.KindComment,
+			strconv.FormatInt(comment.ID, 10),
+			questionTitle,
+			questionLink,
+			comment.Owner.DisplayName,
+			comment.CreatedAt,
 		)
+		event.Body = preview(clearHTML(comment.Body))
 
-		msgs = append(msgs, builder.String())
+		events = append(events, event)
 	}
 
-	return msgs
+	return events
 }
 
-func CommentsToMessages(comment []Comment, questionTitle, questionLink string) []string {
-	msgs := make([]string, 0, len(comment))
+// TimelineToEvents reports accepted answers and vote changes. Other timeline
+// items are reported by answers, comments and revisions.
+func TimelineToEvents(items []TimelineItem, questionTitle, questionLink string) []domain.Event {
+	events := make([]domain.Event, 0)
+
+	for _, item := range items {
+		author := item.User.DisplayName
+		if author == "" {
+			author = item.Owner.DisplayName
+		}
+
+		id := fmt.Sprintf("%s:%d", item.Type, item.CreatedAt)
+
+		switch item.Type {
+		case "accepted_answer":
+			events = append(events, newEvent(
+				domain.KindAccepted, id, questionTitle, questionLink, author, item.CreatedAt,
+			))
+
+		case "unaccepted_answer":
+			events = append(events, newEvent(
+				domain.KindUnaccepted, id, questionTitle, questionLink, author, item.CreatedAt,
+			))
+
+		case "vote_aggregate":
+			if item.PostType != "question" {
+				continue
+			}
+
+			event := newEvent(domain.KindVotes, id, questionTitle, questionLink, "", item.CreatedAt)
+			event.Attributes = map[string]string{
+				domain.AttrVotes: fmt.Sprintf("+%d / -%d", item.UpVoteCount, item.DownVoteCount),
+				domain.AttrScore: strconv.Itoa(item.UpVoteCount - item.DownVoteCount),
+			}
+
+			events = append(events, event)
+		}
+	}
 
-	for _, com := range comment {
-		builder := strings.Builder{}
+	return events
+}
 
-		builder.WriteString("<b>Новый комментарий на StackOverflow!</b>\n")
-		builder.WriteString(
-			fmt.Sprintf(
-				"<b>Вопрос</b>: <a href=%q>%s</a>\n",
-				questionLink,
-				clearHTML(questionTitle),
-			),
-		)
-		builder.WriteString(fmt.Sprintf("<b>Автор</b>: <i>%s</i>\n", com.Owner.DisplayName))
-		builder.WriteString(
-			fmt.Sprintf(
-				"<b>Время создания</b>: <i>%s</i>\n",
-				time.Unix(com.CreatedAt, 0).Format("15:04 02.01.2006"),
-			),
-		)
-		builder.WriteString(
-			fmt.Sprintf("<blockquote>%s</blockquote>\n", preview(clearHTML(com.Body))),
+// RevisionsToEvents reports edits of the question. Vote based revisions are
+// created when the question is closed, reopened or marked as duplicate.
+func RevisionsToEvents(revisions []Revision, questionTitle, questionLink string) []domain.Event {
+	events := make([]domain.Event, 0)
+
+	for _, revision := range revisions {
+		kind, ok := revisionKind(&revision)
+		if !ok {
+			continue
+		}
+
+		event := newEvent(
+			kind,
+			revision.GUID,
+			questionTitle,
+			questionLink,
+			revision.User.DisplayName,
+			revision.CreatedAt,
 		)
 
-		msgs = append(msgs, builder.String())
+		if kind == domain.KindEdited {
+			event.Body = preview(clearHTML(revision.Comment))
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// BountyToEvent reports the bounty started at startedAt. The API doesn't
+// provide the bounty owner, so the event has no author.
+func BountyToEvent(question *Question, questionLink string, startedAt int64) domain.Event {
+	event := newEvent(
+		domain.KindBounty,
+		fmt.Sprintf("bounty:%d", startedAt),
+		question.Title,
+		questionLink,
+		"",
+		startedAt,
+	)
+	event.Attributes = map[string]string{
+		domain.AttrBounty: strconv.Itoa(question.BountyAmount),
 	}
 
-	return msgs
+	return event
+}
+
+func revisionKind(revision *Revision) (string, bool) {
+	if revision.Type != "vote_based" {
+		// the first revision is the question itself
+		return domain.KindEdited, revision.Number > 1
+	}
+
+	comment := strings.ToLower(revision.Comment)
+
+	switch {
+	case strings.Contains(comment, "reopened"):
+		return domain.KindReopened, true
+
+	case strings.Contains(comment, "duplicate"):
+		return domain.KindDuplicate, true
+
+	case strings.Contains(comment, "closed"):
+		return domain.KindClosed, true
+
+	default:
+		return "", false
+	}
+}
+
+func newEvent(kind, id, questionTitle, questionLink, author string, createdAt int64) domain.Event {
+	return domain.Event{
+		Source:     domain.SourceStackOverflow,
+		Kind:       kind,
+		ExternalID: id,
+		Author:     author,
+		Title:      clearHTML(questionTitle),
+		URL:        questionLink,
+		CreatedAt:  time.Unix(createdAt, 0),
+	}
 }
 
 func preview(text string) string {
@@ -81,10 +193,9 @@ func preview(text string) string {
 	return text
 }
 
+// clearHTML strips tags and unescapes entities, the bot escapes text itself.
 func clearHTML(text string) string {
 	text = strings.TrimSpace(text)
 
-	re := regexp.MustCompile(`<[^>]+>`)
-
-	return re.ReplaceAllString(text, "")
+	return html.UnescapeString(tagRegex.ReplaceAllString(text, ""))
 }
@@ -1,71 +1,161 @@
 package sof_test
 
 import (
-	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/sof"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestAnswersToMessages(t *testing.T) {
+func TestAnswersToEvents(t *testing.T) {
 	t.Parallel()
 
-	questionTitle := "How to <b>test</b> my code?"
+	questionTitle := "How to <b>test</b> my &quot;code&quot;?"
 	questionLink := "https://stackoverflow.com/q/123456"
 	answers := []sof.Answer{
 		{
+			ID:        1,
+			Score:     7,
 			Owner:     sof.User{DisplayName: "Alice"},
 			CreatedAt: time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix(),
 			Body:      strings.Repeat("a", 250),
 		},
 		{
+			ID:        2,
 			Owner:     sof.User{DisplayName: "Bob"},
 			CreatedAt: time.Date(2026, 3, 30, 15, 30, 0, 0, time.Local).Unix(),
 			Body:      "This is the answer body with <i>HTML</i> content.",
 		},
 	}
 
-	msgs := sof.AnswersToMessages(answers, questionTitle, questionLink)
-	require.Len(t, msgs, 2)
-
-	exp1 := "<b>Новый ответ на StackOverflow!</b>\n" +
-		"<b>Вопрос</b>: <a href=\"" + questionLink + "\">How to test my code?</a>\n" +
-		"<b>Автор</b>: <i>Alice</i>\n" +
-		"<b>Время создания</b>: <i>15:30 30.03.2025</i>\n" +
-		fmt.Sprintf("<blockquote>%s</blockquote>\n", strings.Repeat("a", 200)+"...")
-	assert.Equal(t, exp1, msgs[0], "invalid message1")
-
-	exp2 := "<b>Новый ответ на StackOverflow!</b>\n" +
-		"<b>Вопрос</b>: <a href=\"" + questionLink + "\">How to test my code?</a>\n" +
-		"<b>Автор</b>: <i>Bob</i>\n" +
-		"<b>Время создания</b>: <i>15:30 30.03.2026</i>\n" +
-		"<blockquote>This is the answer body with HTML content.</blockquote>\n"
-	assert.Equal(t, exp2, msgs[1], "invalid message2")
+	events := sof.AnswersToEvents(answers, questionTitle, questionLink)
+	require.Len(t, events, 2)
+
+	exp1 := domain.Event{
+		Source:     domain.SourceStackOverflow,
+		Kind:       domain.KindAnswer,
+		ExternalID: "1",
+		Author:     "Alice",
+		Title:      `How to test my "code"?`,
+		URL:        questionLink,
+		Body:       strings.Repeat("a", 200) + "...",
+		CreatedAt:  time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
+		Attributes: map[string]string{domain.AttrScore: "7"},
+	}
+	assert.Equal(t, exp1, events[0], "invalid event1")
+
+	assert.Equal(t, "Bob", events[1].Author, "invalid author")
+	assert.Equal(t, "This is the answer body with HTML content.", events[1].Body, "invalid body")
 }
 
-func TestCommentsToMessages(t *testing.T) {
+func TestCommentsToEvents(t *testing.T) {
 	t.Parallel()
 
-	questionTitle := "What is <i>Go</i> language?"
 	questionLink := "https://stackoverflow.com/q/654321"
-	comment := sof.Comment{
-		Owner:     sof.User{DisplayName: "Bob"},
-		CreatedAt: time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix(),
-		Body:      "This is a comment with <script>alert('x');</script> tags.\n\n",
+	comments := []sof.Comment{
+		{
+			ID:        5,
+			Owner:     sof.User{DisplayName: "Bob"},
+			CreatedAt: time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix(),
+			Body:      "This is a comment with <script>alert('x');</script> tags.\n\n",
+		},
+	}
+
+	events := sof.CommentsToEvents(comments, "What is <i>Go</i> language?", questionLink)
+	require.Len(t, events, 1)
+
+	exp := domain.Event{
+		Source:     domain.SourceStackOverflow,
+		Kind:       domain.KindComment,
+		ExternalID: "5",
+		Author:     "Bob",
+		Title:      "What is Go language?",
+		URL:        questionLink,
+		Body:       "This is a comment with alert('x'); tags.",
+		CreatedAt:  time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
 	}
-	comments := []sof.Comment{comment}
+	assert.Equal(t, exp, events[0], "invalid event")
+}
+
+func TestTimelineToEvents(t *testing.T) {
+	t.Parallel()
+
+	questionLink := "https://stackoverflow.com/q/123456"
+	createdAt := time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix()
+	items := []sof.TimelineItem{
+		{Type: "accepted_answer", User: sof.User{DisplayName: "Alice"}, CreatedAt: createdAt},
+		{Type: "vote_aggregate", PostType: "question", UpVoteCount: 3, DownVoteCount: 1, CreatedAt: createdAt},
+		{Type: "vote_aggregate", PostType: "answer", UpVoteCount: 1, CreatedAt: createdAt},
+		{Type: "comment", User: sof.User{DisplayName: "Bob"}, CreatedAt: createdAt},
+	}
+
+	events := sof.TimelineToEvents(items, "Title", questionLink)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, domain.KindAccepted, events[0].Kind, "invalid kind1")
+	assert.Equal(t, "Alice", events[0].Author, "invalid author1")
+
+	assert.Equal(t, domain.KindVotes, events[1].Kind, "invalid kind2")
+	assert.Empty(t, events[1].Author, "votes have no author")
+	assert.Equal(t, "+3 / -1", events[1].Attributes[domain.AttrVotes], "invalid votes")
+	assert.Equal(t, "2", events[1].Attributes[domain.AttrScore], "invalid score")
+}
+
+func TestRevisionsToEvents(t *testing.T) {
+	t.Parallel()
+
+	questionLink := "https://stackoverflow.com/q/123456"
+	createdAt := time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix()
+	revisions := []sof.Revision{
+		{Type: "single_user", Number: 1, CreatedAt: createdAt},
+		{
+			GUID:      "ABC",
+			Type:      "single_user",
+			Number:    2,
+			Comment:   "added <b>details</b>",
+			User:      sof.User{DisplayName: "Alice"},
+			CreatedAt: createdAt,
+		},
+		{Type: "vote_based", Comment: "<b>Post Closed</b> as \"Duplicate\" by ...", CreatedAt: createdAt},
+		{Type: "vote_based", Comment: "<b>Post Closed</b> as \"Not suitable\" by ...", CreatedAt: createdAt},
+		{Type: "vote_based", Comment: "<b>Post Reopened</b> by ...", CreatedAt: createdAt},
+		{Type: "vote_based", Comment: "<b>Post Locked</b> by ...", CreatedAt: createdAt},
+	}
+
+	events := sof.RevisionsToEvents(revisions, "Title", questionLink)
+	require.Len(t, events, 4)
+
+	exp := domain.Event{
+		Source:     domain.SourceStackOverflow,
+		Kind:       domain.KindEdited,
+		ExternalID: "ABC",
+		Author:     "Alice",
+		Title:      "Title",
+		URL:        questionLink,
+		Body:       "added details",
+		CreatedAt:  time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local),
+	}
+	assert.Equal(t, exp, events[0], "invalid edit event")
+
+	assert.Equal(t, domain.KindDuplicate, events[1].Kind)
+	assert.Equal(t, domain.KindClosed, events[2].Kind)
+	assert.Equal(t, domain.KindReopened, events[3].Kind)
+}
+
+func TestBountyToEvent(t *testing.T) {
+	t.Parallel()
+
+	question := sof.Question{Title: "Title", BountyAmount: 50}
+	startedAt := time.Date(2025, 3, 30, 15, 30, 0, 0, time.Local).Unix()
 
-	msgs := sof.CommentsToMessages(comments, questionTitle, questionLink)
-	require.Len(t, msgs, 1)
+	event := sof.BountyToEvent(&question, "https://stackoverflow.com/q/123456", startedAt)
 
-	exp := "<b>Новый комментарий на StackOverflow!</b>\n" +
-		"<b>Вопрос</b>: <a href=\"" + questionLink + "\">What is Go language?</a>\n" +
-		"<b>Автор</b>: <i>Bob</i>\n" +
-		"<b>Время создания</b>: <i>15:30 30.03.2025</i>\n" +
-		"<blockquote>This is a comment with alert('x'); tags.</blockquote>\n"
-	assert.Equal(t, exp, msgs[0], "invalid message")
+	assert.Equal(t, domain.KindBounty, event.Kind, "invalid kind")
+	assert.Empty(t, event.Author, "bounty has no author")
+	assert.Equal(t, "50", event.Attributes[domain.AttrBounty], "invalid bounty")
+	assert.Equal(t, time.Unix(startedAt, 0), event.CreatedAt, "invalid time")
 }
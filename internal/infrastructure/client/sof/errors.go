@@ -29,3 +29,34 @@ func NewErrQuestionNotFound(id string) error {
 func (e ErrQuestionNotFound) Error() string {
 	return fmt.Sprintf("question with id=%q not found", e.ID)
 }
+
+// ErrAPI is an error response of the API, e.g. a throttle violation with
+// ID 502 or an exhausted quota.
+type ErrAPI struct {
+	URL     string
+	Status  int
+	ID      int
+	Name    string
+	Message string
+}
+
+func NewErrAPI(url string, status, id int, name, message string) error {
+	return ErrAPI{
+		URL:     url,
+		Status:  status,
+		ID:      id,
+		Name:    name,
+		Message: message,
+	}
+}
+
+func (e ErrAPI) Error() string {
+	return fmt.Sprintf(
+		"stackexchange error %d %s for url=%q with status %d: %s",
+		e.ID,
+		e.Name,
+		e.URL,
+		e.Status,
+		e.Message,
+	)
+}
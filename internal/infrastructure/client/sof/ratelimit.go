@@ -0,0 +1,80 @@
+package sof
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const source = "stackoverflow"
+
+// quota keeps the daily quota and the backoff reported by the last response.
+// The quota belongs to the key, so all sites share it.
+type quota struct {
+	mu           sync.Mutex
+	limit        domain.RateLimit
+	known        bool
+	backoffUntil time.Time
+}
+
+// update ignores responses without the quota. The quota is reset at midnight
+// UTC.
+func (q *quota) update(remaining *int, backoff int, now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if remaining != nil {
+		q.limit = domain.RateLimit{
+			Source:    source,
+			Remaining: *remaining,
+			Reset:     now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+		}
+		q.known = true
+	}
+
+	if backoff > 0 {
+		q.backoffUntil = now.Add(time.Duration(backoff) * time.Second)
+	}
+}
+
+// limits returns nothing until the first response. Nothing is left until the
+// end of the backoff, so scrapes are paused meanwhile.
+func (q *quota) limits() []domain.RateLimit {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if time.Now().Before(q.backoffUntil) {
+		return []domain.RateLimit{{Source: source, Remaining: 0, Reset: q.backoffUntil}}
+	}
+
+	if !q.known {
+		return nil
+	}
+
+	return []domain.RateLimit{q.limit}
+}
+
+// wait blocks until the backoff of the last response is over. The API bans
+// clients that ignore it.
+func (q *quota) wait(ctx context.Context) error {
+	q.mu.Lock()
+	delay := time.Until(q.backoffUntil)
+	q.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,23 +1,44 @@
 package sof
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 )
 
 const (
-	prefix             = "https://stackoverflow.com/questions/"
 	questionURL        = "https://api.stackexchange.com/2.3/questions/%s"
 	answersURL         = "https://api.stackexchange.com/2.3/questions/%s/answers"
 	answersCommentsURL = "https://api.stackexchange.com/2.3/answers/%s/comments"
 	commentsURL        = "https://api.stackexchange.com/2.3/questions/%s/comments"
+	timelineURL        = "https://api.stackexchange.com/2.3/questions/%s/timeline"
+	revisionsURL       = "https://api.stackexchange.com/2.3/posts/%s/revisions"
+
+	// maxBatchSize is the maximum number of ids accepted by the API in one request.
+	maxBatchSize = 100
+
+	// bountyDuration is the only duration of Stack Exchange bounties. The API
+	// returns the closing date only, so the start is computed from it.
+	bountyDuration = 7 * 24 * time.Hour
+)
+
+// hostRegex matches hosts of the Stack Exchange network including localized
+// and meta sites, e.g. ru.stackoverflow.com or math.meta.stackexchange.com.
+var hostRegex = regexp.MustCompile(
+	`^(?:[\w-]+\.)*(?:stackoverflow|superuser|serverfault|askubuntu|stackapps|stackexchange)\.com$|` +
+		`^(?:[\w-]+\.)?mathoverflow\.net$`,
 )
 
 type Client interface {
@@ -25,14 +46,50 @@ type Client interface {
 }
 
 type SOF struct {
-	client   Client
-	pageSize string
+	client        Client
+	questionRegex *regexp.Regexp
+	pageSize      string
+	key           string
+	quota         quota
+}
+
+// trackedLink is a link to the question with the start of its check window.
+type trackedLink struct {
+	link string
+	from time.Time
+}
+
+// items is the common wrapper of API responses.
+type items[T any] struct {
+	Items []T `json:"items"`
+	wrapper
+}
+
+// wrapper holds paging, quota and error fields of the common wrapper. Error
+// responses have no items.
+type wrapper struct {
+	HasMore        bool   `json:"has_more"`
+	QuotaRemaining *int   `json:"quota_remaining"`
+	Backoff        int    `json:"backoff"`
+	ErrorID        int    `json:"error_id"`
+	ErrorName      string `json:"error_name"`
+	ErrorMessage   string `json:"error_message"`
+}
+
+func (w *wrapper) common() *wrapper {
+	return w
+}
+
+type response interface {
+	common() *wrapper
 }
 
 func New(cfg *config.SOF, client Client) *SOF {
 	return &SOF{
-		client:   client,
-		pageSize: cfg.PageSize,
+		client:        client,
+		questionRegex: regexp.MustCompile(`^https://([\w.-]+)/questions/([^/]*)`),
+		pageSize:      cfg.PageSize,
+		key:           cfg.Key,
 	}
 }
 
@@ -40,121 +97,338 @@ func (s *SOF) GetType() string {
 	return "stackoverflow"
 }
 
-func (s *SOF) GetUpdates(link string, from, to time.Time) ([]string, error) {
-	suffix, ok := strings.CutPrefix(link, prefix)
+// RateLimits returns the daily quota reported by the last response.
+func (s *SOF) RateLimits() []domain.RateLimit {
+	return s.quota.limits()
+}
+
+func (s *SOF) GetUpdates(ctx context.Context, link string, from, to time.Time) ([]domain.Event, error) {
+	site, questionID, ok, err := s.parseLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return []domain.Event{}, nil
+	}
+
+	updates, err := s.getSiteUpdates(
+		ctx,
+		site,
+		map[string][]trackedLink{questionID: {{link: link, from: from}}},
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events, ok := updates[link]
 	if !ok {
-		return []string{}, nil
+		return nil, NewErrQuestionNotFound(questionID)
 	}
 
-	slashIdx := strings.Index(suffix, "/")
-	if slashIdx == -1 {
-		return nil, NewErrInvalidLink(link)
+	return events, nil
+}
+
+// GetBatchUpdates groups questions by site and requests up to maxBatchSize
+// questions at once. Every link is checked from its own time. Invalid links
+// and deleted questions are returned with errors.
+func (s *SOF) GetBatchUpdates(
+	ctx context.Context,
+	links map[string]time.Time,
+	to time.Time,
+) (map[string][]domain.Event, map[string]error, error) {
+	sites := make(map[string]map[string][]trackedLink)
+	linkErrs := make(map[string]error)
+
+	for link, from := range links {
+		site, questionID, ok, err := s.parseLink(link)
+		if err != nil {
+			linkErrs[link] = err
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if sites[site] == nil {
+			sites[site] = make(map[string][]trackedLink)
+		}
+
+		sites[site][questionID] = append(
+			sites[site][questionID],
+			trackedLink{link: link, from: from},
+		)
 	}
 
-	questionID := suffix[:slashIdx]
+	updates := make(map[string][]domain.Event, len(links))
 
-	title, err := s.getTitle(questionID)
+	for site, questions := range sites {
+		ids := slices.Sorted(maps.Keys(questions))
+
+		for chunk := range slices.Chunk(ids, maxBatchSize) {
+			batch := make(map[string][]trackedLink, len(chunk))
+			for _, id := range chunk {
+				batch[id] = questions[id]
+			}
+
+			siteUpdates, err := s.getSiteUpdates(ctx, site, batch, to)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for id, tracked := range batch {
+				for _, t := range tracked {
+					if _, ok := siteUpdates[t.link]; !ok {
+						linkErrs[t.link] = NewErrQuestionNotFound(id)
+					}
+				}
+			}
+
+			maps.Copy(updates, siteUpdates)
+		}
+	}
+
+	return updates, linkErrs, nil
+}
+
+// parseLink returns false for links to other sources.
+func (s *SOF) parseLink(link string) (string, string, bool, error) {
+	matches := s.questionRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return "", "", false, nil
+	}
+
+	site, ok := SiteFromHost(matches[1])
+	if !ok {
+		return "", "", false, nil
+	}
+
+	questionID := matches[2]
+	// the title slug after the ID is optional
+	if questionID == "" || link != matches[0] && !strings.HasPrefix(link, matches[0]+"/") {
+		return "", "", false, NewErrInvalidLink(link)
+	}
+
+	return site, questionID, true, nil
+}
+
+// SiteFromHost returns the API site parameter for the Stack Exchange host:
+// math.stackexchange.com is "math", ru.stackoverflow.com is "ru.stackoverflow".
+// Sites out of .com, e.g. mathoverflow.net, are named by their full hosts.
+func SiteFromHost(host string) (string, bool) {
+	if !hostRegex.MatchString(host) {
+		return "", false
+	}
+
+	if site, ok := strings.CutSuffix(host, ".stackexchange.com"); ok {
+		return site, true
+	}
+
+	if site, ok := strings.CutSuffix(host, ".com"); ok {
+		return site, true
+	}
+
+	return host, true
+}
+
+// getSiteUpdates requests updates of at most maxBatchSize questions of one site
+// and returns events by links. Links of missing questions are absent.
+func (s *SOF) getSiteUpdates(
+	ctx context.Context,
+	site string,
+	questions map[string][]trackedLink,
+	to time.Time,
+) (map[string][]domain.Event, error) {
+	ids := strings.Join(slices.Sorted(maps.Keys(questions)), ";")
+	from := earliest(questions)
+
+	data, err := getItems[Question](ctx, s, fmt.Sprintf(questionURL, ids), s.params(site))
 	if err != nil {
 		return nil, err
 	}
 
-	answers, err := s.getAnswers(questionID, from, to)
+	answers, err := getItems[Answer](
+		ctx,
+		s,
+		fmt.Sprintf(answersURL, ids),
+		s.sortedParams(site, from, to),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	answersCommens, err := s.getAnswersComments(getAnswersIDs(answers), from, to)
+	answersComments, err := s.getAnswersComments(ctx, site, answers, from, to)
 	if err != nil {
 		return nil, err
 	}
 
-	comments, err := s.getQuestionComments(questionID, from, to)
+	comments, err := getItems[Comment](
+		ctx,
+		s,
+		fmt.Sprintf(commentsURL, ids),
+		s.sortedParams(site, from, to),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	msgs := make([]string, 0, len(answers)+len(answersCommens)+len(comments))
-	msgs = append(msgs, AnswersToMessages(answers, title, link)...)
-	msgs = append(msgs, CommentsToMessages(answersCommens, title, link)...)
-	msgs = append(msgs, CommentsToMessages(comments, title, link)...)
-
-	return msgs, nil
-}
-
-func (s *SOF) getTitle(questionID string) (string, error) {
-	params := url.Values{}
-	params.Add("site", "stackoverflow")
+	timeline, err := getItems[TimelineItem](
+		ctx,
+		s,
+		fmt.Sprintf(timelineURL, ids),
+		s.windowParams(site, from, to),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	reqURL := fmt.Sprintf(questionURL, questionID) + "?" + params.Encode()
+	revisions, err := getItems[Revision](
+		ctx,
+		s,
+		fmt.Sprintf(revisionsURL, ids),
+		s.windowParams(site, from, to),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	var data QuestionData
+	answerQuestions := make(map[int64]int64, len(answers))
+	for _, answer := range answers {
+		answerQuestions[answer.ID] = answer.QuestionID
+	}
 
-	if err := s.doRequest(reqURL, &data); err != nil {
-		return "", err
+	batch := &batchUpdates{
+		answers: groupBy(answers, func(a *Answer) int64 { return a.QuestionID }),
+		answersComments: groupBy(
+			answersComments,
+			func(c *Comment) int64 { return answerQuestions[c.PostID] },
+		),
+		comments:  groupBy(comments, func(c *Comment) int64 { return c.PostID }),
+		timeline:  groupBy(timeline, func(i *TimelineItem) int64 { return i.QuestionID }),
+		revisions: groupBy(revisions, func(r *Revision) int64 { return r.PostID }),
 	}
 
-	if len(data.Items) == 0 {
-		return "", NewErrQuestionNotFound(questionID)
+	updates := make(map[string][]domain.Event)
+
+	for _, question := range data {
+		for _, tracked := range questions[strconv.FormatInt(question.ID, 10)] {
+			updates[tracked.link] = batch.events(&question, tracked, to)
+		}
 	}
 
-	return data.Items[0].Title, nil
+	return updates, nil
 }
 
-func (s *SOF) getAnswers(questionID string, from, to time.Time) ([]Answer, error) {
-	params := url.Values{}
-	params.Add("site", "stackoverflow")
-	params.Add("fromdate", fmt.Sprintf("%d", from.Unix()))
-	params.Add("todate", fmt.Sprintf("%d", to.Unix()))
-	params.Add("sort", "creation")
-	params.Add("order", "desc")
-	params.Add("filter", "withbody")
-
-	page := 1
-	answers := make([]Answer, 0)
+// batchUpdates holds updates of the batch grouped by question ids.
+type batchUpdates struct {
+	answers         map[int64][]Answer
+	answersComments map[int64][]Comment
+	comments        map[int64][]Comment
+	timeline        map[int64][]TimelineItem
+	revisions       map[int64][]Revision
+}
 
-	for {
-		params.Set("page", fmt.Sprintf("%d", page))
-		params.Set("pagesize", s.pageSize)
+func (b *batchUpdates) events(question *Question, tracked trackedLink, to time.Time) []domain.Event {
+	link, title, since := tracked.link, question.Title, tracked.from.Unix()
+
+	commentTime := func(c *Comment) int64 { return c.CreatedAt }
+
+	events := make([]domain.Event, 0)
+	events = append(events, AnswersToEvents(
+		after(b.answers[question.ID], since, func(a *Answer) int64 { return a.CreatedAt }),
+		title,
+		link,
+	)...)
+	events = append(events, CommentsToEvents(
+		after(b.answersComments[question.ID], since, commentTime),
+		title,
+		link,
+	)...)
+	events = append(events, CommentsToEvents(
+		after(b.comments[question.ID], since, commentTime),
+		title,
+		link,
+	)...)
+	events = append(events, TimelineToEvents(
+		after(b.timeline[question.ID], since, func(i *TimelineItem) int64 { return i.CreatedAt }),
+		title,
+		link,
+	)...)
+	events = append(events, RevisionsToEvents(
+		after(b.revisions[question.ID], since, func(r *Revision) int64 { return r.CreatedAt }),
+		title,
+		link,
+	)...)
+
+	if question.BountyAmount != 0 {
+		startedAt := time.Unix(question.BountyClosesDate, 0).Add(-bountyDuration)
+		if !tracked.from.After(startedAt) && !to.Before(startedAt) {
+			events = append(events, BountyToEvent(question, link, startedAt.Unix()))
+		}
+	}
 
-		reqURL := fmt.Sprintf(answersURL, questionID) + "?" + params.Encode()
+	return events
+}
 
-		var data AnswerData
+func (s *SOF) getAnswersComments(
+	ctx context.Context,
+	site string,
+	answers []Answer,
+	from, to time.Time,
+) ([]Comment, error) {
+	comments := make([]Comment, 0)
 
-		if err := s.doRequest(reqURL, &data); err != nil {
+	for chunk := range slices.Chunk(getAnswersIDs(answers), maxBatchSize) {
+		chunkComments, err := getItems[Comment](
+			ctx,
+			s,
+			fmt.Sprintf(answersCommentsURL, strings.Join(chunk, ";")),
+			s.sortedParams(site, from, to),
+		)
+		if err != nil {
 			return nil, err
 		}
 
-		if len(data.Items) == 0 {
-			return answers, nil
-		}
-
-		answers = append(answers, data.Items...)
-		page++
+		comments = append(comments, chunkComments...)
 	}
-}
 
-func (s *SOF) getAnswersComments(
-	answersIDs []string,
-	from, to time.Time,
-) ([]Comment, error) {
-	return s.getComments(fmt.Sprintf(answersCommentsURL, strings.Join(answersIDs, ";")), from, to)
+	return comments, nil
 }
 
-func (s *SOF) getQuestionComments(questionID string, from, to time.Time) ([]Comment, error) {
-	return s.getComments(fmt.Sprintf(commentsURL, questionID), from, to)
+func (s *SOF) params(site string) url.Values {
+	params := url.Values{}
+	params.Add("site", site)
+
+	if s.key != "" {
+		params.Add("key", s.key)
+	}
+
+	return params
 }
 
-func (s *SOF) getComments(link string, from, to time.Time) ([]Comment, error) {
-	params := url.Values{}
-	params.Add("site", "stackoverflow")
+func (s *SOF) windowParams(site string, from, to time.Time) url.Values {
+	params := s.params(site)
 	params.Add("fromdate", fmt.Sprintf("%d", from.Unix()))
 	params.Add("todate", fmt.Sprintf("%d", to.Unix()))
+
+	return params
+}
+
+func (s *SOF) sortedParams(site string, from, to time.Time) url.Values {
+	params := s.windowParams(site, from, to)
 	params.Add("sort", "creation")
 	params.Add("order", "desc")
 	params.Add("filter", "withbody")
 
+	return params
+}
+
+func getItems[T any](ctx context.Context, s *SOF, link string, params url.Values) ([]T, error) {
 	page := 1
-	comments := make([]Comment, 0)
+	result := make([]T, 0)
 
 	for {
 		params.Set("page", fmt.Sprintf("%d", page))
@@ -162,23 +436,31 @@ func (s *SOF) getComments(link string, from, to time.Time) ([]Comment, error) {
 
 		reqURL := link + "?" + params.Encode()
 
-		var data CommentData
+		var data items[T]
 
-		if err := s.doRequest(reqURL, &data); err != nil {
+		if err := s.doRequest(ctx, reqURL, &data); err != nil {
 			return nil, err
 		}
 
-		if len(data.Items) == 0 {
-			return comments, nil
+		result = append(result, data.Items...)
+
+		if !data.HasMore {
+			return result, nil
 		}
 
-		comments = append(comments, data.Items...)
 		page++
 	}
 }
 
-func (s *SOF) doRequest(reqURL string, data any) error {
-	req, err := http.NewRequest(http.MethodGet, reqURL, http.NoBody)
+// doRequest returns ErrAPI for error responses: an empty list of items must
+// not pass for no updates. The quota and the backoff are taken from error
+// responses as well.
+func (s *SOF) doRequest(ctx context.Context, reqURL string, data response) error {
+	if err := s.quota.wait(ctx); err != nil {
+		return fmt.Errorf("failed to wait for backoff with url=%q: %w", reqURL, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, http.NoBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request with url=%q: %w", reqURL, err)
 	}
@@ -199,10 +481,23 @@ func (s *SOF) doRequest(reqURL string, data any) error {
 		}
 	}()
 
+	successful := resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices
+
 	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		if !successful {
+			return NewErrAPI(reqURL, resp.StatusCode, 0, "", "")
+		}
+
 		return fmt.Errorf("failed to decode response with url=%q: %w", reqURL, err)
 	}
 
+	common := data.common()
+	s.quota.update(common.QuotaRemaining, common.Backoff, time.Now())
+
+	if !successful || common.ErrorID != 0 {
+		return NewErrAPI(reqURL, resp.StatusCode, common.ErrorID, common.ErrorName, common.ErrorMessage)
+	}
+
 	return nil
 }
 
@@ -215,3 +510,44 @@ func getAnswersIDs(answers []Answer) []string {
 
 	return ids
 }
+
+func earliest(questions map[string][]trackedLink) time.Time {
+	var (
+		from  time.Time
+		found bool
+	)
+
+	for _, links := range questions {
+		for _, link := range links {
+			if !found || link.from.Before(from) {
+				from = link.from
+				found = true
+			}
+		}
+	}
+
+	return from
+}
+
+func groupBy[T any](list []T, key func(*T) int64) map[int64][]T {
+	groups := make(map[int64][]T)
+
+	for i := range list {
+		k := key(&list[i])
+		groups[k] = append(groups[k], list[i])
+	}
+
+	return groups
+}
+
+func after[T any](list []T, since int64, createdAt func(*T) int64) []T {
+	result := make([]T, 0, len(list))
+
+	for i := range list {
+		if createdAt(&list[i]) >= since {
+			result = append(result, list[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,248 @@
+package sof_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/client/sof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSiteFromHost(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		host string
+		site string
+		ok   bool
+	}{
+		{host: "stackoverflow.com", site: "stackoverflow", ok: true},
+		{host: "ru.stackoverflow.com", site: "ru.stackoverflow", ok: true},
+		{host: "meta.stackoverflow.com", site: "meta.stackoverflow", ok: true},
+		{host: "superuser.com", site: "superuser", ok: true},
+		{host: "serverfault.com", site: "serverfault", ok: true},
+		{host: "askubuntu.com", site: "askubuntu", ok: true},
+		{host: "mathoverflow.net", site: "mathoverflow.net", ok: true},
+		{host: "meta.mathoverflow.net", site: "meta.mathoverflow.net", ok: true},
+		{host: "meta.stackexchange.com", site: "meta", ok: true},
+		{host: "math.stackexchange.com", site: "math", ok: true},
+		{host: "math.meta.stackexchange.com", site: "math.meta", ok: true},
+		{host: "github.com", ok: false},
+		{host: "notstackoverflow.com", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.host, func(t *testing.T) {
+			t.Parallel()
+
+			site, ok := sof.SiteFromHost(tc.host)
+			assert.Equal(t, tc.ok, ok, "wrong ok")
+			assert.Equal(t, tc.site, site, "wrong site")
+		})
+	}
+}
+
+type fakeClient struct {
+	responses map[string]string
+	requests  []*http.Request
+	status    int
+}
+
+func (c *fakeClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests = append(c.requests, req)
+
+	body := `{"items": []}`
+
+	if resp, ok := c.responses[req.URL.Path+"?page="+req.URL.Query().Get("page")]; ok {
+		body = resp
+	} else if resp, ok := c.responses[req.URL.Path]; ok && req.URL.Query().Get("page") == "1" {
+		body = resp
+	}
+
+	status := http.StatusOK
+	if c.status != 0 {
+		status = c.status
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func TestGetBatchUpdates(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+	early := from.Add(-time.Hour)
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/2.3/questions/1;2": `{"items": [
+				{"question_id": 1, "title": "First"},
+				{"question_id": 2, "title": "Second"}
+			]}`,
+			"/2.3/questions/1;2/answers": fmt.Sprintf(`{"items": [
+				{"answer_id": 10, "question_id": 1, "creation_date": %d, "owner": {"display_name": "Alice"}},
+				{"answer_id": 20, "question_id": 2, "creation_date": %d, "owner": {"display_name": "Bob"}}
+			]}`, from.Add(time.Minute).Unix(), early.Add(time.Minute).Unix()),
+			"/2.3/answers/10;20/comments": fmt.Sprintf(`{"items": [
+				{"post_id": 20, "creation_date": %d, "owner": {"display_name": "Carol"}}
+			]}`, from.Add(time.Minute).Unix()),
+			"/2.3/questions/3": `{"items": [{"question_id": 3, "title": "Third"}]}`,
+		},
+	}
+
+	s := sof.New(&config.SOF{PageSize: "100", Key: "secret"}, client)
+
+	updates, linkErrs, err := s.GetBatchUpdates(context.Background(), map[string]time.Time{
+		"https://stackoverflow.com/questions/1/first":  from,
+		"https://stackoverflow.com/questions/2/second": early,
+		"https://superuser.com/questions/3":            from,
+		"https://askubuntu.com/questions/4":            from,
+		"https://github.com/example/repo":              from,
+	}, to)
+	require.NoError(t, err)
+	require.Len(t, updates, 3)
+
+	require.Len(t, linkErrs, 1, "a deleted question must not pass for no updates")
+	assert.ErrorAs(t, linkErrs["https://askubuntu.com/questions/4"], &sof.ErrQuestionNotFound{})
+
+	require.Len(t, updates["https://stackoverflow.com/questions/1/first"], 1)
+	assert.Equal(t, "Alice", updates["https://stackoverflow.com/questions/1/first"][0].Author)
+
+	require.Len(t, updates["https://stackoverflow.com/questions/2/second"], 2)
+	assert.Equal(t, "Bob", updates["https://stackoverflow.com/questions/2/second"][0].Author)
+	assert.Equal(t, "Carol", updates["https://stackoverflow.com/questions/2/second"][1].Author)
+
+	assert.Empty(t, updates["https://superuser.com/questions/3"])
+
+	sites := make(map[string]int)
+
+	for _, req := range client.requests {
+		assert.Equal(t, "secret", req.URL.Query().Get("key"), "key must be sent")
+
+		if req.URL.Query().Get("page") == "1" {
+			sites[req.URL.Query().Get("site")]++
+		}
+	}
+
+	assert.Equal(t, 6, sites["stackoverflow"], "stackoverflow questions must share requests")
+	assert.Equal(t, 5, sites["superuser"], "superuser questions without answers skip answer comments")
+}
+
+func TestGetUpdates_Pages(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	answer := func(id int64) string {
+		return fmt.Sprintf(
+			`{"answer_id": %d, "question_id": 1, "creation_date": %d, "owner": {"display_name": "Alice"}}`,
+			id,
+			from.Add(time.Minute).Unix(),
+		)
+	}
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/2.3/questions/1":                `{"items": [{"question_id": 1, "title": "First"}], "quota_remaining": 300}`,
+			"/2.3/questions/1/answers?page=1": `{"items": [` + answer(10) + `], "has_more": true, "quota_remaining": 299}`,
+			"/2.3/questions/1/answers?page=2": `{"items": [` + answer(20) + `], "has_more": false, "quota_remaining": 298}`,
+			"/2.3/questions/1/answers?page=3": `{"items": [` + answer(30) + `]}`,
+		},
+	}
+
+	s := sof.New(&config.SOF{PageSize: "1"}, client)
+	assert.Empty(t, s.RateLimits(), "quota is unknown before the first response")
+
+	events, err := s.GetUpdates(context.Background(), "https://stackoverflow.com/questions/1", from, to)
+	require.NoError(t, err)
+	assert.Len(t, events, 2, "pages after has_more=false must not be requested")
+
+	for _, req := range client.requests {
+		assert.NotEqual(t, "3", req.URL.Query().Get("page"))
+	}
+
+	limits := s.RateLimits()
+	require.Len(t, limits, 1)
+	assert.Equal(t, "stackoverflow", limits[0].Source)
+	assert.Positive(t, limits[0].Remaining)
+	assert.True(t, limits[0].Reset.After(time.Now()))
+}
+
+func TestGetUpdates_Backoff(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		responses: map[string]string{
+			"/2.3/questions/1": `{"items": [{"question_id": 1, "title": "First"}], "backoff": 60}`,
+		},
+	}
+
+	s := sof.New(&config.SOF{PageSize: "100"}, client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := s.GetUpdates(ctx, "https://stackoverflow.com/questions/1", time.Now().Add(-time.Hour), time.Now())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, client.requests, 1, "the next request must wait for the backoff")
+
+	limits := s.RateLimits()
+	require.Len(t, limits, 1)
+	assert.Zero(t, limits[0].Remaining, "scrapes must be paused until the end of the backoff")
+	assert.True(t, limits[0].Reset.After(time.Now().Add(50*time.Second)))
+}
+
+func TestGetBatchUpdates_APIError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{name: "error status", status: http.StatusBadRequest},
+		{name: "error in successful response", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{
+				responses: map[string]string{
+					"/2.3/questions/1": `{
+						"error_id": 502,
+						"error_name": "throttle_violation",
+						"error_message": "too many requests from this IP",
+						"quota_remaining": 0
+					}`,
+				},
+				status: tt.status,
+			}
+
+			s := sof.New(&config.SOF{PageSize: "100"}, client)
+
+			updates, linkErrs, err := s.GetBatchUpdates(context.Background(), map[string]time.Time{
+				"https://stackoverflow.com/questions/1": time.Now().Add(-time.Hour),
+			}, time.Now())
+			require.ErrorAs(t, err, &sof.ErrAPI{}, "an error response must not pass for no updates")
+			assert.Nil(t, updates)
+			assert.Nil(t, linkErrs)
+
+			limits := s.RateLimits()
+			require.Len(t, limits, 1)
+			assert.Zero(t, limits[0].Remaining, "the quota of the error response must be reported")
+		})
+	}
+}
@@ -14,7 +14,12 @@ type Prometheus struct {
 	tgRequestsDurationSeconds   *prometheus.HistogramVec
 	activeLinksTotal            *prometheus.GaugeVec
 	scrapesTotal                *prometheus.CounterVec
+	scrapeTimeoutsTotal         *prometheus.CounterVec
 	scrapeDurationSeconds       *prometheus.HistogramVec
+	rateLimitRemaining          *prometheus.GaugeVec
+	scrapeQueueDepth            *prometheus.GaugeVec
+	scrapeQueueWaitSeconds      *prometheus.HistogramVec
+	lateEventsTotal             *prometheus.CounterVec
 }
 
 func NewPrometheus(name string) *Prometheus {
@@ -62,6 +67,13 @@ func NewPrometheus(name string) *Prometheus {
 		},
 		[]string{"type", "status"},
 	)
+	scrapeTimeoutsTotal := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_scrape_timeouts_total",
+			Help: "Total number of scrapes out of time",
+		},
+		[]string{"type"},
+	)
 	scrapeDurationSeconds := promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    name + "_scrape_duration_seconds",
@@ -70,6 +82,35 @@ func NewPrometheus(name string) *Prometheus {
 		},
 		[]string{"type"},
 	)
+	rateLimitRemaining := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name + "_rate_limit_remaining",
+			Help: "Number of requests left in the rate limit of a source",
+		},
+		[]string{"source"},
+	)
+	scrapeQueueDepth := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name + "_scrape_queue_depth",
+			Help: "Number of links waiting for a scrape",
+		},
+		[]string{"type"},
+	)
+	scrapeQueueWaitSeconds := promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    name + "_scrape_queue_wait_seconds",
+			Help:    "Histogram of times links wait for a scrape",
+			Buckets: prometheus.ExponentialBuckets(0.1, 4, 8),
+		},
+		[]string{"type"},
+	)
+	lateEventsTotal := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_late_events_total",
+			Help: "Total number of events found after their check window",
+		},
+		[]string{"source"},
+	)
 
 	return &Prometheus{
 		httpRequestsTotal:           httpRequestsTotal,
@@ -78,7 +119,12 @@ func NewPrometheus(name string) *Prometheus {
 		tgRequestsDurationSeconds:   tgRequestsDurationSeconds,
 		activeLinksTotal:            activeLinksTotal,
 		scrapesTotal:                scrapesTotal,
+		scrapeTimeoutsTotal:         scrapeTimeoutsTotal,
 		scrapeDurationSeconds:       scrapeDurationSeconds,
+		rateLimitRemaining:          rateLimitRemaining,
+		scrapeQueueDepth:            scrapeQueueDepth,
+		scrapeQueueWaitSeconds:      scrapeQueueWaitSeconds,
+		lateEventsTotal:             lateEventsTotal,
 	}
 }
 
@@ -114,6 +160,26 @@ func (p *Prometheus) IncScrapesTotal(scrapeType, status string) {
 	p.scrapesTotal.WithLabelValues(scrapeType, status).Inc()
 }
 
+func (p *Prometheus) IncScrapeTimeoutsTotal(scrapeType string) {
+	p.scrapeTimeoutsTotal.WithLabelValues(scrapeType).Inc()
+}
+
 func (p *Prometheus) ObserveScrapeDurationSeconds(scrapeType string, seconds float64) {
 	p.scrapeDurationSeconds.WithLabelValues(scrapeType).Observe(seconds)
 }
+
+func (p *Prometheus) SetRateLimitRemaining(source string, remaining int) {
+	p.rateLimitRemaining.WithLabelValues(source).Set(float64(remaining))
+}
+
+func (p *Prometheus) SetScrapeQueueDepth(scrapeType string, depth int) {
+	p.scrapeQueueDepth.WithLabelValues(scrapeType).Set(float64(depth))
+}
+
+func (p *Prometheus) ObserveScrapeQueueWaitSeconds(scrapeType string, seconds float64) {
+	p.scrapeQueueWaitSeconds.WithLabelValues(scrapeType).Observe(seconds)
+}
+
+func (p *Prometheus) IncLateEventsTotal(source string) {
+	p.lateEventsTotal.WithLabelValues(source).Inc()
+}
@@ -10,16 +10,24 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Builder struct {
-	db *pgxpool.Pool
+	db  Querier
+	uow *UnitOfWork
 }
 
-func NewBuilder(db *pgxpool.Pool) *Builder {
+func NewBuilder(db DB) *Builder {
 	return &Builder{
-		db: db,
+		db:  db,
+		uow: NewUnitOfWork(db),
+	}
+}
+
+// withTx returns a repository running statements in tx.
+func (s *Builder) withTx(tx Querier) *Builder {
+	return &Builder{
+		db: tx,
 	}
 }
 
@@ -65,6 +73,17 @@ func (s *Builder) DeleteChat(ctx context.Context, chatID int64) error {
 }
 
 func (s *Builder) TrackLink(ctx context.Context, link *domain.Link) (*domain.Link, error) {
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		return s.withTx(tx).trackLink(ctx, link)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (s *Builder) trackLink(ctx context.Context, link *domain.Link) error {
 	queryLink, args, err := sq.Insert("links").
 		Columns("url").
 		Values(link.URL).
@@ -72,14 +91,14 @@ func (s *Builder) TrackLink(ctx context.Context, link *domain.Link) (*domain.Lin
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build link query: %w", err)
+		return fmt.Errorf("failed to build link query: %w", err)
 	}
 
 	var id int64
 
 	err = s.db.QueryRow(ctx, queryLink, args...).Scan(&id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add link: %w", err)
+		return fmt.Errorf("failed to add link: %w", err)
 	}
 
 	link.ID = id
@@ -90,26 +109,43 @@ func (s *Builder) TrackLink(ctx context.Context, link *domain.Link) (*domain.Lin
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build link chat query: %w", err)
+		return fmt.Errorf("failed to build link chat query: %w", err)
 	}
 
 	_, err = s.db.Exec(ctx, queryLinkChat, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add link chat: %w", err)
+		return fmt.Errorf("failed to add link chat: %w", err)
 	}
 
 	if err := s.addTags(ctx, link); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.addFilters(ctx, link); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Builder) UntrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
+	var link *domain.Link
+
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		link, err = s.withTx(tx).untrackLink(ctx, chatID, url)
+
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return link, nil
 }
 
-func (s *Builder) UntrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
+func (s *Builder) untrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
 	link, err := s.GetLink(ctx, chatID, url)
 	if err != nil {
 		return nil, err
@@ -280,23 +316,178 @@ func (s *Builder) ListLinksByTag(
 	return links, nil
 }
 
-func (s *Builder) GetCheckLinks(
+// ClaimCheckLinks leases links with the next check before due to the owner. Links leased
+// by other owners are skipped until their lease expires, so replicas never
+// get the same link. The leases are dropped if the chats of the links can't be read.
+func (s *Builder) ClaimCheckLinks(
 	ctx context.Context,
-	from, to time.Time,
+	owner string,
+	due time.Time,
+	lease time.Duration,
 	limit uint,
 ) ([]*domain.CheckLink, error) {
 	var links []*domain.CheckLink
 
-	query, args, err := sq.Select("id", "url", "checked_at").
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		links, err = s.withTx(tx).claimCheckLinks(ctx, owner, due, lease, limit)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (s *Builder) claimCheckLinks(
+	ctx context.Context,
+	owner string,
+	due time.Time,
+	lease time.Duration,
+	limit uint,
+) ([]*domain.CheckLink, error) {
+	var links []*domain.CheckLink
+
+	claimed := sq.Select("id").
 		From("links").
-		Where("checked_at > ?", from).
-		Where("checked_at <= ?", to).
-		OrderBy("checked_at ASC").
+		Where("next_check_at <= ?", due).
+		Where("NOT webhook").
+		Where("(lease_until IS NULL OR lease_until < NOW())").
+		OrderBy("next_check_at ASC").
 		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := sq.Update("links").
+		Set("locked_by", owner).
+		Set("lease_until", sq.Expr("NOW() + make_interval(secs => ?)", lease.Seconds())).
+		Where(sq.Expr("id IN (?)", claimed)).
+		Suffix("RETURNING id, url, checked_at, check_interval").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build claim check links query: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim links: %w", err)
+	}
+
+	if err := pgxscan.ScanAll(&links, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan links: %w", err)
+	}
+
+	sortByCheckTime(links)
+
+	for i, link := range links {
+		chats, err := s.getChats(ctx, link.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chats: %w", err)
+		}
+
+		links[i].Chats = chats
+	}
+
+	return links, nil
+}
+
+// ClaimLink leases the link to the owner out of the schedule, webhook-fed or
+// not. It returns nil if the link is leased by another owner or not found.
+func (s *Builder) ClaimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error) {
+	var link *domain.CheckLink
+
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		link, err = s.withTx(tx).claimLink(ctx, owner, url, lease)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (s *Builder) claimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error) {
+	var links []*domain.CheckLink
+
+	claimed := sq.Select("id").
+		From("links").
+		Where(sq.Eq{"url": url}).
+		Where("(lease_until IS NULL OR lease_until < NOW())").
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := sq.Update("links").
+		Set("locked_by", owner).
+		Set("lease_until", sq.Expr("NOW() + make_interval(secs => ?)", lease.Seconds())).
+		Where(sq.Expr("id IN (?)", claimed)).
+		Suffix("RETURNING id, url, checked_at, check_interval, webhook").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build claim link query: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim link: %w", err)
+	}
+
+	if err := pgxscan.ScanAll(&links, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan link: %w", err)
+	}
+
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	chats, err := s.getChats(ctx, links[0].ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chats: %w", err)
+	}
+
+	links[0].Chats = chats
+
+	return links[0], nil
+}
+
+// ReleaseLinks drops leases of the owner only: an expired lease may be taken
+// by another owner already.
+func (s *Builder) ReleaseLinks(ctx context.Context, owner string, ids []int64) error {
+	query, args, err := sq.Update("links").
+		Set("locked_by", nil).
+		Set("lease_until", nil).
+		Where(sq.Eq{"id": ids, "locked_by": owner}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build get check links query: %w", err)
+		return fmt.Errorf("failed to build release links query: %w", err)
+	}
+
+	_, err = s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to release links: %w", err)
+	}
+
+	return nil
+}
+
+// GetLinksByURLs returns links with the given urls, webhook-fed or not.
+func (s *Builder) GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error) {
+	var links []*domain.CheckLink
+
+	query, args, err := sq.Select("id", "url", "checked_at", "check_interval").
+		From("links").
+		Where(sq.Eq{"url": urls}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get links by urls query: %w", err)
 	}
 
 	rows, err := s.db.Query(ctx, query, args...)
@@ -320,9 +511,12 @@ func (s *Builder) GetCheckLinks(
 	return links, nil
 }
 
-func (s *Builder) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error {
+// UpdateCheckTime schedules the next check of the link in interval.
+func (s *Builder) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time, interval time.Duration) error {
 	query, args, err := sq.Update("links").
 		Set("checked_at", checkedAt).
+		Set("check_interval", interval).
+		Set("next_check_at", sq.Expr("?::TIMESTAMPTZ + ?::INTERVAL", checkedAt, interval)).
 		Where(sq.Eq{"url": url}).
 		PlaceholderFormat(sq.Dollar).
 		ToSql()
@@ -338,6 +532,118 @@ func (s *Builder) UpdateCheckTime(ctx context.Context, url string, checkedAt tim
 	return nil
 }
 
+// SetLinkInterval pins the interval of the link for the chat, zero interval
+// unpins it. A shorter interval moves the next check closer.
+func (s *Builder) SetLinkInterval(ctx context.Context, chatID int64, url string, interval time.Duration) error {
+	updated := sq.Update("links_chats").
+		Set("check_interval", interval).
+		Where(sq.Eq{"chat_id": chatID}).
+		Where(sq.Expr("link_id = (?)", sq.Select("id").From("links").Where(sq.Eq{"url": url}))).
+		Suffix("RETURNING link_id")
+
+	query, args, err := sq.Update("links").
+		Prefix("WITH updated AS (?)", updated).
+		Set("next_check_at", sq.Expr("LEAST(next_check_at, NOW() + NULLIF(?::INTERVAL, INTERVAL '0'))", interval)).
+		From("updated").
+		Where("links.id = updated.link_id").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build set link interval query: %w", err)
+	}
+
+	tag, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set link interval: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NewErrLinkNotFound(url)
+	}
+
+	return nil
+}
+
+// SetLinkWebhook marks the link tracked by the chat as fed by webhooks, the
+// scheduler skips such links. The mark is shared by all chats of the link.
+func (s *Builder) SetLinkWebhook(ctx context.Context, chatID int64, url string, webhook bool) error {
+	query, args, err := sq.Update("links").
+		Set("webhook", webhook).
+		Where(sq.Eq{"url": url}).
+		Where(sq.Expr("EXISTS (?)", sq.Select("1").
+			From("links_chats").
+			Where("link_id = links.id").
+			Where(sq.Eq{"chat_id": chatID}))).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build set link webhook query: %w", err)
+	}
+
+	tag, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set link webhook: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NewErrLinkNotFound(url)
+	}
+
+	return nil
+}
+
+// GetLinkState returns nil if the checker has not saved a state for the link yet.
+func (s *Builder) GetLinkState(ctx context.Context, linkID int64, checker string) ([]byte, error) {
+	query, args, err := sq.Select("state").
+		From("link_states").
+		Where(sq.Eq{"link_id": linkID, "checker": checker}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get link state query: %w", err)
+	}
+
+	var state []byte
+
+	err = s.db.QueryRow(ctx, query, args...).Scan(&state)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *Builder) SaveLinkState(
+	ctx context.Context,
+	linkID int64,
+	checker string,
+	state []byte,
+) error {
+	query, args, err := sq.Insert("link_states").
+		Columns("link_id", "checker", "state", "updated_at").
+		Values(linkID, checker, state, sq.Expr("NOW()")).
+		Suffix(
+			"ON CONFLICT (link_id, checker) DO UPDATE " +
+				"SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at",
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build save link state query: %w", err)
+	}
+
+	_, err = s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save link state: %w", err)
+	}
+
+	return nil
+}
+
 //nolint:dupl // not a duplication
 func (s *Builder) addTags(ctx context.Context, link *domain.Link) error {
 	for _, tag := range link.Tags {
@@ -485,7 +791,7 @@ func (s *Builder) getSendImmediately(ctx context.Context, linkID, chatID int64)
 func (s *Builder) getChats(ctx context.Context, linkID int64) ([]domain.LinkChat, error) {
 	var chats []domain.LinkChat
 
-	queryChats, args, err := sq.Select("chat_id", "send_immediately").
+	queryChats, args, err := sq.Select("chat_id", "send_immediately", "check_interval").
 		From("links_chats").
 		Where(sq.Eq{"link_id": linkID}).
 		PlaceholderFormat(sq.Dollar).
@@ -521,14 +827,10 @@ func (s *Builder) getChats(ctx context.Context, linkID int64) ([]domain.LinkChat
 	return chats, nil
 }
 
-func (s *Builder) GetActiveLinks(ctx context.Context) (map[string]int, error) {
+func (s *Builder) GetActiveLinks(ctx context.Context) ([]string, error) {
 	queryBuilder := sq.
-		Select(
-			`regexp_replace(url, '^https?://([^/]+)/.*$', '\1') AS host`,
-			"COUNT(*) AS link_count",
-		).
-		From("links").
-		GroupBy("host")
+		Select("url").
+		From("links")
 
 	sqlStr, args, err := queryBuilder.ToSql()
 	if err != nil {
@@ -542,24 +844,21 @@ func (s *Builder) GetActiveLinks(ctx context.Context) (map[string]int, error) {
 
 	defer rows.Close()
 
-	activeLinks := make(map[string]int)
+	urls := make([]string, 0)
 
 	for rows.Next() {
-		var (
-			host  string
-			count int
-		)
+		var url string
 
-		if err := rows.Scan(&host, &count); err != nil {
+		if err := rows.Scan(&url); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		activeLinks[host] = count
+		urls = append(urls, url)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
-	return activeLinks, nil
+	return urls, nil
 }
@@ -0,0 +1,156 @@
+package scrapper_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application/linktype"
+	scrshed "github.com/es-debug/backend-academy-2024-go-template/internal/application/scheduler/scrapper"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/config"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/scrapper"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const leaseInterval = time.Second
+
+// recordingChecker records check times of links checked by all schedulers.
+type recordingChecker struct {
+	mu     sync.Mutex
+	checks map[string][]time.Time
+}
+
+func (c *recordingChecker) GetType() string {
+	return linktype.Page
+}
+
+func (c *recordingChecker) GetUpdates(_ context.Context, link string, _, _ time.Time) ([]domain.Event, error) {
+	c.mu.Lock()
+	c.checks[link] = append(c.checks[link], time.Now())
+	c.mu.Unlock()
+
+	// a slow source lets both schedulers claim links in the same tick
+	time.Sleep(10 * time.Millisecond)
+
+	return nil, nil
+}
+
+type nopClient struct{}
+
+func (nopClient) UpdatesPost(context.Context, *domain.Update) error {
+	return nil
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveScrapeDurationSeconds(string, float64) {}
+
+func (nopMetrics) IncScrapesTotal(string, string) {}
+
+func (nopMetrics) IncScrapeTimeoutsTotal(string) {}
+
+func (nopMetrics) SetRateLimitRemaining(string, int) {}
+
+func (nopMetrics) SetScrapeQueueDepth(string, int) {}
+
+func (nopMetrics) ObserveScrapeQueueWaitSeconds(string, float64) {}
+
+func (nopMetrics) IncLateEventsTotal(string) {}
+
+func (s *ScrapperSuite) TestClaimCheckLinks_LeaseExpires_SQL(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+	chatID := int64(1)
+
+	err := repo.RegisterChat(ctx, chatID)
+	require.NoError(t, err, "failed to register chat")
+
+	_, err = repo.TrackLink(ctx, &domain.Link{URL: "https://link1.com", ChatID: chatID})
+	require.NoError(t, err, "failed to track link")
+
+	tm := time.Now().Add(time.Minute)
+
+	claimed, err := repo.ClaimCheckLinks(ctx, "crashed", tm, time.Millisecond, 10)
+	require.NoError(t, err, "failed to claim links")
+	require.Len(t, claimed, 1, "should claim 1 link")
+
+	err = repo.ReleaseLinks(ctx, "other", []int64{claimed[0].ID})
+	require.NoError(t, err, "failed to release links")
+
+	time.Sleep(10 * time.Millisecond)
+
+	claimed, err = repo.ClaimCheckLinks(ctx, "other", tm, time.Minute, 10)
+	require.NoError(t, err, "failed to claim links")
+	require.Len(t, claimed, 1, "expired lease should be claimed again")
+}
+
+func (s *ScrapperSuite) TestLeasing_TwoSchedulers(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+	chatID := int64(1)
+
+	err := repo.RegisterChat(ctx, chatID)
+	require.NoError(t, err, "failed to register chat")
+
+	urls := make([]string, 0, 20)
+
+	for i := range cap(urls) {
+		url := fmt.Sprintf("https://example.com/page%d", i)
+		urls = append(urls, url)
+
+		_, err := repo.TrackLink(ctx, &domain.Link{URL: url, ChatID: chatID})
+		require.NoError(t, err, "failed to track link")
+	}
+
+	checker := &recordingChecker{checks: make(map[string][]time.Time)}
+
+	// at least 3 checks of every link
+	runCtx, cancel := context.WithTimeout(ctx, 3*leaseInterval+leaseInterval/2)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, replica := range []string{"replica1", "replica2"} {
+		cfg := &config.ScrapperScheduler{
+			Interval:     leaseInterval / 10,
+			MinInterval:  leaseInterval,
+			MaxInterval:  leaseInterval,
+			PageSize:     2,
+			ReplicaID:    replica,
+			LeaseTimeout: time.Minute,
+			Workers:      2,
+			QueueSize:    10,
+		}
+
+		scheduler := scrshed.NewScheduler(cfg, repo, nopClient{}, nopMetrics{}, linktype.New(&config.GitLab{}), checker)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, scheduler.Run(runCtx), "scheduler should stop")
+		}()
+	}
+
+	wg.Wait()
+
+	for _, url := range urls {
+		checks := checker.checks[url]
+		require.GreaterOrEqual(t, len(checks), 2, "link %s should be checked once per interval", url)
+
+		for i := 1; i < len(checks); i++ {
+			assert.GreaterOrEqual(
+				t,
+				checks[i].Sub(checks[i-1]),
+				leaseInterval/2,
+				"link %s should be checked by one replica at a time",
+				url,
+			)
+		}
+	}
+}
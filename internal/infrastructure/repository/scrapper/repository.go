@@ -2,6 +2,7 @@ package scrapper
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
@@ -19,13 +20,22 @@ type Repository interface {
 		chatID int64,
 		tag string,
 	) ([]*domain.Link, error)
-	GetCheckLinks(
+	ClaimCheckLinks(
 		ctx context.Context,
-		from, to time.Time,
+		owner string,
+		due time.Time,
+		lease time.Duration,
 		limit uint,
 	) ([]*domain.CheckLink, error)
-	UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error
-	GetActiveLinks(ctx context.Context) (map[string]int, error)
+	ClaimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error)
+	ReleaseLinks(ctx context.Context, owner string, ids []int64) error
+	GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error)
+	UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time, interval time.Duration) error
+	SetLinkInterval(ctx context.Context, chatID int64, url string, interval time.Duration) error
+	SetLinkWebhook(ctx context.Context, chatID int64, url string, webhook bool) error
+	GetLinkState(ctx context.Context, linkID int64, checker string) ([]byte, error)
+	SaveLinkState(ctx context.Context, linkID int64, checker string, state []byte) error
+	GetActiveLinks(ctx context.Context) ([]string, error)
 }
 
 func New(db *pgxpool.Pool, tpe string) (Repository, error) {
@@ -40,3 +50,11 @@ func New(db *pgxpool.Pool, tpe string) (Repository, error) {
 		return nil, NewErrUnknownDBType(tpe)
 	}
 }
+
+// sortByCheckTime restores the order of claimed links: UPDATE ... RETURNING
+// doesn't keep the order of the subquery.
+func sortByCheckTime(links []*domain.CheckLink) {
+	slices.SortFunc(links, func(a, b *domain.CheckLink) int {
+		return a.CheckedAt.Compare(b.CheckedAt)
+	})
+}
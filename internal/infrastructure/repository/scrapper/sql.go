@@ -9,16 +9,24 @@ import (
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SQL struct {
-	db *pgxpool.Pool
+	db  Querier
+	uow *UnitOfWork
 }
 
-func NewSQL(db *pgxpool.Pool) *SQL {
+func NewSQL(db DB) *SQL {
 	return &SQL{
-		db: db,
+		db:  db,
+		uow: NewUnitOfWork(db),
+	}
+}
+
+// withTx returns a repository running statements in tx.
+func (s *SQL) withTx(tx Querier) *SQL {
+	return &SQL{
+		db: tx,
 	}
 }
 
@@ -56,6 +64,17 @@ func (s *SQL) DeleteChat(ctx context.Context, chatID int64) error {
 }
 
 func (s *SQL) TrackLink(ctx context.Context, link *domain.Link) (*domain.Link, error) {
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		return s.withTx(tx).trackLink(ctx, link)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (s *SQL) trackLink(ctx context.Context, link *domain.Link) error {
 	queryLink := `
 		INSERT INTO links (url)
 		VALUES ($1)
@@ -67,7 +86,7 @@ func (s *SQL) TrackLink(ctx context.Context, link *domain.Link) (*domain.Link, e
 
 	err := s.db.QueryRow(ctx, queryLink, link.URL).Scan(&id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add link: %w", err)
+		return fmt.Errorf("failed to add link: %w", err)
 	}
 
 	link.ID = id
@@ -79,21 +98,38 @@ func (s *SQL) TrackLink(ctx context.Context, link *domain.Link) (*domain.Link, e
 
 	_, err = s.db.Exec(ctx, queryLinkChat, link.ID, link.ChatID, link.SendImmediately.Value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add link chat: %w", err)
+		return fmt.Errorf("failed to add link chat: %w", err)
 	}
 
 	if err := s.addTags(ctx, link); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.addFilters(ctx, link); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *SQL) UntrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
+	var link *domain.Link
+
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		link, err = s.withTx(tx).untrackLink(ctx, chatID, url)
+
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return link, nil
 }
 
-func (s *SQL) UntrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
+func (s *SQL) untrackLink(ctx context.Context, chatID int64, url string) (*domain.Link, error) {
 	link, err := s.GetLink(ctx, chatID, url)
 	if err != nil {
 		return nil, err
@@ -262,22 +298,163 @@ func (s *SQL) ListLinksByTag(
 	return links, nil
 }
 
-func (s *SQL) GetCheckLinks(
+// ClaimCheckLinks leases links with the next check before due to the owner. Links leased
+// by other owners are skipped until their lease expires, so replicas never
+// get the same link. The leases are dropped if the chats of the links can't be read.
+func (s *SQL) ClaimCheckLinks(
+	ctx context.Context,
+	owner string,
+	due time.Time,
+	lease time.Duration,
+	limit uint,
+) ([]*domain.CheckLink, error) {
+	var links []*domain.CheckLink
+
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		links, err = s.withTx(tx).claimCheckLinks(ctx, owner, due, lease, limit)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (s *SQL) claimCheckLinks(
 	ctx context.Context,
-	from, to time.Time,
+	owner string,
+	due time.Time,
+	lease time.Duration,
 	limit uint,
 ) ([]*domain.CheckLink, error) {
 	links := []*domain.CheckLink{}
 
 	queryLinks := `
-		SELECT id, url, checked_at
-		FROM links l
-		WHERE checked_at > $1 AND checked_at <= $2
-		ORDER BY checked_at ASC
-		LIMIT $3
+		UPDATE links
+		SET locked_by = $1, lease_until = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM links
+			WHERE next_check_at <= $3 AND NOT webhook AND (lease_until IS NULL OR lease_until < NOW())
+			ORDER BY next_check_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, url, checked_at, check_interval
+	`
+
+	rows, err := s.db.Query(ctx, queryLinks, owner, lease.Seconds(), due, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim links: %w", err)
+	}
+
+	if err := pgxscan.ScanAll(&links, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan links: %w", err)
+	}
+
+	sortByCheckTime(links)
+
+	for i, link := range links {
+		chats, err := s.getChats(ctx, link.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chats: %w", err)
+		}
+
+		links[i].Chats = chats
+	}
+
+	return links, nil
+}
+
+// ClaimLink leases the link to the owner out of the schedule, webhook-fed or
+// not. It returns nil if the link is leased by another owner or not found.
+func (s *SQL) ClaimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error) {
+	var link *domain.CheckLink
+
+	err := s.uow.Do(ctx, func(tx Querier) error {
+		var err error
+
+		link, err = s.withTx(tx).claimLink(ctx, owner, url, lease)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (s *SQL) claimLink(ctx context.Context, owner, url string, lease time.Duration) (*domain.CheckLink, error) {
+	links := []*domain.CheckLink{}
+
+	queryLink := `
+		UPDATE links
+		SET locked_by = $1, lease_until = NOW() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM links
+			WHERE url = $3 AND (lease_until IS NULL OR lease_until < NOW())
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, url, checked_at, check_interval, webhook
+	`
+
+	rows, err := s.db.Query(ctx, queryLink, owner, lease.Seconds(), url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim link: %w", err)
+	}
+
+	if err := pgxscan.ScanAll(&links, rows); err != nil {
+		return nil, fmt.Errorf("failed to scan link: %w", err)
+	}
+
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	chats, err := s.getChats(ctx, links[0].ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chats: %w", err)
+	}
+
+	links[0].Chats = chats
+
+	return links[0], nil
+}
+
+// ReleaseLinks drops leases of the owner only: an expired lease may be taken
+// by another owner already.
+func (s *SQL) ReleaseLinks(ctx context.Context, owner string, ids []int64) error {
+	queryLinks := `
+		UPDATE links
+		SET locked_by = NULL, lease_until = NULL
+		WHERE id = ANY($1) AND locked_by = $2
+	`
+
+	_, err := s.db.Exec(ctx, queryLinks, ids, owner)
+	if err != nil {
+		return fmt.Errorf("failed to release links: %w", err)
+	}
+
+	return nil
+}
+
+// GetLinksByURLs returns links with the given urls, webhook-fed or not.
+func (s *SQL) GetLinksByURLs(ctx context.Context, urls []string) ([]*domain.CheckLink, error) {
+	links := []*domain.CheckLink{}
+
+	queryLinks := `
+		SELECT id, url, checked_at, check_interval
+		FROM links
+		WHERE url = ANY($1)
 	`
 
-	rows, err := s.db.Query(ctx, queryLinks, from, to, limit)
+	rows, err := s.db.Query(ctx, queryLinks, urls)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get links: %w", err)
 	}
@@ -298,14 +475,15 @@ func (s *SQL) GetCheckLinks(
 	return links, nil
 }
 
-func (s *SQL) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time) error {
+// UpdateCheckTime schedules the next check of the link in interval.
+func (s *SQL) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Time, interval time.Duration) error {
 	queryLink := `
 		UPDATE links
-		SET checked_at = $1
-		WHERE url = $2
+		SET checked_at = $1, check_interval = $2, next_check_at = $1 + $2
+		WHERE url = $3
 	`
 
-	_, err := s.db.Exec(ctx, queryLink, checkedAt, url)
+	_, err := s.db.Exec(ctx, queryLink, checkedAt, interval, url)
 	if err != nil {
 		return fmt.Errorf("failed to update link: %w", err)
 	}
@@ -313,6 +491,95 @@ func (s *SQL) UpdateCheckTime(ctx context.Context, url string, checkedAt time.Ti
 	return nil
 }
 
+// SetLinkInterval pins the interval of the link for the chat, zero interval
+// unpins it. A shorter interval moves the next check closer.
+func (s *SQL) SetLinkInterval(ctx context.Context, chatID int64, url string, interval time.Duration) error {
+	query := `
+		WITH updated AS (
+			UPDATE links_chats
+			SET check_interval = $1
+			WHERE chat_id = $2 AND link_id = (SELECT id FROM links WHERE url = $3)
+			RETURNING link_id
+		)
+		UPDATE links
+		SET next_check_at = LEAST(next_check_at, NOW() + NULLIF($1, INTERVAL '0'))
+		FROM updated
+		WHERE links.id = updated.link_id
+	`
+
+	tag, err := s.db.Exec(ctx, query, interval, chatID, url)
+	if err != nil {
+		return fmt.Errorf("failed to set link interval: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NewErrLinkNotFound(url)
+	}
+
+	return nil
+}
+
+// SetLinkWebhook marks the link tracked by the chat as fed by webhooks, the
+// scheduler skips such links. The mark is shared by all chats of the link.
+func (s *SQL) SetLinkWebhook(ctx context.Context, chatID int64, url string, webhook bool) error {
+	query := `
+		UPDATE links
+		SET webhook = $1
+		WHERE url = $2 AND EXISTS (
+			SELECT 1 FROM links_chats WHERE link_id = links.id AND chat_id = $3
+		)
+	`
+
+	tag, err := s.db.Exec(ctx, query, webhook, url, chatID)
+	if err != nil {
+		return fmt.Errorf("failed to set link webhook: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NewErrLinkNotFound(url)
+	}
+
+	return nil
+}
+
+// GetLinkState returns nil if the checker has not saved a state for the link yet.
+func (s *SQL) GetLinkState(ctx context.Context, linkID int64, checker string) ([]byte, error) {
+	var state []byte
+
+	query := `
+		SELECT state
+		FROM link_states
+		WHERE link_id = $1 AND checker = $2
+	`
+
+	err := s.db.QueryRow(ctx, query, linkID, checker).Scan(&state)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get link state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *SQL) SaveLinkState(ctx context.Context, linkID int64, checker string, state []byte) error {
+	query := `
+		INSERT INTO link_states (link_id, checker, state, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (link_id, checker) DO UPDATE
+		SET state = EXCLUDED.state, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.db.Exec(ctx, query, linkID, checker, state)
+	if err != nil {
+		return fmt.Errorf("failed to save link state: %w", err)
+	}
+
+	return nil
+}
+
 func (s *SQL) addTags(ctx context.Context, link *domain.Link) error {
 	for _, tag := range link.Tags {
 		var tagID int64
@@ -436,7 +703,7 @@ func (s *SQL) getChats(ctx context.Context, linkID int64) ([]domain.LinkChat, er
 	chats := []domain.LinkChat{}
 
 	queryChats := `
-		SELECT chat_id, send_immediately
+		SELECT chat_id, send_immediately, check_interval
 		FROM links_chats
 		WHERE link_id = $1
 	`
@@ -468,14 +735,8 @@ func (s *SQL) getChats(ctx context.Context, linkID int64) ([]domain.LinkChat, er
 	return chats, nil
 }
 
-func (s *SQL) GetActiveLinks(ctx context.Context) (map[string]int, error) {
-	query := `
-		SELECT
-			regexp_replace(url, '^https?://([^/]+)/.*$', '\1') AS host,
-			COUNT(*) AS link_count
-		FROM links
-		GROUP BY host;
-	`
+func (s *SQL) GetActiveLinks(ctx context.Context) ([]string, error) {
+	query := `SELECT url FROM links;`
 
 	rows, err := s.db.Query(ctx, query)
 	if err != nil {
@@ -484,24 +745,21 @@ func (s *SQL) GetActiveLinks(ctx context.Context) (map[string]int, error) {
 
 	defer rows.Close()
 
-	activeLinks := make(map[string]int)
+	urls := make([]string, 0)
 
 	for rows.Next() {
-		var (
-			host  string
-			count int
-		)
+		var url string
 
-		if err := rows.Scan(&host, &count); err != nil {
+		if err := rows.Scan(&url); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		activeLinks[host] = count
+		urls = append(urls, url)
 	}
 
 	if rows.Err() != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", rows.Err())
 	}
 
-	return activeLinks, nil
+	return urls, nil
 }
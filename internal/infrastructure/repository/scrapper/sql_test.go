@@ -255,7 +255,7 @@ func (s *ScrapperSuite) TestListLinks_SQL(t provider.T) {
 	})
 }
 
-func (s *ScrapperSuite) TestGetCheckLinks_SQL(t provider.T) {
+func (s *ScrapperSuite) TestClaimCheckLinks_SQL(t provider.T) {
 	ctx := context.Background()
 
 	repo := scrapper.NewSQL(s.pool)
@@ -291,14 +291,14 @@ func (s *ScrapperSuite) TestGetCheckLinks_SQL(t provider.T) {
 		require.NoError(t, err)
 	}
 
-	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now().Add(-48*time.Hour))
+	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now().Add(-48*time.Hour), 0)
 	require.NoError(t, err, "failed to update check time")
-	err = repo.UpdateCheckTime(ctx, links[1].URL, time.Now().Add(-1*time.Hour))
+	err = repo.UpdateCheckTime(ctx, links[1].URL, time.Now().Add(-1*time.Hour), 0)
 	require.NoError(t, err, "failed to update check time")
 
 	tm := time.Now().Add(-time.Minute)
 
-	checkLinks, err := repo.GetCheckLinks(ctx, tm.Add(-50*time.Hour), tm, 10)
+	checkLinks, err := repo.ClaimCheckLinks(ctx, "replica", tm, time.Minute, 10)
 	require.NoError(t, err, "failed to get links")
 	require.Len(t, checkLinks, 2, "should get 2 link")
 
@@ -360,10 +360,17 @@ func (s *ScrapperSuite) TestGetCheckLinks_SQL(t provider.T) {
 		"send_immediately should be equal",
 	)
 
-	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now())
+	leased, err := repo.ClaimCheckLinks(ctx, "other", tm, time.Minute, 10)
+	require.NoError(t, err, "failed to claim links")
+	assert.Empty(t, leased, "leased links should be skipped")
+
+	err = repo.ReleaseLinks(ctx, "replica", []int64{checkLinks[0].ID, checkLinks[1].ID})
+	require.NoError(t, err, "failed to release links")
+
+	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now(), 0)
 	require.NoError(t, err, "failed to update check time")
 
-	checkLinks, err = repo.GetCheckLinks(ctx, tm.Add(-24*time.Hour), tm, 10)
+	checkLinks, err = repo.ClaimCheckLinks(ctx, "other", tm, time.Minute, 10)
 	require.NoError(t, err, "failed to get links")
 	require.Len(t, checkLinks, 1, "should get 1 link")
 
@@ -379,7 +386,7 @@ func (s *ScrapperSuite) TestGetCheckLinks_SQL(t provider.T) {
 	)
 }
 
-func (s *ScrapperSuite) TestGetCheckLinks_Pagination_SQL(t provider.T) {
+func (s *ScrapperSuite) TestClaimCheckLinks_Pagination_SQL(t provider.T) {
 	ctx := context.Background()
 
 	repo := scrapper.NewSQL(s.pool)
@@ -402,14 +409,14 @@ func (s *ScrapperSuite) TestGetCheckLinks_Pagination_SQL(t provider.T) {
 		require.NoError(t, err)
 	}
 
-	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now().Add(-48*time.Hour))
+	err = repo.UpdateCheckTime(ctx, links[0].URL, time.Now().Add(-48*time.Hour), 0)
 	require.NoError(t, err, "failed to update check time")
-	err = repo.UpdateCheckTime(ctx, links[1].URL, time.Now().Add(-1*time.Hour))
+	err = repo.UpdateCheckTime(ctx, links[1].URL, time.Now().Add(-1*time.Hour), 0)
 	require.NoError(t, err, "failed to update check time")
 
 	tm := time.Now().Add(-time.Minute)
 
-	checkLinks, err := repo.GetCheckLinks(ctx, tm.Add(-50*time.Hour), tm, 1)
+	checkLinks, err := repo.ClaimCheckLinks(ctx, "replica", tm, time.Minute, 1)
 	require.NoError(t, err, "failed to get links")
 	require.Len(t, checkLinks, 1, "should get 2 link")
 
@@ -452,8 +459,152 @@ func (s *ScrapperSuite) TestGetActiveLinks_SQL(t provider.T) {
 
 	result, err := repo.GetActiveLinks(ctx)
 	require.NoError(t, err, "failed to get active links")
-	require.Len(t, result, 2, "should return 2 active links")
+	assert.ElementsMatch(t, urls, result, "should return all links")
+}
+
+func (s *ScrapperSuite) TestLinkState_SQL(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+
+	var linkID int64
+
+	err := s.pool.QueryRow(
+		ctx,
+		`INSERT INTO links (url, checked_at) VALUES ($1, NOW()) RETURNING id`,
+		"https://go.dev/doc/devel/release",
+	).Scan(&linkID)
+	require.NoError(t, err, "failed to insert link")
+
+	state, err := repo.GetLinkState(ctx, linkID, "page")
+	require.NoError(t, err, "failed to get link state")
+	assert.Nil(t, state, "state should be empty")
+
+	err = repo.SaveLinkState(ctx, linkID, "page", []byte(`{"hash": "1"}`))
+	require.NoError(t, err, "failed to save link state")
+
+	err = repo.SaveLinkState(ctx, linkID, "page", []byte(`{"hash": "2"}`))
+	require.NoError(t, err, "failed to update link state")
+
+	state, err = repo.GetLinkState(ctx, linkID, "page")
+	require.NoError(t, err, "failed to get link state")
+	assert.JSONEq(t, `{"hash": "2"}`, string(state), "state should be updated")
+
+	state, err = repo.GetLinkState(ctx, linkID, "feed")
+	require.NoError(t, err, "failed to get link state")
+	assert.Nil(t, state, "state of another checker should be empty")
+}
+
+func (s *ScrapperSuite) TestWebhookLinks_SQL(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+	chatID := int64(1)
+
+	err := repo.RegisterChat(ctx, chatID)
+	require.NoError(t, err, "failed to register chat")
+
+	links := []*domain.Link{
+		{URL: "https://github.com/owner/repo", ChatID: chatID, Tags: []string{"t1"}},
+		{URL: "https://github.com/owner/other", ChatID: chatID},
+	}
+
+	for _, l := range links {
+		_, err := repo.TrackLink(ctx, l)
+		require.NoError(t, err)
+	}
+
+	err = repo.SetLinkWebhook(ctx, chatID, links[0].URL, true)
+	require.NoError(t, err, "failed to mark link")
+
+	err = repo.SetLinkWebhook(ctx, chatID+1, links[1].URL, true)
+	require.ErrorAs(t, err, &scrapper.ErrLinkNotFound{}, "link of another chat should not be found")
+
+	tm := time.Now().Add(time.Minute)
+
+	checkLinks, err := repo.ClaimCheckLinks(ctx, "replica", tm, time.Minute, 10)
+	require.NoError(t, err, "failed to get links")
+	require.Len(t, checkLinks, 1, "webhook link should not be checked")
+	assert.Equal(t, links[1].URL, checkLinks[0].URL, "link url should be equal")
+
+	webhookLinks, err := repo.GetLinksByURLs(ctx, []string{links[0].URL, "https://github.com/owner/untracked"})
+	require.NoError(t, err, "failed to get links")
+	require.Len(t, webhookLinks, 1, "should get tracked link only")
+	assert.Equal(t, links[0].URL, webhookLinks[0].URL, "link url should be equal")
+	require.Len(t, webhookLinks[0].Chats, 1, "link should have 1 chat")
+	assert.Equal(t, links[0].Tags, webhookLinks[0].Chats[0].Tags, "link tags should be equal")
+}
+
+func (s *ScrapperSuite) TestSetLinkInterval_SQL(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+	chatID := int64(1)
+
+	err := repo.RegisterChat(ctx, chatID)
+	require.NoError(t, err, "failed to register chat")
+
+	link := &domain.Link{URL: "https://link1.com", ChatID: chatID}
+
+	_, err = repo.TrackLink(ctx, link)
+	require.NoError(t, err)
+
+	err = repo.UpdateCheckTime(ctx, link.URL, time.Now(), 24*time.Hour)
+	require.NoError(t, err, "failed to update check time")
+
+	checkLinks, err := repo.ClaimCheckLinks(ctx, "replica", time.Now().Add(10*time.Minute), time.Minute, 10)
+	require.NoError(t, err, "failed to claim links")
+	assert.Empty(t, checkLinks, "link should not be due")
+
+	err = repo.SetLinkInterval(ctx, chatID, link.URL, 5*time.Minute)
+	require.NoError(t, err, "failed to set link interval")
+
+	checkLinks, err = repo.ClaimCheckLinks(ctx, "replica", time.Now().Add(10*time.Minute), time.Minute, 10)
+	require.NoError(t, err, "failed to claim links")
+	require.Len(t, checkLinks, 1, "pinned interval should move the next check")
+	assert.Equal(t, 24*time.Hour, checkLinks[0].Interval, "adaptive interval should be kept")
+	require.Len(t, checkLinks[0].Chats, 1, "link should have 1 chat")
+	assert.Equal(t, 5*time.Minute, checkLinks[0].Chats[0].Interval, "pinned interval should be equal")
+
+	err = repo.SetLinkInterval(ctx, chatID, link.URL, 0)
+	require.NoError(t, err, "failed to unpin link interval")
+
+	err = repo.SetLinkInterval(ctx, chatID, "https://link2.com", time.Minute)
+	require.ErrorAs(t, err, &scrapper.ErrLinkNotFound{}, "link should not be found")
+}
+
+func (s *ScrapperSuite) TestClaimLink_SQL(t provider.T) {
+	ctx := context.Background()
+	repo := scrapper.NewSQL(s.pool)
+	chatID := int64(1)
+
+	err := repo.RegisterChat(ctx, chatID)
+	require.NoError(t, err, "failed to register chat")
+
+	link := &domain.Link{URL: "https://github.com/owner/repo", ChatID: chatID, Tags: []string{"t1"}}
+
+	_, err = repo.TrackLink(ctx, link)
+	require.NoError(t, err, "failed to track link")
+
+	err = repo.SetLinkWebhook(ctx, chatID, link.URL, true)
+	require.NoError(t, err, "failed to mark link")
+
+	claimed, err := repo.ClaimLink(ctx, "api", link.URL, time.Minute)
+	require.NoError(t, err, "failed to claim link")
+	require.NotNil(t, claimed, "webhook link should be claimed on demand")
+	assert.True(t, claimed.Webhook, "link should be webhook-fed")
+	require.Len(t, claimed.Chats, 1, "link should have 1 chat")
+	assert.Equal(t, link.Tags, claimed.Chats[0].Tags, "link tags should be equal")
+
+	leased, err := repo.ClaimLink(ctx, "replica", link.URL, time.Minute)
+	require.NoError(t, err, "failed to claim link")
+	assert.Nil(t, leased, "leased link should not be claimed")
+
+	missing, err := repo.ClaimLink(ctx, "replica", "https://github.com/owner/untracked", time.Minute)
+	require.NoError(t, err, "failed to claim link")
+	assert.Nil(t, missing, "untracked link should not be claimed")
+
+	err = repo.ReleaseLinks(ctx, "api", []int64{claimed.ID})
+	require.NoError(t, err, "failed to release link")
 
-	assert.Equal(t, 2, result["github.com"], "github.com count should be 2")
-	assert.Equal(t, 1, result["stackoverflow.com"], "stackoverflow.com count should be 1")
+	released, err := repo.ClaimLink(ctx, "replica", link.URL, time.Minute)
+	require.NoError(t, err, "failed to claim link")
+	assert.NotNil(t, released, "released link should be claimed")
 }
@@ -0,0 +1,66 @@
+package scrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Querier runs statements on the pool or in a transaction.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// DB is satisfied by *pgxpool.Pool.
+type DB interface {
+	Querier
+	TxBeginner
+}
+
+// UnitOfWork makes a multi-statement operation atomic, so a failure midway
+// leaves nothing behind and the operation can be retried.
+type UnitOfWork struct {
+	db TxBeginner
+}
+
+func NewUnitOfWork(db TxBeginner) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do commits the transaction if fn succeeds and rolls it back otherwise.
+func (u *UnitOfWork) Do(ctx context.Context, fn func(tx Querier) error) error {
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		// the rollback must run even if ctx is canceled
+		err := tx.Rollback(context.WithoutCancel(ctx))
+		if err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			slog.Error("failed to rollback transaction", slog.Any("error", err))
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
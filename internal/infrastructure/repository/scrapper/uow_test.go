@@ -0,0 +1,263 @@
+package scrapper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure/repository/scrapper"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trackLinkSteps are link, link chat, tag, link tag, filter and link filter.
+const trackLinkSteps = 6
+
+var (
+	errInjected    = errors.New("injected failure")
+	errOutsideOfTx = errors.New("statement outside of transaction")
+
+	newRepositories = map[string]func(db scrapper.DB) scrapper.Repository{
+		"sql":     func(db scrapper.DB) scrapper.Repository { return scrapper.NewSQL(db) },
+		"builder": func(db scrapper.DB) scrapper.Repository { return scrapper.NewBuilder(db) },
+	}
+)
+
+// fakeTx fails the failAt-th statement, counting from 1.
+type fakeTx struct {
+	pgx.Tx
+	failAt     int
+	statements int
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) step() error {
+	tx.statements++
+
+	if tx.statements == tx.failAt {
+		return errInjected
+	}
+
+	return nil
+}
+
+func (tx *fakeTx) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.NewCommandTag("INSERT 0 1"), tx.step()
+}
+
+func (tx *fakeTx) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, tx.step()
+}
+
+func (tx *fakeTx) QueryRow(context.Context, string, ...any) pgx.Row {
+	return fakeRow{err: tx.step()}
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	if tx.committed {
+		return pgx.ErrTxClosed
+	}
+
+	tx.rolledBack = true
+
+	return nil
+}
+
+type fakeRow struct {
+	err error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if id, ok := dest[0].(*int64); ok {
+		*id = 1
+	}
+
+	return nil
+}
+
+// fakeDB runs statements in transactions only.
+type fakeDB struct {
+	tx       *fakeTx
+	beginErr error
+}
+
+func (db *fakeDB) Begin(context.Context) (pgx.Tx, error) {
+	if db.beginErr != nil {
+		return nil, db.beginErr
+	}
+
+	return db.tx, nil
+}
+
+func (db *fakeDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errOutsideOfTx
+}
+
+func (db *fakeDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errOutsideOfTx
+}
+
+func (db *fakeDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return fakeRow{err: errOutsideOfTx}
+}
+
+func newTrackedLink(chatID int64) *domain.Link {
+	return &domain.Link{
+		URL:             "https://example.com",
+		ChatID:          chatID,
+		Tags:            []string{"news"},
+		Filters:         []string{"lang=en"},
+		SendImmediately: domain.NewNull(true),
+	}
+}
+
+func TestTrackLink_Commits(t *testing.T) {
+	t.Parallel()
+
+	for name, newRepo := range newRepositories {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &fakeDB{tx: &fakeTx{}}
+
+			_, err := newRepo(db).TrackLink(context.Background(), newTrackedLink(1))
+			require.NoError(t, err, "failed to track link")
+
+			assert.Equal(t, trackLinkSteps, db.tx.statements, "all statements should run in the transaction")
+			assert.True(t, db.tx.committed, "transaction should be committed")
+			assert.False(t, db.tx.rolledBack, "transaction should not be rolled back")
+		})
+	}
+}
+
+func TestTrackLink_RollsBackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	for name, newRepo := range newRepositories {
+		for failAt := 1; failAt <= trackLinkSteps; failAt++ {
+			t.Run(fmt.Sprintf("%s/step %d", name, failAt), func(t *testing.T) {
+				t.Parallel()
+
+				db := &fakeDB{tx: &fakeTx{failAt: failAt}}
+
+				link, err := newRepo(db).TrackLink(context.Background(), newTrackedLink(1))
+				require.ErrorIs(t, err, errInjected, "track should fail")
+				assert.Nil(t, link, "link should not be returned")
+
+				assert.Equal(t, failAt, db.tx.statements, "statements after the failure should not run")
+				assert.False(t, db.tx.committed, "transaction should not be committed")
+				assert.True(t, db.tx.rolledBack, "transaction should be rolled back")
+			})
+		}
+	}
+}
+
+func TestUnitOfWork_Do_BeginError(t *testing.T) {
+	t.Parallel()
+
+	uow := scrapper.NewUnitOfWork(&fakeDB{beginErr: errInjected})
+
+	err := uow.Do(context.Background(), func(scrapper.Querier) error {
+		t.Fatal("fn should not be called")
+
+		return nil
+	})
+	require.ErrorIs(t, err, errInjected, "begin error should be returned")
+}
+
+// failStep makes statements of the kind on the table fail until the returned
+// function is called.
+func failStep(ctx context.Context, t provider.T, s *ScrapperSuite, kind, table string) func() {
+	_, err := s.pool.Exec(ctx, `
+		CREATE OR REPLACE FUNCTION fail_step() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'injected failure';
+		END
+		$$ LANGUAGE plpgsql
+	`)
+	require.NoError(t, err, "failed to create trigger function")
+
+	_, err = s.pool.Exec(ctx, "CREATE TRIGGER fail_step BEFORE "+kind+" ON "+table+
+		" FOR EACH ROW EXECUTE FUNCTION fail_step()")
+	require.NoError(t, err, "failed to create trigger")
+
+	return func() {
+		_, err := s.pool.Exec(ctx, "DROP TRIGGER fail_step ON "+table)
+		require.NoError(t, err, "failed to drop trigger")
+	}
+}
+
+func countRows(ctx context.Context, t provider.T, s *ScrapperSuite, table string, chatID int64) int {
+	var count int
+
+	err := s.pool.QueryRow(ctx, "SELECT COUNT(*) FROM "+table+" WHERE chat_id = $1", chatID).Scan(&count)
+	require.NoError(t, err, "failed to count %s", table)
+
+	return count
+}
+
+func (s *ScrapperSuite) TestTrackUntrackLink_Atomic(t provider.T) {
+	ctx := context.Background()
+
+	repos := []struct {
+		name   string
+		repo   scrapper.Repository
+		chatID int64
+	}{
+		{name: "sql", repo: scrapper.NewSQL(s.pool), chatID: 1},
+		{name: "builder", repo: scrapper.NewBuilder(s.pool), chatID: 2},
+	}
+
+	for _, r := range repos {
+		t.Run(r.name, func(t provider.T) {
+			err := r.repo.RegisterChat(ctx, r.chatID)
+			require.NoError(t, err, "failed to register chat")
+
+			link := newTrackedLink(r.chatID)
+
+			restore := failStep(ctx, t, s, "INSERT", "links_filters")
+
+			_, err = r.repo.TrackLink(ctx, link)
+			require.Error(t, err, "track should fail on the last step")
+
+			assert.Zero(t, countRows(ctx, t, s, "links_chats", r.chatID), "link chat should be rolled back")
+			assert.Zero(t, countRows(ctx, t, s, "links_tags", r.chatID), "link tags should be rolled back")
+
+			restore()
+
+			_, err = r.repo.TrackLink(ctx, link)
+			require.NoError(t, err, "retry should succeed")
+
+			restore = failStep(ctx, t, s, "DELETE", "links_chats")
+
+			_, err = r.repo.UntrackLink(ctx, r.chatID, link.URL)
+			require.Error(t, err, "untrack should fail on the last step")
+
+			assert.Equal(t, 1, countRows(ctx, t, s, "links_tags", r.chatID), "link tags should be kept")
+			assert.Equal(t, 1, countRows(ctx, t, s, "links_filters", r.chatID), "link filters should be kept")
+
+			restore()
+
+			_, err = r.repo.UntrackLink(ctx, r.chatID, link.URL)
+			require.NoError(t, err, "retry should succeed")
+
+			assert.Zero(t, countRows(ctx, t, s, "links_chats", r.chatID), "link should be untracked")
+		})
+	}
+}
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE
+	link_states (
+		link_id BIGINT NOT NULL REFERENCES links (id) ON DELETE CASCADE,
+		checker TEXT NOT NULL,
+		state JSONB NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW (),
+		PRIMARY KEY (link_id, checker)
+	);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE link_states;
+-- +goose StatementEnd
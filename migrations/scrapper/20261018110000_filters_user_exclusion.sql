@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+-- "user=x" used to exclude events of the user. In filter expressions "=" is a
+-- synonym of ":", so such filters become explicit negations.
+INSERT INTO
+	filters (value)
+SELECT
+	'-user:' || SUBSTR(value, 6)
+FROM
+	filters
+WHERE
+	value LIKE 'user=%'
+ON CONFLICT (value) DO NOTHING;
+
+DELETE FROM links_filters lf USING filters src,
+filters dst,
+links_filters existing
+WHERE
+	lf.filter_id = src.id
+	AND src.value LIKE 'user=%'
+	AND dst.value = '-user:' || SUBSTR(src.value, 6)
+	AND existing.filter_id = dst.id
+	AND existing.link_id = lf.link_id
+	AND existing.chat_id = lf.chat_id;
+
+UPDATE links_filters lf
+SET
+	filter_id = dst.id
+FROM
+	filters src,
+	filters dst
+WHERE
+	lf.filter_id = src.id
+	AND src.value LIKE 'user=%'
+	AND dst.value = '-user:' || SUBSTR(src.value, 6);
+
+DELETE FROM filters
+WHERE
+	value LIKE 'user=%';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+INSERT INTO
+	filters (value)
+SELECT
+	'user=' || SUBSTR(value, 7)
+FROM
+	filters
+WHERE
+	value LIKE '-user:%'
+ON CONFLICT (value) DO NOTHING;
+
+DELETE FROM links_filters lf USING filters src,
+filters dst,
+links_filters existing
+WHERE
+	lf.filter_id = src.id
+	AND src.value LIKE '-user:%'
+	AND dst.value = 'user=' || SUBSTR(src.value, 7)
+	AND existing.filter_id = dst.id
+	AND existing.link_id = lf.link_id
+	AND existing.chat_id = lf.chat_id;
+
+UPDATE links_filters lf
+SET
+	filter_id = dst.id
+FROM
+	filters src,
+	filters dst
+WHERE
+	lf.filter_id = src.id
+	AND src.value LIKE '-user:%'
+	AND dst.value = 'user=' || SUBSTR(src.value, 7);
+
+DELETE FROM filters
+WHERE
+	value LIKE '-user:%';
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE links
+ADD COLUMN locked_by TEXT,
+ADD COLUMN lease_until TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE links
+DROP COLUMN locked_by,
+DROP COLUMN lease_until;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- check_interval of links is the adaptive interval of the last check, zero
+-- before the first one. check_interval of links_chats is pinned by the chat,
+-- zero keeps the adaptive interval.
+ALTER TABLE links
+ADD COLUMN next_check_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+ADD COLUMN check_interval INTERVAL NOT NULL DEFAULT INTERVAL '0';
+
+UPDATE links SET next_check_at = checked_at;
+
+CREATE INDEX idx_links_next_check_at ON links(next_check_at);
+
+ALTER TABLE links_chats
+ADD COLUMN check_interval INTERVAL NOT NULL DEFAULT INTERVAL '0';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE links_chats
+DROP COLUMN check_interval;
+
+DROP INDEX idx_links_next_check_at;
+
+ALTER TABLE links
+DROP COLUMN next_check_at,
+DROP COLUMN check_interval;
+-- +goose StatementEnd
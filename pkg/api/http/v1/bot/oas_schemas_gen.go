@@ -4,6 +4,7 @@ package bot
 
 import (
 	"net/url"
+	"time"
 )
 
 // Ref: #/components/schemas/ApiErrorResponse
@@ -67,13 +68,127 @@ func (s *ApiErrorResponse) SetStacktrace(val []string) {
 
 func (*ApiErrorResponse) updatesPostRes() {}
 
+// Ref: #/components/schemas/LinkEvent
+type LinkEvent struct {
+	Source     OptString              `json:"source"`
+	Kind       OptString              `json:"kind"`
+	ExternalID OptString              `json:"external_id"`
+	Author     OptString              `json:"author"`
+	Title      OptString              `json:"title"`
+	URL        OptString              `json:"url"`
+	Body       OptString              `json:"body"`
+	CreatedAt  OptDateTime            `json:"created_at"`
+	Attributes OptLinkEventAttributes `json:"attributes"`
+}
+
+// GetSource returns the value of Source.
+func (s *LinkEvent) GetSource() OptString {
+	return s.Source
+}
+
+// GetKind returns the value of Kind.
+func (s *LinkEvent) GetKind() OptString {
+	return s.Kind
+}
+
+// GetExternalID returns the value of ExternalID.
+func (s *LinkEvent) GetExternalID() OptString {
+	return s.ExternalID
+}
+
+// GetAuthor returns the value of Author.
+func (s *LinkEvent) GetAuthor() OptString {
+	return s.Author
+}
+
+// GetTitle returns the value of Title.
+func (s *LinkEvent) GetTitle() OptString {
+	return s.Title
+}
+
+// GetURL returns the value of URL.
+func (s *LinkEvent) GetURL() OptString {
+	return s.URL
+}
+
+// GetBody returns the value of Body.
+func (s *LinkEvent) GetBody() OptString {
+	return s.Body
+}
+
+// GetCreatedAt returns the value of CreatedAt.
+func (s *LinkEvent) GetCreatedAt() OptDateTime {
+	return s.CreatedAt
+}
+
+// GetAttributes returns the value of Attributes.
+func (s *LinkEvent) GetAttributes() OptLinkEventAttributes {
+	return s.Attributes
+}
+
+// SetSource sets the value of Source.
+func (s *LinkEvent) SetSource(val OptString) {
+	s.Source = val
+}
+
+// SetKind sets the value of Kind.
+func (s *LinkEvent) SetKind(val OptString) {
+	s.Kind = val
+}
+
+// SetExternalID sets the value of ExternalID.
+func (s *LinkEvent) SetExternalID(val OptString) {
+	s.ExternalID = val
+}
+
+// SetAuthor sets the value of Author.
+func (s *LinkEvent) SetAuthor(val OptString) {
+	s.Author = val
+}
+
+// SetTitle sets the value of Title.
+func (s *LinkEvent) SetTitle(val OptString) {
+	s.Title = val
+}
+
+// SetURL sets the value of URL.
+func (s *LinkEvent) SetURL(val OptString) {
+	s.URL = val
+}
+
+// SetBody sets the value of Body.
+func (s *LinkEvent) SetBody(val OptString) {
+	s.Body = val
+}
+
+// SetCreatedAt sets the value of CreatedAt.
+func (s *LinkEvent) SetCreatedAt(val OptDateTime) {
+	s.CreatedAt = val
+}
+
+// SetAttributes sets the value of Attributes.
+func (s *LinkEvent) SetAttributes(val OptLinkEventAttributes) {
+	s.Attributes = val
+}
+
+type LinkEventAttributes map[string]string
+
+func (s *LinkEventAttributes) init() LinkEventAttributes {
+	m := *s
+	if m == nil {
+		m = map[string]string{}
+		*s = m
+	}
+	return m
+}
+
 // Ref: #/components/schemas/LinkUpdate
 type LinkUpdate struct {
-	ChatID          OptInt64  `json:"chat_id"`
-	URL             OptURI    `json:"url"`
-	Message         OptString `json:"message"`
-	Tags            []string  `json:"tags"`
-	SendImmediately OptBool   `json:"send_immediately"`
+	ChatID          OptInt64     `json:"chat_id"`
+	URL             OptURI       `json:"url"`
+	Event           OptLinkEvent `json:"event"`
+	Tags            []string     `json:"tags"`
+	SendImmediately OptBool      `json:"send_immediately"`
 }
 
 // GetChatID returns the value of ChatID.
@@ -86,9 +201,9 @@ func (s *LinkUpdate) GetURL() OptURI {
 	return s.URL
 }
 
-// GetMessage returns the value of Message.
-func (s *LinkUpdate) GetMessage() OptString {
-	return s.Message
+// GetEvent returns the value of Event.
+func (s *LinkUpdate) GetEvent() OptLinkEvent {
+	return s.Event
 }
 
 // GetTags returns the value of Tags.
@@ -111,9 +226,9 @@ func (s *LinkUpdate) SetURL(val OptURI) {
 	s.URL = val
 }
 
-// SetMessage sets the value of Message.
-func (s *LinkUpdate) SetMessage(val OptString) {
-	s.Message = val
+// SetEvent sets the value of Event.
+func (s *LinkUpdate) SetEvent(val OptLinkEvent) {
+	s.Event = val
 }
 
 // SetTags sets the value of Tags.
@@ -172,6 +287,52 @@ func (o OptBool) Or(d bool) bool {
 	return d
 }
 
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
+	Set   bool
+}
+
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptDateTime) Reset() {
+	var v time.Time
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptDateTime) SetTo(v time.Time) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptDateTime) Get() (v time.Time, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptDateTime) Or(d time.Time) time.Time {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptInt64 returns new OptInt64 with value set to v.
 func NewOptInt64(v int64) OptInt64 {
 	return OptInt64{
@@ -218,6 +379,98 @@ func (o OptInt64) Or(d int64) int64 {
 	return d
 }
 
+// NewOptLinkEvent returns new OptLinkEvent with value set to v.
+func NewOptLinkEvent(v LinkEvent) OptLinkEvent {
+	return OptLinkEvent{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptLinkEvent is optional LinkEvent.
+type OptLinkEvent struct {
+	Value LinkEvent
+	Set   bool
+}
+
+// IsSet returns true if OptLinkEvent was set.
+func (o OptLinkEvent) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptLinkEvent) Reset() {
+	var v LinkEvent
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptLinkEvent) SetTo(v LinkEvent) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptLinkEvent) Get() (v LinkEvent, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptLinkEvent) Or(d LinkEvent) LinkEvent {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptLinkEventAttributes returns new OptLinkEventAttributes with value set to v.
+func NewOptLinkEventAttributes(v LinkEventAttributes) OptLinkEventAttributes {
+	return OptLinkEventAttributes{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptLinkEventAttributes is optional LinkEventAttributes.
+type OptLinkEventAttributes struct {
+	Value LinkEventAttributes
+	Set   bool
+}
+
+// IsSet returns true if OptLinkEventAttributes was set.
+func (o OptLinkEventAttributes) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptLinkEventAttributes) Reset() {
+	var v LinkEventAttributes
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptLinkEventAttributes) SetTo(v LinkEventAttributes) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptLinkEventAttributes) Get() (v LinkEventAttributes, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptLinkEventAttributes) Or(d LinkEventAttributes) LinkEventAttributes {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
 // NewOptString returns new OptString with value set to v.
 func NewOptString(v string) OptString {
 	return OptString{
@@ -27,6 +27,12 @@ func trimTrailingSlashes(u *url.URL) {
 
 // Invoker invokes operations described by OpenAPI v3 specification.
 type Invoker interface {
+	// LinksCheckPost invokes POST /links/check operation.
+	//
+	// Проверить ссылку вне расписания.
+	//
+	// POST /links/check
+	LinksCheckPost(ctx context.Context, request *CheckLinkRequest, params LinksCheckPostParams) (LinksCheckPostRes, error)
 	// LinksDelete invokes DELETE /links operation.
 	//
 	// Убрать отслеживание ссылки.
@@ -39,12 +45,24 @@ type Invoker interface {
 	//
 	// GET /links
 	LinksGet(ctx context.Context, params LinksGetParams) (LinksGetRes, error)
+	// LinksIntervalPut invokes PUT /links/interval operation.
+	//
+	// Закрепить интервал проверки ссылки.
+	//
+	// PUT /links/interval
+	LinksIntervalPut(ctx context.Context, request *SetLinkIntervalRequest, params LinksIntervalPutParams) (LinksIntervalPutRes, error)
 	// LinksPost invokes POST /links operation.
 	//
 	// Добавить отслеживание ссылки.
 	//
 	// POST /links
 	LinksPost(ctx context.Context, request *AddLinkRequest, params LinksPostParams) (LinksPostRes, error)
+	// LinksWebhookPut invokes PUT /links/webhook operation.
+	//
+	// Переключить получение обновлений ссылки на вебхуки.
+	//
+	// PUT /links/webhook
+	LinksWebhookPut(ctx context.Context, request *SetLinkWebhookRequest, params LinksWebhookPutParams) (LinksWebhookPutRes, error)
 	// TgChatIDDelete invokes DELETE /tg-chat/{id} operation.
 	//
 	// Удалить чат.
@@ -102,6 +120,94 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 	return u
 }
 
+// LinksCheckPost invokes POST /links/check operation.
+//
+// Проверить ссылку вне расписания.
+//
+// POST /links/check
+func (c *Client) LinksCheckPost(ctx context.Context, request *CheckLinkRequest, params LinksCheckPostParams) (LinksCheckPostRes, error) {
+	res, err := c.sendLinksCheckPost(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendLinksCheckPost(ctx context.Context, request *CheckLinkRequest, params LinksCheckPostParams) (res LinksCheckPostRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("POST"),
+		semconv.HTTPRouteKey.String("/links/check"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, LinksCheckPostOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/links/check"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "POST", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeLinksCheckPostRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.Int64ToString(params.TgChatID))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeLinksCheckPostResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // LinksDelete invokes DELETE /links operation.
 //
 // Убрать отслеживание ссылки.
@@ -296,6 +402,94 @@ func (c *Client) sendLinksGet(ctx context.Context, params LinksGetParams) (res L
 	return result, nil
 }
 
+// LinksIntervalPut invokes PUT /links/interval operation.
+//
+// Закрепить интервал проверки ссылки.
+//
+// PUT /links/interval
+func (c *Client) LinksIntervalPut(ctx context.Context, request *SetLinkIntervalRequest, params LinksIntervalPutParams) (LinksIntervalPutRes, error) {
+	res, err := c.sendLinksIntervalPut(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendLinksIntervalPut(ctx context.Context, request *SetLinkIntervalRequest, params LinksIntervalPutParams) (res LinksIntervalPutRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("PUT"),
+		semconv.HTTPRouteKey.String("/links/interval"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, LinksIntervalPutOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/links/interval"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PUT", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeLinksIntervalPutRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.Int64ToString(params.TgChatID))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeLinksIntervalPutResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // LinksPost invokes POST /links operation.
 //
 // Добавить отслеживание ссылки.
@@ -384,6 +578,94 @@ func (c *Client) sendLinksPost(ctx context.Context, request *AddLinkRequest, par
 	return result, nil
 }
 
+// LinksWebhookPut invokes PUT /links/webhook operation.
+//
+// Переключить получение обновлений ссылки на вебхуки.
+//
+// PUT /links/webhook
+func (c *Client) LinksWebhookPut(ctx context.Context, request *SetLinkWebhookRequest, params LinksWebhookPutParams) (LinksWebhookPutRes, error) {
+	res, err := c.sendLinksWebhookPut(ctx, request, params)
+	return res, err
+}
+
+func (c *Client) sendLinksWebhookPut(ctx context.Context, request *SetLinkWebhookRequest, params LinksWebhookPutParams) (res LinksWebhookPutRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String("PUT"),
+		semconv.HTTPRouteKey.String("/links/webhook"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, LinksWebhookPutOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [1]string
+	pathParts[0] = "/links/webhook"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "PUT", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+	if err := encodeLinksWebhookPutRequest(request, r); err != nil {
+		return res, errors.Wrap(err, "encode request")
+	}
+
+	stage = "EncodeHeaderParams"
+	h := uri.NewHeaderEncoder(r.Header)
+	{
+		cfg := uri.HeaderParameterEncodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+			return e.EncodeValue(conv.Int64ToString(params.TgChatID))
+		}); err != nil {
+			return res, errors.Wrap(err, "encode header")
+		}
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeLinksWebhookPutResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // TgChatIDDelete invokes DELETE /tg-chat/{id} operation.
 //
 // Удалить чат.
@@ -1,6 +1,10 @@
 // Code generated by ogen, DO NOT EDIT.
 package scrapper
 
+type LinksCheckPostRes interface {
+	linksCheckPostRes()
+}
+
 type LinksDeleteRes interface {
 	linksDeleteRes()
 }
@@ -9,10 +13,18 @@ type LinksGetRes interface {
 	linksGetRes()
 }
 
+type LinksIntervalPutRes interface {
+	linksIntervalPutRes()
+}
+
 type LinksPostRes interface {
 	linksPostRes()
 }
 
+type LinksWebhookPutRes interface {
+	linksWebhookPutRes()
+}
+
 type TgChatIDDeleteRes interface {
 	tgChatIDDeleteRes()
 }
@@ -6,9 +6,12 @@ package scrapper
 type OperationName = string
 
 const (
-	LinksDeleteOperation    OperationName = "LinksDelete"
-	LinksGetOperation       OperationName = "LinksGet"
-	LinksPostOperation      OperationName = "LinksPost"
-	TgChatIDDeleteOperation OperationName = "TgChatIDDelete"
-	TgChatIDPostOperation   OperationName = "TgChatIDPost"
+	LinksCheckPostOperation   OperationName = "LinksCheckPost"
+	LinksDeleteOperation      OperationName = "LinksDelete"
+	LinksGetOperation         OperationName = "LinksGet"
+	LinksIntervalPutOperation OperationName = "LinksIntervalPut"
+	LinksPostOperation        OperationName = "LinksPost"
+	LinksWebhookPutOperation  OperationName = "LinksWebhookPut"
+	TgChatIDDeleteOperation   OperationName = "TgChatIDDelete"
+	TgChatIDPostOperation     OperationName = "TgChatIDPost"
 )
@@ -15,6 +15,61 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
+// LinksCheckPostParams is parameters of POST /links/check operation.
+type LinksCheckPostParams struct {
+	TgChatID int64
+}
+
+func unpackLinksCheckPostParams(packed middleware.Parameters) (params LinksCheckPostParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+		}
+		params.TgChatID = packed[key].(int64)
+	}
+	return params
+}
+
+func decodeLinksCheckPostParams(args [0]string, argsEscaped bool, r *http.Request) (params LinksCheckPostParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: Tg-Chat-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToInt64(val)
+				if err != nil {
+					return err
+				}
+
+				params.TgChatID = c
+				return nil
+			}); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // LinksDeleteParams is parameters of DELETE /links operation.
 type LinksDeleteParams struct {
 	TgChatID int64
@@ -177,6 +232,61 @@ func decodeLinksGetParams(args [0]string, argsEscaped bool, r *http.Request) (pa
 	return params, nil
 }
 
+// LinksIntervalPutParams is parameters of PUT /links/interval operation.
+type LinksIntervalPutParams struct {
+	TgChatID int64
+}
+
+func unpackLinksIntervalPutParams(packed middleware.Parameters) (params LinksIntervalPutParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+		}
+		params.TgChatID = packed[key].(int64)
+	}
+	return params
+}
+
+func decodeLinksIntervalPutParams(args [0]string, argsEscaped bool, r *http.Request) (params LinksIntervalPutParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: Tg-Chat-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToInt64(val)
+				if err != nil {
+					return err
+				}
+
+				params.TgChatID = c
+				return nil
+			}); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // LinksPostParams is parameters of POST /links operation.
 type LinksPostParams struct {
 	TgChatID int64
@@ -232,6 +342,61 @@ func decodeLinksPostParams(args [0]string, argsEscaped bool, r *http.Request) (p
 	return params, nil
 }
 
+// LinksWebhookPutParams is parameters of PUT /links/webhook operation.
+type LinksWebhookPutParams struct {
+	TgChatID int64
+}
+
+func unpackLinksWebhookPutParams(packed middleware.Parameters) (params LinksWebhookPutParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+		}
+		params.TgChatID = packed[key].(int64)
+	}
+	return params
+}
+
+func decodeLinksWebhookPutParams(args [0]string, argsEscaped bool, r *http.Request) (params LinksWebhookPutParams, _ error) {
+	h := uri.NewHeaderDecoder(r.Header)
+	// Decode header: Tg-Chat-Id.
+	if err := func() error {
+		cfg := uri.HeaderParameterDecodingConfig{
+			Name:    "Tg-Chat-Id",
+			Explode: false,
+		}
+		if err := h.HasParam(cfg); err == nil {
+			if err := h.DecodeParam(cfg, func(d uri.Decoder) error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToInt64(val)
+				if err != nil {
+					return err
+				}
+
+				params.TgChatID = c
+				return nil
+			}); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "Tg-Chat-Id",
+			In:   "header",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // TgChatIDDeleteParams is parameters of DELETE /tg-chat/{id} operation.
 type TgChatIDDeleteParams struct {
 	ID int64
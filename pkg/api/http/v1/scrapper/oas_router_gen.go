@@ -70,7 +70,6 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				}
 
 				if len(elem) == 0 {
-					// Leaf node.
 					switch r.Method {
 					case "DELETE":
 						s.handleLinksDeleteRequest([0]string{}, elemIsEscaped, w, r)
@@ -84,6 +83,86 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 					return
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					origElem := elem
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "check"
+						origElem := elem
+						if l := len("check"); len(elem) >= l && elem[0:l] == "check" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "POST":
+								s.handleLinksCheckPostRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "POST")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 'i': // Prefix: "interval"
+						origElem := elem
+						if l := len("interval"); len(elem) >= l && elem[0:l] == "interval" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "PUT":
+								s.handleLinksIntervalPutRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "PUT")
+							}
+
+							return
+						}
+
+						elem = origElem
+					case 'w': // Prefix: "webhook"
+						origElem := elem
+						if l := len("webhook"); len(elem) >= l && elem[0:l] == "webhook" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "PUT":
+								s.handleLinksWebhookPutRequest([0]string{}, elemIsEscaped, w, r)
+							default:
+								s.notAllowed(w, r, "PUT")
+							}
+
+							return
+						}
+
+						elem = origElem
+					}
+
+					elem = origElem
+				}
 
 			case 't': // Prefix: "tg-chat/"
 
@@ -223,7 +302,6 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 				}
 
 				if len(elem) == 0 {
-					// Leaf node.
 					switch method {
 					case "DELETE":
 						r.name = LinksDeleteOperation
@@ -253,6 +331,98 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 						return
 					}
 				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					origElem := elem
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "check"
+						origElem := elem
+						if l := len("check"); len(elem) >= l && elem[0:l] == "check" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "POST":
+								r.name = LinksCheckPostOperation
+								r.summary = "Проверить ссылку вне расписания"
+								r.operationID = ""
+								r.pathPattern = "/links/check"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					case 'i': // Prefix: "interval"
+						origElem := elem
+						if l := len("interval"); len(elem) >= l && elem[0:l] == "interval" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "PUT":
+								r.name = LinksIntervalPutOperation
+								r.summary = "Закрепить интервал проверки ссылки"
+								r.operationID = ""
+								r.pathPattern = "/links/interval"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					case 'w': // Prefix: "webhook"
+						origElem := elem
+						if l := len("webhook"); len(elem) >= l && elem[0:l] == "webhook" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch method {
+							case "PUT":
+								r.name = LinksWebhookPutOperation
+								r.summary = "Переключить получение обновлений ссылки на вебхуки"
+								r.operationID = ""
+								r.pathPattern = "/links/webhook"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+
+						elem = origElem
+					}
+
+					elem = origElem
+				}
 
 			case 't': // Prefix: "tg-chat/"
 
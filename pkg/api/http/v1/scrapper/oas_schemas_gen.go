@@ -117,6 +117,39 @@ func (*ApiErrorResponse) linksGetRes()     {}
 func (*ApiErrorResponse) linksPostRes()    {}
 func (*ApiErrorResponse) tgChatIDPostRes() {}
 
+// Ref: #/components/schemas/CheckLinkRequest
+type CheckLinkRequest struct {
+	Link OptURI `json:"link"`
+}
+
+// GetLink returns the value of Link.
+func (s *CheckLinkRequest) GetLink() OptURI {
+	return s.Link
+}
+
+// SetLink sets the value of Link.
+func (s *CheckLinkRequest) SetLink(val OptURI) {
+	s.Link = val
+}
+
+// Ref: #/components/schemas/CheckLinkResponse
+type CheckLinkResponse struct {
+	// Число обновлений, отправленных в чат после его фильтров.
+	Updates OptInt32 `json:"updates"`
+}
+
+// GetUpdates returns the value of Updates.
+func (s *CheckLinkResponse) GetUpdates() OptInt32 {
+	return s.Updates
+}
+
+// SetUpdates sets the value of Updates.
+func (s *CheckLinkResponse) SetUpdates(val OptInt32) {
+	s.Updates = val
+}
+
+func (*CheckLinkResponse) linksCheckPostRes() {}
+
 // Ref: #/components/schemas/LinkResponse
 type LinkResponse struct {
 	ID              OptInt64 `json:"id"`
@@ -179,6 +212,23 @@ func (s *LinkResponse) SetSendImmediately(val OptBool) {
 func (*LinkResponse) linksDeleteRes() {}
 func (*LinkResponse) linksPostRes()   {}
 
+type LinksCheckPostBadRequest ApiErrorResponse
+
+func (*LinksCheckPostBadRequest) linksCheckPostRes() {}
+
+type LinksCheckPostConflict ApiErrorResponse
+
+func (*LinksCheckPostConflict) linksCheckPostRes() {}
+
+type LinksCheckPostNotFound ApiErrorResponse
+
+func (*LinksCheckPostNotFound) linksCheckPostRes() {}
+
+// LinksCheckPostTooManyRequests is response for LinksCheckPost operation.
+type LinksCheckPostTooManyRequests struct{}
+
+func (*LinksCheckPostTooManyRequests) linksCheckPostRes() {}
+
 type LinksDeleteBadRequest ApiErrorResponse
 
 func (*LinksDeleteBadRequest) linksDeleteRes() {}
@@ -197,11 +247,47 @@ type LinksGetTooManyRequests struct{}
 
 func (*LinksGetTooManyRequests) linksGetRes() {}
 
+type LinksIntervalPutBadRequest ApiErrorResponse
+
+func (*LinksIntervalPutBadRequest) linksIntervalPutRes() {}
+
+type LinksIntervalPutNotFound ApiErrorResponse
+
+func (*LinksIntervalPutNotFound) linksIntervalPutRes() {}
+
+// LinksIntervalPutOK is response for LinksIntervalPut operation.
+type LinksIntervalPutOK struct{}
+
+func (*LinksIntervalPutOK) linksIntervalPutRes() {}
+
+// LinksIntervalPutTooManyRequests is response for LinksIntervalPut operation.
+type LinksIntervalPutTooManyRequests struct{}
+
+func (*LinksIntervalPutTooManyRequests) linksIntervalPutRes() {}
+
 // LinksPostTooManyRequests is response for LinksPost operation.
 type LinksPostTooManyRequests struct{}
 
 func (*LinksPostTooManyRequests) linksPostRes() {}
 
+type LinksWebhookPutBadRequest ApiErrorResponse
+
+func (*LinksWebhookPutBadRequest) linksWebhookPutRes() {}
+
+type LinksWebhookPutNotFound ApiErrorResponse
+
+func (*LinksWebhookPutNotFound) linksWebhookPutRes() {}
+
+// LinksWebhookPutOK is response for LinksWebhookPut operation.
+type LinksWebhookPutOK struct{}
+
+func (*LinksWebhookPutOK) linksWebhookPutRes() {}
+
+// LinksWebhookPutTooManyRequests is response for LinksWebhookPut operation.
+type LinksWebhookPutTooManyRequests struct{}
+
+func (*LinksWebhookPutTooManyRequests) linksWebhookPutRes() {}
+
 // Ref: #/components/schemas/ListLinksResponse
 type ListLinksResponse struct {
 	Links []LinkResponse `json:"links"`
@@ -475,6 +561,62 @@ func (s *RemoveLinkRequest) SetLink(val OptURI) {
 	s.Link = val
 }
 
+// Ref: #/components/schemas/SetLinkIntervalRequest
+type SetLinkIntervalRequest struct {
+	Link OptURI `json:"link"`
+	// Интервал проверки, без него интервал подбирается
+	// автоматически.
+	IntervalSeconds OptInt64 `json:"interval_seconds"`
+}
+
+// GetLink returns the value of Link.
+func (s *SetLinkIntervalRequest) GetLink() OptURI {
+	return s.Link
+}
+
+// GetIntervalSeconds returns the value of IntervalSeconds.
+func (s *SetLinkIntervalRequest) GetIntervalSeconds() OptInt64 {
+	return s.IntervalSeconds
+}
+
+// SetLink sets the value of Link.
+func (s *SetLinkIntervalRequest) SetLink(val OptURI) {
+	s.Link = val
+}
+
+// SetIntervalSeconds sets the value of IntervalSeconds.
+func (s *SetLinkIntervalRequest) SetIntervalSeconds(val OptInt64) {
+	s.IntervalSeconds = val
+}
+
+// Ref: #/components/schemas/SetLinkWebhookRequest
+type SetLinkWebhookRequest struct {
+	Link OptURI `json:"link"`
+	// Обновления приходят из вебхуков, без него ссылка
+	// проверяется по расписанию.
+	Webhook OptBool `json:"webhook"`
+}
+
+// GetLink returns the value of Link.
+func (s *SetLinkWebhookRequest) GetLink() OptURI {
+	return s.Link
+}
+
+// GetWebhook returns the value of Webhook.
+func (s *SetLinkWebhookRequest) GetWebhook() OptBool {
+	return s.Webhook
+}
+
+// SetLink sets the value of Link.
+func (s *SetLinkWebhookRequest) SetLink(val OptURI) {
+	s.Link = val
+}
+
+// SetWebhook sets the value of Webhook.
+func (s *SetLinkWebhookRequest) SetWebhook(val OptBool) {
+	s.Webhook = val
+}
+
 type TgChatIDDeleteBadRequest ApiErrorResponse
 
 func (*TgChatIDDeleteBadRequest) tgChatIDDeleteRes() {}
@@ -8,6 +8,12 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
+	// LinksCheckPost implements POST /links/check operation.
+	//
+	// Проверить ссылку вне расписания.
+	//
+	// POST /links/check
+	LinksCheckPost(ctx context.Context, req *CheckLinkRequest, params LinksCheckPostParams) (LinksCheckPostRes, error)
 	// LinksDelete implements DELETE /links operation.
 	//
 	// Убрать отслеживание ссылки.
@@ -20,12 +26,24 @@ type Handler interface {
 	//
 	// GET /links
 	LinksGet(ctx context.Context, params LinksGetParams) (LinksGetRes, error)
+	// LinksIntervalPut implements PUT /links/interval operation.
+	//
+	// Закрепить интервал проверки ссылки.
+	//
+	// PUT /links/interval
+	LinksIntervalPut(ctx context.Context, req *SetLinkIntervalRequest, params LinksIntervalPutParams) (LinksIntervalPutRes, error)
 	// LinksPost implements POST /links operation.
 	//
 	// Добавить отслеживание ссылки.
 	//
 	// POST /links
 	LinksPost(ctx context.Context, req *AddLinkRequest, params LinksPostParams) (LinksPostRes, error)
+	// LinksWebhookPut implements PUT /links/webhook operation.
+	//
+	// Переключить получение обновлений ссылки на вебхуки.
+	//
+	// PUT /links/webhook
+	LinksWebhookPut(ctx context.Context, req *SetLinkWebhookRequest, params LinksWebhookPutParams) (LinksWebhookPutRes, error)
 	// TgChatIDDelete implements DELETE /tg-chat/{id} operation.
 	//
 	// Удалить чат.
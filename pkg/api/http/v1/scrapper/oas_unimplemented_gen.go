@@ -13,6 +13,15 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
+// LinksCheckPost implements POST /links/check operation.
+//
+// Проверить ссылку вне расписания.
+//
+// POST /links/check
+func (UnimplementedHandler) LinksCheckPost(ctx context.Context, req *CheckLinkRequest, params LinksCheckPostParams) (r LinksCheckPostRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // LinksDelete implements DELETE /links operation.
 //
 // Убрать отслеживание ссылки.
@@ -31,6 +40,15 @@ func (UnimplementedHandler) LinksGet(ctx context.Context, params LinksGetParams)
 	return r, ht.ErrNotImplemented
 }
 
+// LinksIntervalPut implements PUT /links/interval operation.
+//
+// Закрепить интервал проверки ссылки.
+//
+// PUT /links/interval
+func (UnimplementedHandler) LinksIntervalPut(ctx context.Context, req *SetLinkIntervalRequest, params LinksIntervalPutParams) (r LinksIntervalPutRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // LinksPost implements POST /links operation.
 //
 // Добавить отслеживание ссылки.
@@ -40,6 +58,15 @@ func (UnimplementedHandler) LinksPost(ctx context.Context, req *AddLinkRequest,
 	return r, ht.ErrNotImplemented
 }
 
+// LinksWebhookPut implements PUT /links/webhook operation.
+//
+// Переключить получение обновлений ссылки на вебхуки.
+//
+// PUT /links/webhook
+func (UnimplementedHandler) LinksWebhookPut(ctx context.Context, req *SetLinkWebhookRequest, params LinksWebhookPutParams) (r LinksWebhookPutRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // TgChatIDDelete implements DELETE /tg-chat/{id} operation.
 //
 // Удалить чат.
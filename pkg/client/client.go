@@ -1,9 +1,12 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"net"
 	"net/http"
 	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/client/config"
@@ -12,17 +15,31 @@ import (
 
 type Backoff = func(mn, mx time.Duration, attemptNum int, resp *http.Response) time.Duration
 
+type dialControl = func(network, address string, c syscall.RawConn) error
+
 type Client struct {
 	retry *retryablehttp.Client
 }
 
 func New(cfg *config.Config) *Client {
+	return newClient(cfg, nil)
+}
+
+// NewPublic connects to public addresses only, so links of users can't reach
+// the internal network. Addresses are checked after the resolution, as any
+// host may resolve to an internal address.
+func NewPublic(cfg *config.Config) *Client {
+	return newClient(cfg, publicOnly)
+}
+
+func newClient(cfg *config.Config, control dialControl) *Client {
 	retry := retryablehttp.NewClient()
 	retry.RetryMax = cfg.Retry.RetryMax
 	retry.RetryWaitMin = cfg.Retry.RetryWaitMin
 	retry.RetryWaitMax = cfg.Retry.RetryWaitMax
 	retry.Backoff = customBackoff(cfg.Retry.BackoffType)
-	retry.HTTPClient = configureHTTPClient(cfg)
+	retry.CheckRetry = checkRetry
+	retry.HTTPClient = configureHTTPClient(cfg, control)
 	retry.ErrorHandler = retryablehttp.PassthroughErrorHandler
 
 	return &Client{
@@ -39,6 +56,16 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	return c.retry.Do(retryReq)
 }
 
+// checkRetry doesn't retry connections to internal addresses, they are
+// rejected every time.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if errors.As(err, &ErrInternalAddress{}) {
+		return false, err
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
 func customBackoff(backoffType string) Backoff {
 	var backoff Backoff
 
@@ -68,12 +95,19 @@ func customBackoff(backoffType string) Backoff {
 	}
 }
 
-func configureHTTPClient(cfg *config.Config) *http.Client {
+func configureHTTPClient(cfg *config.Config, control dialControl) *http.Client {
+	proxy := http.ProxyFromEnvironment
+	if control != nil {
+		// the control would check the proxy instead of the target
+		proxy = nil
+	}
+
 	transport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
 			Timeout:   cfg.HTTPClient.DialTimeout,
 			KeepAlive: cfg.HTTPClient.DialKeepAlive,
+			Control:   control,
 		}).DialContext,
 		MaxIdleConns:          cfg.HTTPClient.MaxIdleConns,
 		IdleConnTimeout:       cfg.HTTPClient.IdleConnTimeout,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"testing"
 	"time"
 
@@ -372,3 +373,124 @@ func TestClient_Do_CircuitBreakerRecovers(t *testing.T) {
 		"expected 4th request in closed state",
 	)
 }
+
+func TestClient_Do_TimeoutsDontOpenCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("slow") {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.CircuitBreaker.MinRequests = 2
+	cfg.CircuitBreaker.ConsecutiveFailures = 2
+	c := client.New(cfg)
+
+	for range 3 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?slow", http.NoBody)
+		require.NoError(t, err, "failed to create request")
+
+		resp, err := c.Do(req)
+		if resp != nil {
+			assert.NoError(t, resp.Body.Close(), "failed to close response body")
+		}
+
+		cancel()
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "expected deadline error")
+	}
+
+	req, err := http.NewRequestWithContext(
+		context.Background(),
+		http.MethodGet,
+		server.URL,
+		http.NoBody,
+	)
+	require.NoError(t, err, "failed to create request")
+
+	resp, err := c.Do(req)
+	require.NoError(t, err, "circuit breaker shouldn't be open")
+
+	defer func() {
+		err := resp.Body.Close()
+		assert.NoError(t, err, "failed to close response body")
+	}()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "expected status code 200")
+}
+
+func TestClient_NewPublic_RejectsInternalAddress(t *testing.T) {
+	t.Parallel()
+
+	requestCount := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requestCount++
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.CircuitBreaker.MinRequests = 1
+	cfg.CircuitBreaker.ConsecutiveFailures = 1
+
+	c := client.NewPublic(cfg)
+
+	// more requests than the circuit breaker tolerates
+	for range 3 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err, "failed to create request")
+
+		resp, err := c.Do(req)
+		if resp != nil {
+			err := resp.Body.Close()
+			assert.NoError(t, err, "failed to close response body")
+		}
+
+		require.ErrorAs(t, err, &client.ErrInternalAddress{}, "expected internal address error")
+	}
+
+	assert.Zero(t, requestCount, "expected no requests to reach the server")
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.215.14", want: true},
+		{addr: "2606:4700::1111", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.1.1"},
+		{addr: "169.254.169.254"},
+		{addr: "100.64.0.1"},
+		{addr: "0.0.0.0"},
+		{addr: "fd00::1"},
+		{addr: "fe80::1"},
+		{addr: "::ffff:127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, client.IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
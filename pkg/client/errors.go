@@ -15,3 +15,17 @@ func NewErrUnexpectedStatusCode(code int) error {
 func (e ErrUnexpectedStatusCode) Error() string {
 	return fmt.Sprintf("Unexpected status code: %d", e.Code)
 }
+
+type ErrInternalAddress struct {
+	Address string
+}
+
+func NewErrInternalAddress(address string) error {
+	return ErrInternalAddress{
+		Address: address,
+	}
+}
+
+func (e ErrInternalAddress) Error() string {
+	return fmt.Sprintf("connection to internal address %s is not allowed", e.Address)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"net/netip"
+	"syscall"
+)
+
+// internalPrefixes are not covered by netip: "this network" and the shared
+// address space of carrier-grade NAT.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// IsPublicAddr reports whether the address is reachable from the internet:
+// loopback, private, link-local, multicast and unspecified addresses are not.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// publicOnly is the control of the dialer: it gets the resolved address, so a
+// public host resolving to an internal address is rejected too.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return NewErrInternalAddress(address)
+	}
+
+	if !IsPublicAddr(addrPort.Addr()) {
+		return NewErrInternalAddress(address)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -26,6 +27,7 @@ func NewTransport(cfg *config.CircuitBreaker, base http.RoundTripper) *CircuitBr
 			return counts.ConsecutiveFailures >= cfg.ConsecutiveFailures ||
 				float64(counts.TotalFailures)/float64(counts.Requests) > cfg.FailureRate
 		},
+		IsSuccessful: isSuccessful,
 	}
 	//nolint:bodyclose // nothing to close
 	cb := gobreaker.NewCircuitBreaker[*http.Response](cbSettings)
@@ -37,6 +39,10 @@ func NewTransport(cfg *config.CircuitBreaker, base http.RoundTripper) *CircuitBr
 }
 
 func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
 	res, err := t.cb.Execute(func() (*http.Response, error) {
 		resp, err := t.base.RoundTrip(req)
 		if err != nil {
@@ -55,3 +61,10 @@ func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response,
 
 	return res, nil
 }
+
+// isSuccessful doesn't count canceled requests, requests out of time and
+// rejected internal addresses as failures: they say nothing about the service.
+func isSuccessful(err error) bool {
+	return err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &ErrInternalAddress{})
+}